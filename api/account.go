@@ -4,16 +4,21 @@ import (
 	"database/sql"
 	"errors"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	db "github.com/gyu-young-park/simplebank/db/sqlc"
 	"github.com/gyu-young-park/simplebank/token"
+	"github.com/gyu-young-park/simplebank/util"
 	"github.com/lib/pq"
 )
 
 // CreateAccountParams와 같다. 단, 잔액은 처음부터 0이다. "binding:required"가 있어야 validation이 된다. oneof를 통해 이 중에 하나의 값인지를 체크한다.
 type createAccountRequest struct {
 	Currency string `json:"currency" binding:"required,currency"`
+	// 비어 있으면 checking 계좌를 만든다. 통화마다 계좌 종류별로 하나씩 만들 수 있다.
+	Type     string `json:"type" binding:"omitempty,oneof=checking savings business"`
+	Nickname string `json:"nickname" binding:"max=64"`
 }
 
 // gin은 git.Context를 통해서 input parameter를 받을 수 있고, 응답을 전송할 수 있다.
@@ -23,14 +28,19 @@ func (server *Server) createAccount(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err)) // http status code와 응답으로 보낼 json값을 보낸다. key-value값으로 보내면 gin이 알아서 json으로 직렬화 해준다.
 		return
 	}
+	if req.Type == "" {
+		req.Type = db.AccountTypeChecking
+	}
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 	arg := db.CreateAccountParams{
 		Owner:    authPayload.Username,
 		Currency: req.Currency,
 		Balance:  0,
+		Type:     req.Type,
+		Nickname: req.Nickname,
 	}
 
-	account, err := server.store.CreateAccount(ctx, arg)
+	account, err := server.store.CreateAccountTx(ctx, arg)
 	if err != nil {
 		if pqErr, ok := err.(*pq.Error); ok {
 			switch pqErr.Code.Name() {
@@ -39,7 +49,7 @@ func (server *Server) createAccount(ctx *gin.Context) {
 				return
 			}
 		}
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		respondTxError(ctx, err)
 		return
 	}
 	ctx.JSON(http.StatusOK, account)
@@ -64,10 +74,8 @@ func (server *Server) getAccount(ctx *gin.Context) {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
-	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-	if authPayload.Username != account.Owner {
-		err := errors.New("account doesn't belong to the authenticated user")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+	// joint와 viewer holder도 계좌를 볼 수 있다.
+	if _, ok := server.holderRole(ctx, account); !ok {
 		return
 	}
 	ctx.JSON(http.StatusOK, account)
@@ -76,6 +84,8 @@ func (server *Server) getAccount(ctx *gin.Context) {
 type listAccountsRequest struct {
 	PageID   int32 `form:"page_id" binding:"required,min=1"`
 	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+	// 비어 있으면 모든 종류의 계좌를 보여준다.
+	Type string `form:"type" binding:"omitempty,oneof=checking savings business"`
 }
 
 func (server *Server) listAccounts(ctx *gin.Context) {
@@ -85,10 +95,12 @@ func (server *Server) listAccounts(ctx *gin.Context) {
 		return
 	}
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	// 사용자가 holder로 있는 계좌를 모두 보여준다.
 	arg := db.ListAccountParams{
-		Owner:  authPayload.Username,
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
+		Username: authPayload.Username,
+		Type:     req.Type,
+		Limit:    req.PageSize,
+		Offset:   (req.PageID - 1) * req.PageSize,
 	}
 
 	accounts, err := server.store.ListAccount(ctx, arg)
@@ -98,3 +110,110 @@ func (server *Server) listAccounts(ctx *gin.Context) {
 	}
 	ctx.JSON(http.StatusOK, accounts)
 }
+
+type updateAccountRequest struct {
+	Nickname string `json:"nickname" binding:"max=64"`
+}
+
+// updateAccount renames an account. 빈 nickname은 이름을 지운다.
+func (server *Server) updateAccount(ctx *gin.Context) {
+	var uri getAccountRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req updateAccountRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	account, err := server.store.GetAccount(ctx, uri.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if authPayload.Username != account.Owner {
+		err := errors.New("account doesn't belong to the authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	account, err = server.store.UpdateAccountNickname(ctx, db.UpdateAccountNicknameParams{
+		ID:       account.ID,
+		Nickname: req.Nickname,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, account)
+}
+
+// 기존 클라이언트를 위해 금액은 최소 단위 숫자로 남겨두고, *_money에 소수 문자열과 함께 다시 보여준다.
+type accountBalanceResponse struct {
+	AccountID             int64      `json:"account_id"`
+	Currency              string     `json:"currency"`
+	Balance               int64      `json:"balance"`
+	HeldAmount            int64      `json:"held_amount"`
+	AvailableBalance      int64      `json:"available_balance"`
+	BalanceMoney          util.Money `json:"balance_money"`
+	HeldAmountMoney       util.Money `json:"held_amount_money"`
+	AvailableBalanceMoney util.Money `json:"available_balance_money"`
+}
+
+type getAccountBalanceQuery struct {
+	AsOf time.Time `form:"as_of" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+// getAccountBalance는 hold로 잡혀있는 금액을 뺀 사용 가능한 잔액도 함께 보여준다.
+// as_of가 있으면 그 시점의 잔액을 entries에서 계산해서 보여준다.
+func (server *Server) getAccountBalance(ctx *gin.Context) {
+	var req getAccountRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var query getAccountBalanceQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	account, err := server.store.GetAccount(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if _, ok := server.holderRole(ctx, account); !ok {
+		return
+	}
+
+	if !query.AsOf.IsZero() {
+		server.respondBalanceAt(ctx, account, query.AsOf)
+		return
+	}
+
+	held, err := server.store.GetHeldAmount(ctx, account.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, accountBalanceResponse{
+		AccountID:             account.ID,
+		Currency:              account.Currency,
+		Balance:               account.Balance,
+		HeldAmount:            held,
+		AvailableBalance:      account.Balance - held,
+		BalanceMoney:          util.NewMoney(account.Balance, account.Currency),
+		HeldAmountMoney:       util.NewMoney(held, account.Currency),
+		AvailableBalanceMoney: util.NewMoney(account.Balance-held, account.Currency),
+	})
+}
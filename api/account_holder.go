@@ -0,0 +1,288 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/gyu-young-park/simplebank/token"
+	"github.com/lib/pq"
+)
+
+// holderRole returns the role the authenticated user holds on the account, and responds with 401 if they hold none.
+// accounts.owner는 항상 primary holder이므로 따로 조회하지 않는다. 초대를 수락하지 않은 사용자는 holder가 아니다.
+func (server *Server) holderRole(ctx *gin.Context, account db.Account) (string, bool) {
+	role, err := server.accountRole(ctx, account)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return "", false
+	}
+	if role == "" {
+		err := errors.New("account doesn't belong to the authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return "", false
+	}
+	return role, true
+}
+
+// accountRole returns the authenticated user's role on the account, or "" if they are not an active holder.
+func (server *Server) accountRole(ctx *gin.Context, account db.Account) (string, error) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if account.Owner == authPayload.Username {
+		return db.AccountHolderRolePrimary, nil
+	}
+	holder, err := server.store.GetAccountHolderByUsername(ctx, db.GetAccountHolderByUsernameParams{
+		AccountID: account.ID,
+		Username:  authPayload.Username,
+	})
+	if err == sql.ErrNoRows || (err == nil && holder.Status != db.AccountHolderStatusActive) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return holder.Role, nil
+}
+
+// canTransferFrom checks that the authenticated user holds the account with a role that may move money out of it.
+func (server *Server) canTransferFrom(ctx *gin.Context, account db.Account) bool {
+	role, ok := server.holderRole(ctx, account)
+	if !ok {
+		return false
+	}
+	if !db.CanTransferFrom(role) {
+		err := errors.New("viewers cannot transfer from the account")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return false
+	}
+	return true
+}
+
+// ownAccount loads an account whose primary holder is the authenticated user.
+func (server *Server) ownAccount(ctx *gin.Context, accountID int64) (db.Account, bool) {
+	account, err := server.store.GetAccount(ctx, accountID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return account, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return account, false
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if account.Owner != authPayload.Username {
+		err := errors.New("account doesn't belong to the authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return account, false
+	}
+	return account, true
+}
+
+type inviteAccountHolderRequest struct {
+	Username string `json:"username" binding:"required,alphanum"`
+	Role     string `json:"role" binding:"required,oneof=joint viewer"`
+}
+
+// inviteAccountHolder invites another user to hold the account. 초대받은 사용자가 수락해야 holder가 된다.
+func (server *Server) inviteAccountHolder(ctx *gin.Context) {
+	var uri getAccountRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req inviteAccountHolderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	account, valid := server.ownAccount(ctx, uri.ID)
+	if !valid {
+		return
+	}
+	if account.Status == db.AccountStatusClosed {
+		ctx.JSON(http.StatusForbidden, errorResponse(db.ErrAccountNotActive))
+		return
+	}
+
+	holder, err := server.store.CreateAccountHolder(ctx, db.CreateAccountHolderParams{
+		AccountID: account.ID,
+		Username:  req.Username,
+		Role:      req.Role,
+		Status:    db.AccountHolderStatusInvited,
+		InvitedBy: sql.NullString{String: account.Owner, Valid: true},
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			// 없는 사용자이거나 이미 holder이거나 초대된 사용자이다.
+			case "foreign_key_violation", "unique_violation":
+				ctx.JSON(http.StatusForbidden, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, holder)
+}
+
+// listAccountHolders shows every holder and pending invitation of the account to its holders.
+func (server *Server) listAccountHolders(ctx *gin.Context) {
+	var uri getAccountRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	account, err := server.store.GetAccount(ctx, uri.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if _, ok := server.holderRole(ctx, account); !ok {
+		return
+	}
+
+	holders, err := server.store.ListAccountHolders(ctx, account.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, holders)
+}
+
+type accountHolderURI struct {
+	ID       int64  `uri:"id" binding:"required,min=1"`
+	Username string `uri:"username" binding:"required,alphanum"`
+}
+
+// removeAccountHolder removes a holder or cancels an invitation.
+// primary holder는 누구든 뺄 수 있고, 다른 holder는 자기 자신만 빠질 수 있다. primary holder는 빠질 수 없다.
+func (server *Server) removeAccountHolder(ctx *gin.Context) {
+	var uri accountHolderURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	account, err := server.store.GetAccount(ctx, uri.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if authPayload.Username != account.Owner && authPayload.Username != uri.Username {
+		err := errors.New("account doesn't belong to the authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	holder, err := server.store.GetAccountHolderByUsername(ctx, db.GetAccountHolderByUsernameParams{
+		AccountID: account.ID,
+		Username:  uri.Username,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if holder.Role == db.AccountHolderRolePrimary {
+		err := errors.New("the primary holder cannot be removed")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := server.store.DeleteAccountHolder(ctx, holder.ID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, holder)
+}
+
+// listAccountInvitations shows the invitations waiting for the authenticated user.
+func (server *Server) listAccountInvitations(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	invitations, err := server.store.ListAccountInvitations(ctx, authPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, invitations)
+}
+
+type accountInvitationURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// accountInvitation loads an invitation addressed to the authenticated user.
+func (server *Server) accountInvitation(ctx *gin.Context) (db.AccountHolder, bool) {
+	var uri accountInvitationURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return db.AccountHolder{}, false
+	}
+	invitation, err := server.store.GetAccountHolder(ctx, uri.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return invitation, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return invitation, false
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if invitation.Username != authPayload.Username {
+		err := errors.New("invitation doesn't belong to the authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return invitation, false
+	}
+	if invitation.Status != db.AccountHolderStatusInvited {
+		err := errors.New("invitation has already been accepted")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return invitation, false
+	}
+	return invitation, true
+}
+
+// acceptAccountInvitation makes the authenticated user a holder of the account they were invited to.
+func (server *Server) acceptAccountInvitation(ctx *gin.Context) {
+	invitation, valid := server.accountInvitation(ctx)
+	if !valid {
+		return
+	}
+	holder, err := server.store.AcceptAccountInvitation(ctx, invitation.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err := errors.New("invitation has already been accepted")
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, holder)
+}
+
+// declineAccountInvitation deletes an invitation addressed to the authenticated user.
+func (server *Server) declineAccountInvitation(ctx *gin.Context) {
+	invitation, valid := server.accountInvitation(ctx)
+	if !valid {
+		return
+	}
+	if err := server.store.DeleteAccountHolder(ctx, invitation.ID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, invitation)
+}
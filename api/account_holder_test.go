@@ -0,0 +1,238 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/gyu-young-park/simplebank/db/mock"
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/gyu-young-park/simplebank/util"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountHolderAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	partner, _ := randomUser(t)
+	other, _ := randomUser(t)
+	account := randomAccount(user.Username)
+	invitation := db.AccountHolder{
+		ID:        util.RandomInt(1, 1000),
+		AccountID: account.ID,
+		Username:  partner.Username,
+		Role:      db.AccountHolderRoleJoint,
+		Status:    db.AccountHolderStatusInvited,
+		InvitedBy: sql.NullString{String: user.Username, Valid: true},
+	}
+
+	testCases := []struct {
+		name          string
+		method        string
+		url           string
+		username      string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "Invite",
+			method:   http.MethodPost,
+			url:      fmt.Sprintf("/accounts/%d/holders", account.ID),
+			username: user.Username,
+			body:     gin.H{"username": partner.Username, "role": db.AccountHolderRoleJoint},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				arg := db.CreateAccountHolderParams{
+					AccountID: account.ID,
+					Username:  partner.Username,
+					Role:      db.AccountHolderRoleJoint,
+					Status:    db.AccountHolderStatusInvited,
+					InvitedBy: sql.NullString{String: user.Username, Valid: true},
+				}
+				store.EXPECT().CreateAccountHolder(gomock.Any(), gomock.Eq(arg)).Times(1).Return(invitation, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var holder db.AccountHolder
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &holder))
+				require.Equal(t, db.AccountHolderStatusInvited, holder.Status)
+			},
+		},
+		{
+			name:     "InvitePrimaryRole",
+			method:   http.MethodPost,
+			url:      fmt.Sprintf("/accounts/%d/holders", account.ID),
+			username: user.Username,
+			body:     gin.H{"username": partner.Username, "role": db.AccountHolderRolePrimary},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAccountHolder(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "InviteAlreadyHolder",
+			method:   http.MethodPost,
+			url:      fmt.Sprintf("/accounts/%d/holders", account.ID),
+			username: user.Username,
+			body:     gin.H{"username": partner.Username, "role": db.AccountHolderRoleViewer},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().CreateAccountHolder(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountHolder{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "OnlyPrimaryCanInvite",
+			method:   http.MethodPost,
+			url:      fmt.Sprintf("/accounts/%d/holders", account.ID),
+			username: partner.Username,
+			body:     gin.H{"username": other.Username, "role": db.AccountHolderRoleViewer},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().CreateAccountHolder(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:     "Accept",
+			method:   http.MethodPost,
+			url:      fmt.Sprintf("/account_invitations/%d/accept", invitation.ID),
+			username: partner.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountHolder(gomock.Any(), gomock.Eq(invitation.ID)).Times(1).Return(invitation, nil)
+				accepted := invitation
+				accepted.Status = db.AccountHolderStatusActive
+				store.EXPECT().AcceptAccountInvitation(gomock.Any(), gomock.Eq(invitation.ID)).Times(1).Return(accepted, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var holder db.AccountHolder
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &holder))
+				require.Equal(t, db.AccountHolderStatusActive, holder.Status)
+			},
+		},
+		{
+			name:     "AcceptSomeoneElsesInvitation",
+			method:   http.MethodPost,
+			url:      fmt.Sprintf("/account_invitations/%d/accept", invitation.ID),
+			username: other.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountHolder(gomock.Any(), gomock.Eq(invitation.ID)).Times(1).Return(invitation, nil)
+				store.EXPECT().AcceptAccountInvitation(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:     "Decline",
+			method:   http.MethodPost,
+			url:      fmt.Sprintf("/account_invitations/%d/decline", invitation.ID),
+			username: partner.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountHolder(gomock.Any(), gomock.Eq(invitation.ID)).Times(1).Return(invitation, nil)
+				store.EXPECT().DeleteAccountHolder(gomock.Any(), gomock.Eq(invitation.ID)).Times(1).Return(nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "ListInvitations",
+			method:   http.MethodGet,
+			url:      "/account_invitations",
+			username: partner.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListAccountInvitations(gomock.Any(), gomock.Eq(partner.Username)).Times(1).Return([]db.AccountHolder{invitation}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "HolderLeaves",
+			method:   http.MethodDelete,
+			url:      fmt.Sprintf("/accounts/%d/holders/%s", account.ID, partner.Username),
+			username: partner.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				arg := db.GetAccountHolderByUsernameParams{AccountID: account.ID, Username: partner.Username}
+				store.EXPECT().GetAccountHolderByUsername(gomock.Any(), gomock.Eq(arg)).Times(1).Return(invitation, nil)
+				store.EXPECT().DeleteAccountHolder(gomock.Any(), gomock.Eq(invitation.ID)).Times(1).Return(nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "CannotRemovePrimary",
+			method:   http.MethodDelete,
+			url:      fmt.Sprintf("/accounts/%d/holders/%s", account.ID, user.Username),
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				primary := db.AccountHolder{ID: 1, AccountID: account.ID, Username: user.Username, Role: db.AccountHolderRolePrimary}
+				store.EXPECT().GetAccountHolderByUsername(gomock.Any(), gomock.Any()).Times(1).Return(primary, nil)
+				store.EXPECT().DeleteAccountHolder(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "OtherUserCannotRemove",
+			method:   http.MethodDelete,
+			url:      fmt.Sprintf("/accounts/%d/holders/%s", account.ID, partner.Username),
+			username: other.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().DeleteAccountHolder(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			mockController := gomock.NewController(t)
+			defer mockController.Finish()
+
+			store := mockdb.NewMockStore(mockController)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			var body bytes.Buffer
+			if tc.body != nil {
+				require.NoError(t, json.NewEncoder(&body).Encode(tc.body))
+			}
+
+			request, err := http.NewRequest(tc.method, tc.url, &body)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.username, util.DepositorRole, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
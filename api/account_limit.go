@@ -0,0 +1,120 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/gyu-young-park/simplebank/token"
+	"github.com/gyu-young-park/simplebank/util"
+)
+
+type accountLimitResponse struct {
+	AccountID int64  `json:"account_id"`
+	Currency  string `json:"currency"`
+	util.TransferLimit
+}
+
+// getAccountLimit shows the limits in effect for the account to its owner or a banker.
+func (server *Server) getAccountLimit(ctx *gin.Context) {
+	var req getAccountRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	account, valid := server.getAccountForLimit(ctx, req.ID)
+	if !valid {
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if authPayload.Role != util.BankerRole {
+		if _, ok := server.holderRole(ctx, account); !ok {
+			return
+		}
+	}
+	server.respondAccountLimit(ctx, account)
+}
+
+// 0은 제한 없음을 뜻한다.
+type updateAccountLimitRequest struct {
+	MaxPerTransfer int64 `json:"max_per_transfer" binding:"min=0"`
+	MaxDailyAmount int64 `json:"max_daily_amount" binding:"min=0"`
+	MaxDailyCount  int32 `json:"max_daily_count" binding:"min=0"`
+}
+
+func (server *Server) updateAccountLimit(ctx *gin.Context) {
+	var uri getAccountRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req updateAccountLimitRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	account, valid := server.getAccountForLimit(ctx, uri.ID)
+	if !valid {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	_, err := server.store.UpsertAccountLimit(ctx, db.UpsertAccountLimitParams{
+		AccountID:      account.ID,
+		MaxPerTransfer: req.MaxPerTransfer,
+		MaxDailyAmount: req.MaxDailyAmount,
+		MaxDailyCount:  req.MaxDailyCount,
+		UpdatedBy:      authPayload.Username,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	server.respondAccountLimit(ctx, account)
+}
+
+// resetAccountLimit은 계좌별 한도를 지워서 통화 기본값으로 되돌린다.
+func (server *Server) resetAccountLimit(ctx *gin.Context) {
+	var req getAccountRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	account, valid := server.getAccountForLimit(ctx, req.ID)
+	if !valid {
+		return
+	}
+
+	if err := server.store.DeleteAccountLimit(ctx, account.ID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	server.respondAccountLimit(ctx, account)
+}
+
+func (server *Server) getAccountForLimit(ctx *gin.Context, accountID int64) (db.Account, bool) {
+	account, err := server.store.GetAccount(ctx, accountID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return account, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return account, false
+	}
+	return account, true
+}
+
+func (server *Server) respondAccountLimit(ctx *gin.Context, account db.Account) {
+	limit, err := server.store.GetTransferLimit(ctx, account)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, accountLimitResponse{
+		AccountID:     account.ID,
+		Currency:      account.Currency,
+		TransferLimit: limit,
+	})
+}
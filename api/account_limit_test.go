@@ -0,0 +1,171 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/gyu-young-park/simplebank/db/mock"
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/gyu-young-park/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountLimitAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	other, _ := randomUser(t)
+	banker, _ := randomUser(t)
+	account := randomAccount(user.Username)
+	limit := util.TransferLimit{
+		MaxPerTransfer: 100,
+		MaxDailyAmount: 1000,
+		MaxDailyCount:  10,
+	}
+
+	testCases := []struct {
+		name          string
+		method        string
+		username      string
+		role          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OwnerGet",
+			method:   http.MethodGet,
+			username: user.Username,
+			role:     util.DepositorRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetTransferLimit(gomock.Any(), gomock.Eq(account)).Times(1).Return(limit, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp accountLimitResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, account.ID, rsp.AccountID)
+				require.Equal(t, limit, rsp.TransferLimit)
+			},
+		},
+		{
+			name:     "OtherUserGet",
+			method:   http.MethodGet,
+			username: other.Username,
+			role:     util.DepositorRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountHolderByUsername(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountHolder{}, sql.ErrNoRows)
+				store.EXPECT().GetTransferLimit(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:     "BankerUpdate",
+			method:   http.MethodPut,
+			username: banker.Username,
+			role:     util.BankerRole,
+			body: gin.H{
+				"max_per_transfer": limit.MaxPerTransfer,
+				"max_daily_amount": limit.MaxDailyAmount,
+				"max_daily_count":  limit.MaxDailyCount,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				arg := db.UpsertAccountLimitParams{
+					AccountID:      account.ID,
+					MaxPerTransfer: limit.MaxPerTransfer,
+					MaxDailyAmount: limit.MaxDailyAmount,
+					MaxDailyCount:  limit.MaxDailyCount,
+					UpdatedBy:      banker.Username,
+				}
+				store.EXPECT().UpsertAccountLimit(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.AccountLimit{}, nil)
+				store.EXPECT().GetTransferLimit(gomock.Any(), gomock.Eq(account)).Times(1).Return(limit, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "DepositorCannotUpdate",
+			method:   http.MethodPut,
+			username: user.Username,
+			role:     util.DepositorRole,
+			body: gin.H{
+				"max_per_transfer": 0,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpsertAccountLimit(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "NegativeLimit",
+			method:   http.MethodPut,
+			username: banker.Username,
+			role:     util.BankerRole,
+			body: gin.H{
+				"max_per_transfer": -1,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpsertAccountLimit(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "BankerReset",
+			method:   http.MethodDelete,
+			username: banker.Username,
+			role:     util.BankerRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().DeleteAccountLimit(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(nil)
+				store.EXPECT().GetTransferLimit(gomock.Any(), gomock.Eq(account)).Times(1).Return(limit, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			mockController := gomock.NewController(t)
+			defer mockController.Finish()
+
+			store := mockdb.NewMockStore(mockController)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			var body bytes.Buffer
+			if tc.body != nil {
+				require.NoError(t, json.NewEncoder(&body).Encode(tc.body))
+			}
+
+			url := fmt.Sprintf("/accounts/%d/limits", account.ID)
+			request, err := http.NewRequest(tc.method, url, &body)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.username, tc.role, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/gyu-young-park/simplebank/token"
+)
+
+type changeAccountStatusRequest struct {
+	Reason string `json:"reason" binding:"required,max=255"`
+}
+
+// freezeAccount stops money moving in or out of an account until a banker unfreezes it.
+func (server *Server) freezeAccount(ctx *gin.Context) {
+	server.changeAccountStatus(ctx, db.AccountStatusFrozen)
+}
+
+// unfreezeAccount makes a frozen account active again.
+func (server *Server) unfreezeAccount(ctx *gin.Context) {
+	server.changeAccountStatus(ctx, db.AccountStatusActive)
+}
+
+func (server *Server) changeAccountStatus(ctx *gin.Context, status string) {
+	var uri getAccountRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req changeAccountStatusRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	result, err := server.store.ChangeAccountStatusTx(ctx, db.ChangeAccountStatusTxParams{
+		AccountID: uri.ID,
+		ToStatus:  status,
+		Reason:    req.Reason,
+		ChangedBy: authPayload.Username,
+	})
+	if err != nil {
+		respondAccountStatusError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, result)
+}
+
+// closeAccount closes one of the user's own accounts. 잔액과 hold가 0이어야 하고, 계좌는 지우지 않고 closed로 남긴다.
+func (server *Server) closeAccount(ctx *gin.Context) {
+	var uri getAccountRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	account, err := server.store.GetAccount(ctx, uri.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if authPayload.Username != account.Owner {
+		err := errors.New("account doesn't belong to the authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	result, err := server.store.ChangeAccountStatusTx(ctx, db.ChangeAccountStatusTxParams{
+		AccountID: account.ID,
+		ToStatus:  db.AccountStatusClosed,
+		Reason:    "closed by owner",
+		ChangedBy: authPayload.Username,
+	})
+	if err != nil {
+		respondAccountStatusError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, result)
+}
+
+func respondAccountStatusError(ctx *gin.Context, err error) {
+	switch {
+	case err == sql.ErrNoRows:
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+	case errors.Is(err, db.ErrInvalidAccountTransition):
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+	case errors.Is(err, db.ErrAccountNotEmpty):
+		ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+	default:
+		respondTxError(ctx, err)
+	}
+}
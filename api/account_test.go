@@ -11,11 +11,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
 	mockdb "github.com/gyu-young-park/simplebank/db/mock"
 	db "github.com/gyu-young-park/simplebank/db/sqlc"
 	"github.com/gyu-young-park/simplebank/token"
 	"github.com/gyu-young-park/simplebank/util"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 )
 
@@ -38,7 +40,7 @@ func TestGetAccountAPI(t *testing.T) {
 			store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
 		},
 		setAuth: func(t *testing.T, request *http.Request, tokenMaker token.TokenMaker) {
-			addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
 		},
 		checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 			require.Equal(t, http.StatusOK, recorder.Code)
@@ -53,7 +55,7 @@ func TestGetAccountAPI(t *testing.T) {
 			store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, sql.ErrNoRows)
 		},
 		setAuth: func(t *testing.T, request *http.Request, tokenMaker token.TokenMaker) {
-			addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
 		},
 		checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 			require.Equal(t, http.StatusNotFound, recorder.Code)
@@ -67,7 +69,7 @@ func TestGetAccountAPI(t *testing.T) {
 			store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, sql.ErrConnDone)
 		},
 		setAuth: func(t *testing.T, request *http.Request, tokenMaker token.TokenMaker) {
-			addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
 		},
 		checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 			require.Equal(t, http.StatusInternalServerError, recorder.Code)
@@ -81,14 +83,49 @@ func TestGetAccountAPI(t *testing.T) {
 			store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
 		},
 		setAuth: func(t *testing.T, request *http.Request, tokenMaker token.TokenMaker) {
-			addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
 		},
 		checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 			require.Equal(t, http.StatusBadRequest, recorder.Code)
 		},
 	}
 
-	testCase := []TestGetAccountAPISuite{okCase, notFoundCase, connectionErrCase, invalidIdCase}
+	viewer, _ := randomUser(t)
+	viewerCase := TestGetAccountAPISuite{
+		name:      "Viewer",
+		accountID: account.ID,
+		buildStubs: func(store *mockdb.MockStore) {
+			store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+			arg := db.GetAccountHolderByUsernameParams{AccountID: account.ID, Username: viewer.Username}
+			holder := db.AccountHolder{Role: db.AccountHolderRoleViewer, Status: db.AccountHolderStatusActive}
+			store.EXPECT().GetAccountHolderByUsername(gomock.Any(), gomock.Eq(arg)).Times(1).Return(holder, nil)
+		},
+		setAuth: func(t *testing.T, request *http.Request, tokenMaker token.TokenMaker) {
+			addAuthorization(t, request, tokenMaker, authorizationTypeBearer, viewer.Username, util.DepositorRole, time.Minute)
+		},
+		checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+			require.Equal(t, http.StatusOK, recorder.Code)
+			requreBodyMatchAccount(t, recorder.Body, account)
+		},
+	}
+
+	other, _ := randomUser(t)
+	notHolderCase := TestGetAccountAPISuite{
+		name:      "Not Holder",
+		accountID: account.ID,
+		buildStubs: func(store *mockdb.MockStore) {
+			store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+			store.EXPECT().GetAccountHolderByUsername(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountHolder{}, sql.ErrNoRows)
+		},
+		setAuth: func(t *testing.T, request *http.Request, tokenMaker token.TokenMaker) {
+			addAuthorization(t, request, tokenMaker, authorizationTypeBearer, other.Username, util.DepositorRole, time.Minute)
+		},
+		checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+			require.Equal(t, http.StatusUnauthorized, recorder.Code)
+		},
+	}
+
+	testCase := []TestGetAccountAPISuite{okCase, notFoundCase, connectionErrCase, invalidIdCase, viewerCase, notHolderCase}
 
 	for i := range testCase {
 		tc := testCase[i]
@@ -115,12 +152,164 @@ func TestGetAccountAPI(t *testing.T) {
 	}
 }
 
+func TestCreateAccountAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "DefaultsToChecking",
+			body: gin.H{"currency": util.USD},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateAccountParams{Owner: user.Username, Currency: util.USD, Type: db.AccountTypeChecking}
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Account{ID: 1, Type: arg.Type}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "BusinessWithNickname",
+			body: gin.H{"currency": util.EUR, "type": db.AccountTypeBusiness, "nickname": "shop"},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateAccountParams{Owner: user.Username, Currency: util.EUR, Type: db.AccountTypeBusiness, Nickname: "shop"}
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Account{ID: 1, Type: arg.Type, Nickname: arg.Nickname}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var account db.Account
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &account))
+				require.Equal(t, "shop", account.Nickname)
+			},
+		},
+		{
+			name: "DuplicateType",
+			body: gin.H{"currency": util.USD, "type": db.AccountTypeSavings},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Account{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidType",
+			body: gin.H{"currency": util.USD, "type": "premium"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			mockController := gomock.NewController(t)
+			defer mockController.Finish()
+
+			store := mockdb.NewMockStore(mockController)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/accounts", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestListAccountsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+	account.Type = db.AccountTypeSavings
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "All",
+			query: "page_id=1&page_size=5",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListAccountParams{Username: user.Username, Limit: 5}
+				store.EXPECT().ListAccount(gomock.Any(), gomock.Eq(arg)).Times(1).Return([]db.Account{account}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "FilterByType",
+			query: "page_id=2&page_size=5&type=savings",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListAccountParams{Username: user.Username, Type: db.AccountTypeSavings, Limit: 5, Offset: 5}
+				store.EXPECT().ListAccount(gomock.Any(), gomock.Eq(arg)).Times(1).Return([]db.Account{account}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var accounts []db.Account
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &accounts))
+				require.Equal(t, []db.Account{account}, accounts)
+			},
+		},
+		{
+			name:  "InvalidType",
+			query: "page_id=1&page_size=5&type=premium",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			mockController := gomock.NewController(t)
+			defer mockController.Finish()
+
+			store := mockdb.NewMockStore(mockController)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/accounts?"+tc.query, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
 func randomAccount(owner string) db.Account {
 	return db.Account{
 		ID:       util.RandomInt(1, 1000),
 		Owner:    owner,
 		Balance:  util.RandomMoney(),
 		Currency: util.RandomCurrency(),
+		Type:     db.AccountTypeChecking,
 	}
 }
 
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/gyu-young-park/simplebank/util"
+)
+
+// amountRequest takes an amount either in minor units or as a decimal string in the major unit of the currency.
+// amount_decimal은 "12.30"처럼 보내고, 통화의 소수 자리보다 정밀한 값은 거절한다.
+type amountRequest struct {
+	Amount        int64  `json:"amount" binding:"omitempty,gt=0"`
+	AmountDecimal string `json:"amount_decimal" binding:"omitempty,max=32"`
+}
+
+// resolve converts amount_decimal into minor units of the currency and stores it in Amount.
+func (req *amountRequest) resolve(currency string) error {
+	if (req.Amount != 0) == (req.AmountDecimal != "") {
+		return errors.New("exactly one of amount and amount_decimal is required")
+	}
+	if req.AmountDecimal == "" {
+		return nil
+	}
+	money, err := util.ParseMoney(req.AmountDecimal, currency)
+	if err != nil {
+		return err
+	}
+	if money.Amount <= 0 {
+		return errors.New("amount must be greater than zero")
+	}
+	req.Amount = money.Amount
+	return nil
+}
+
+// resolveOptional is resolve for requests that may leave the amount out, such as a full capture or reversal.
+func (req *amountRequest) resolveOptional(currency string) error {
+	if req.Amount == 0 && req.AmountDecimal == "" {
+		return nil
+	}
+	return req.resolve(currency)
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/gyu-young-park/simplebank/util"
+)
+
+// maxBalancePoints limits how many periods one balance history request may cover.
+const maxBalancePoints = 366
+
+type accountBalanceAtResponse struct {
+	AccountID    int64      `json:"account_id"`
+	Currency     string     `json:"currency"`
+	AsOf         time.Time  `json:"as_of"`
+	Balance      int64      `json:"balance"`
+	BalanceMoney util.Money `json:"balance_money"`
+}
+
+// respondBalanceAt shows the balance as of a past moment. 과거의 hold는 기록이 없으므로 held_amount는 보여주지 않는다.
+func (server *Server) respondBalanceAt(ctx *gin.Context, account db.Account, asOf time.Time) {
+	balance, err := server.store.AccountBalanceAt(ctx, db.AccountBalanceAtParams{
+		AccountID: account.ID,
+		AsOf:      asOf,
+	})
+	if err != nil {
+		respondTxError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, accountBalanceAtResponse{
+		AccountID:    account.ID,
+		Currency:     account.Currency,
+		AsOf:         asOf,
+		Balance:      balance,
+		BalanceMoney: util.NewMoney(balance, account.Currency),
+	})
+}
+
+type getBalanceHistoryRequest struct {
+	From     time.Time `form:"from" binding:"required" time_format:"2006-01-02" time_utc:"1"`
+	To       time.Time `form:"to" binding:"required" time_format:"2006-01-02" time_utc:"1"`
+	Interval string    `form:"interval" binding:"omitempty,oneof=day week month"`
+}
+
+type balanceHistoryResponse struct {
+	AccountID int64             `json:"account_id"`
+	Currency  string            `json:"currency"`
+	Interval  string            `json:"interval"`
+	Balances  []db.BalancePoint `json:"balances"`
+}
+
+// getBalanceHistory returns the balance at the end of each day, week or month between from and to, both in UTC.
+func (server *Server) getBalanceHistory(ctx *gin.Context) {
+	var uri getAccountRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req getBalanceHistoryRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.Interval == "" {
+		req.Interval = db.BalanceIntervalDay
+	}
+	if req.To.Before(req.From) {
+		err := errors.New("to must not be before from")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	periods, err := db.BalancePeriods(req.From, req.To, req.Interval)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if len(periods) > maxBalancePoints {
+		err := errors.New("balance history covers too many periods")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, err := server.store.GetAccount(ctx, uri.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if _, ok := server.holderRole(ctx, account); !ok {
+		return
+	}
+
+	balances, err := server.store.AccountBalanceHistory(ctx, db.AccountBalanceHistoryParams{
+		AccountID: account.ID,
+		From:      req.From,
+		To:        req.To,
+		Interval:  req.Interval,
+	})
+	if err != nil {
+		respondTxError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, balanceHistoryResponse{
+		AccountID: account.ID,
+		Currency:  account.Currency,
+		Interval:  req.Interval,
+		Balances:  balances,
+	})
+}
@@ -0,0 +1,204 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/gyu-young-park/simplebank/db/mock"
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/gyu-young-park/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountBalanceHistoryAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	other, _ := randomUser(t)
+	viewer, _ := randomUser(t)
+	account := randomAccount(user.Username)
+
+	asOf := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	from := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2022, 3, 3, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		url           string
+		username      string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "BalanceAsOf",
+			url:      fmt.Sprintf("/accounts/%d/balance?as_of=%s", account.ID, asOf.Format(time.RFC3339)),
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().
+					AccountBalanceAt(gomock.Any(), gomock.Eq(db.AccountBalanceAtParams{AccountID: account.ID, AsOf: asOf})).
+					Times(1).
+					Return(int64(70), nil)
+				store.EXPECT().GetHeldAmount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var response accountBalanceAtResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Equal(t, int64(70), response.Balance)
+				require.Equal(t, util.NewMoney(70, account.Currency), response.BalanceMoney)
+				require.True(t, asOf.Equal(response.AsOf))
+			},
+		},
+		{
+			name:     "CurrentBalance",
+			url:      fmt.Sprintf("/accounts/%d/balance", account.ID),
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				account := account
+				account.Balance = 1230
+				account.Currency = util.USD
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetHeldAmount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(int64(230), nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var response struct {
+					AvailableBalance      int64 `json:"available_balance"`
+					AvailableBalanceMoney struct {
+						Amount  int64  `json:"amount"`
+						Decimal string `json:"decimal"`
+					} `json:"available_balance_money"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Equal(t, int64(1000), response.AvailableBalance)
+				require.Equal(t, int64(1000), response.AvailableBalanceMoney.Amount)
+				require.Equal(t, "10.00", response.AvailableBalanceMoney.Decimal)
+			},
+		},
+		{
+			name:     "Viewer",
+			url:      fmt.Sprintf("/accounts/%d/balance", account.ID),
+			username: viewer.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				arg := db.GetAccountHolderByUsernameParams{AccountID: account.ID, Username: viewer.Username}
+				holder := db.AccountHolder{Role: db.AccountHolderRoleViewer, Status: db.AccountHolderStatusActive}
+				store.EXPECT().GetAccountHolderByUsername(gomock.Any(), gomock.Eq(arg)).Times(1).Return(holder, nil)
+				store.EXPECT().GetHeldAmount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(int64(0), nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "InvalidAsOf",
+			url:      fmt.Sprintf("/accounts/%d/balance?as_of=yesterday", account.ID),
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().AccountBalanceAt(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "History",
+			url:      fmt.Sprintf("/accounts/%d/balance_history?from=2022-03-01&to=2022-03-03&interval=day", account.ID),
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				arg := db.AccountBalanceHistoryParams{
+					AccountID: account.ID,
+					From:      from,
+					To:        to,
+					Interval:  db.BalanceIntervalDay,
+				}
+				store.EXPECT().
+					AccountBalanceHistory(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return([]db.BalancePoint{{PeriodStart: from, PeriodEnd: from.AddDate(0, 0, 1), Balance: 10}}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var response balanceHistoryResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Equal(t, db.BalanceIntervalDay, response.Interval)
+				require.Len(t, response.Balances, 1)
+			},
+		},
+		{
+			name:     "InvalidInterval",
+			url:      fmt.Sprintf("/accounts/%d/balance_history?from=2022-03-01&to=2022-03-03&interval=hour", account.ID),
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().AccountBalanceHistory(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "ToBeforeFrom",
+			url:      fmt.Sprintf("/accounts/%d/balance_history?from=2022-03-03&to=2022-03-01", account.ID),
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().AccountBalanceHistory(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "TooManyPeriods",
+			url:      fmt.Sprintf("/accounts/%d/balance_history?from=2020-01-01&to=2022-01-01", account.ID),
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().AccountBalanceHistory(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "OtherUser",
+			url:      fmt.Sprintf("/accounts/%d/balance_history?from=2022-03-01&to=2022-03-03", account.ID),
+			username: other.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountHolderByUsername(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountHolder{}, sql.ErrNoRows)
+				store.EXPECT().AccountBalanceHistory(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			mockController := gomock.NewController(t)
+			defer mockController.Finish()
+
+			store := mockdb.NewMockStore(mockController)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, tc.url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.username, util.DepositorRole, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
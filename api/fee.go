@@ -0,0 +1,87 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/gyu-young-park/simplebank/token"
+	"github.com/lib/pq"
+)
+
+func (server *Server) listFeeSchedules(ctx *gin.Context) {
+	schedules, err := server.store.ListFeeSchedules(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, schedules)
+}
+
+// max_amount가 0이면 상한이 없다.
+type upsertFeeScheduleRequest struct {
+	Product       string `json:"product" binding:"required"`
+	Currency      string `json:"currency" binding:"required,currency"`
+	Operation     string `json:"operation" binding:"required,oneof=transfer maintenance"`
+	FlatAmount    int64  `json:"flat_amount" binding:"min=0"`
+	PercentageBps int32  `json:"percentage_bps" binding:"min=0,max=10000"`
+	MinAmount     int64  `json:"min_amount" binding:"min=0"`
+	MaxAmount     int64  `json:"max_amount" binding:"min=0"`
+}
+
+// upsertFeeSchedule sets the fee of one product, currency and operation. 다음 거래부터 적용된다.
+func (server *Server) upsertFeeSchedule(ctx *gin.Context) {
+	var req upsertFeeScheduleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	schedule, err := server.store.UpsertFeeSchedule(ctx, db.UpsertFeeScheduleParams{
+		Product:       req.Product,
+		Currency:      req.Currency,
+		Operation:     req.Operation,
+		FlatAmount:    req.FlatAmount,
+		PercentageBps: req.PercentageBps,
+		MinAmount:     req.MinAmount,
+		MaxAmount:     req.MaxAmount,
+		UpdatedBy:     authPayload.Username,
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "foreign_key_violation":
+				ctx.JSON(http.StatusForbidden, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, schedule)
+}
+
+type deleteFeeScheduleRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) deleteFeeSchedule(ctx *gin.Context) {
+	var req deleteFeeScheduleRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	schedule, err := server.store.DeleteFeeSchedule(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, schedule)
+}
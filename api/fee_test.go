@@ -0,0 +1,132 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/gyu-young-park/simplebank/db/mock"
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/gyu-young-park/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeeScheduleAPI(t *testing.T) {
+	banker, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		method        string
+		url           string
+		role          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Upsert",
+			method: http.MethodPut,
+			url:    "/fee_schedules",
+			role:   util.BankerRole,
+			body: gin.H{
+				"product":        db.AccountTypeChecking,
+				"currency":       util.USD,
+				"operation":      db.FeeOperationTransfer,
+				"flat_amount":    25,
+				"percentage_bps": 10,
+				"max_amount":     500,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpsertFeeScheduleParams{
+					Product:       db.AccountTypeChecking,
+					Currency:      util.USD,
+					Operation:     db.FeeOperationTransfer,
+					FlatAmount:    25,
+					PercentageBps: 10,
+					MaxAmount:     500,
+					UpdatedBy:     banker.Username,
+				}
+				store.EXPECT().UpsertFeeSchedule(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.FeeSchedule{ID: 1}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "InvalidOperation",
+			method: http.MethodPut,
+			url:    "/fee_schedules",
+			role:   util.BankerRole,
+			body: gin.H{
+				"product":   db.AccountTypeChecking,
+				"currency":  util.USD,
+				"operation": "fx",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpsertFeeSchedule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "DepositorCannotUpsert",
+			method: http.MethodPut,
+			url:    "/fee_schedules",
+			role:   util.DepositorRole,
+			body: gin.H{
+				"product":   db.AccountTypeChecking,
+				"currency":  util.USD,
+				"operation": db.FeeOperationTransfer,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpsertFeeSchedule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "DeleteNotFound",
+			method: http.MethodDelete,
+			url:    "/fee_schedules/7",
+			role:   util.BankerRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteFeeSchedule(gomock.Any(), gomock.Eq(int64(7))).Times(1).Return(db.FeeSchedule{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			mockController := gomock.NewController(t)
+			defer mockController.Finish()
+
+			store := mockdb.NewMockStore(mockController)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(tc.method, tc.url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, banker.Username, tc.role, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
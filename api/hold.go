@@ -0,0 +1,183 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/gyu-young-park/simplebank/token"
+)
+
+type authorizeHoldRequest struct {
+	AccountID   int64  `json:"account_id" binding:"required,min=1"`
+	ToAccountID int64  `json:"to_account_id" binding:"required,min=1"`
+	Currency    string `json:"currency" binding:"required,currency"`
+	amountRequest
+}
+
+// authorizeHold reserves funds on the caller's account for a later capture by the recipient.
+func (server *Server) authorizeHold(ctx *gin.Context) {
+	var req authorizeHoldRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := req.resolve(req.Currency); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	account, valid := server.validAccount(ctx, req.AccountID, req.Currency)
+	if !valid {
+		return
+	}
+	if !server.canTransferFrom(ctx, account) {
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	toAccount, valid := server.validAccount(ctx, req.ToAccountID, req.Currency)
+	if !valid {
+		return
+	}
+	if !server.checkCoolingOff(ctx, authPayload.Username, account, toAccount.ID, toAccount.Owner, req.Amount, time.Now()) {
+		return
+	}
+
+	hold, err := server.store.AuthorizeHoldTx(ctx, db.AuthorizeHoldTxParams{
+		AccountID:   req.AccountID,
+		ToAccountID: req.ToAccountID,
+		Amount:      req.Amount,
+		ExpiresAt:   time.Now().Add(server.config.HoldDuration),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrInsufficientFunds):
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		case errors.Is(err, db.ErrApprovalRequired), errors.Is(err, db.ErrAccountNotActive):
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		respondTxError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, hold)
+}
+
+type holdURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// amount를 생략하면 hold 금액 전부를 capture한다.
+type captureHoldRequest struct {
+	amountRequest
+}
+
+// captureHoldResponse shows the capture to the hold's recipient. 결제한 사람의 계좌 정보는 보여주지 않는다.
+type captureHoldResponse struct {
+	Hold      db.AccountHold   `json:"hold"`
+	Transfer  transferResponse `json:"transfer"`
+	ToAccount db.Account       `json:"to_account"`
+	ToEntry   db.Entry         `json:"to_entry"`
+}
+
+func (server *Server) captureHold(ctx *gin.Context) {
+	var uri holdURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req captureHoldRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil && err != io.EOF {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	hold, toAccount, valid := server.recipientHold(ctx, uri.ID)
+	if !valid {
+		return
+	}
+	if err := req.resolveOptional(toAccount.Currency); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	result, err := server.store.CaptureHoldTx(ctx, db.CaptureHoldTxParams{
+		HoldID: hold.ID,
+		Amount: req.Amount,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrHoldNotActive), errors.Is(err, db.ErrCaptureExceedsHold):
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		case errors.Is(err, db.ErrInsufficientFunds):
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		case errors.Is(err, db.ErrTransferLimitExceeded), errors.Is(err, db.ErrApprovalRequired), errors.Is(err, db.ErrAccountNotActive):
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		respondTxError(ctx, err)
+		return
+	}
+
+	fromAccount, toAccount := result.FromAccount, result.ToAccount
+	ctx.JSON(http.StatusOK, captureHoldResponse{
+		Hold:      result.Hold,
+		Transfer:  newTransferResponse(toAccount.ID, result.Transfer, fromAccount.Owner, toAccount.Owner),
+		ToAccount: toAccount,
+		ToEntry:   result.ToEntry,
+	})
+}
+
+func (server *Server) voidHold(ctx *gin.Context) {
+	var uri holdURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	hold, _, valid := server.recipientHold(ctx, uri.ID)
+	if !valid {
+		return
+	}
+
+	hold, err := server.store.VoidHoldTx(ctx, hold.ID)
+	if err != nil {
+		if errors.Is(err, db.ErrHoldNotActive) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		respondTxError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, hold)
+}
+
+// hold는 돈을 받을 쪽(가맹점)만 capture하거나 void할 수 있다. 받는 계좌도 함께 돌려준다.
+func (server *Server) recipientHold(ctx *gin.Context, holdID int64) (db.AccountHold, db.Account, bool) {
+	hold, err := server.store.GetAccountHold(ctx, holdID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return hold, db.Account{}, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return hold, db.Account{}, false
+	}
+
+	toAccount, err := server.store.GetAccount(ctx, hold.ToAccountID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return hold, toAccount, false
+	}
+	// hold는 받는 계좌에서 이체할 수 있는 holder만 정산할 수 있다.
+	if !server.canTransferFrom(ctx, toAccount) {
+		return hold, toAccount, false
+	}
+	return hold, toAccount, true
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/gyu-young-park/simplebank/token"
+	"github.com/gyu-young-park/simplebank/util"
+)
+
+// 계좌 이자 조회에서 보여 주는 최근 지급 기록의 수
+const interestPostingsShown = 12
+
+func (server *Server) listAccountProducts(ctx *gin.Context) {
+	products, err := server.store.ListAccountProducts(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, products)
+}
+
+type updateAccountProductUri struct {
+	Code string `uri:"code" binding:"required"`
+}
+
+type updateAccountProductRequest struct {
+	// basis point 단위. 10000이 연 100%이다.
+	AnnualRateBps int32  `json:"annual_rate_bps" binding:"min=0,max=10000"`
+	DayCount      string `json:"day_count" binding:"required,oneof=ACT/365 ACT/360 ACT/ACT 30/360"`
+}
+
+// updateAccountProduct changes the interest rate of a product. 이미 쌓인 이자는 바뀌지 않고 다음 날부터 새 이자율이 적용된다.
+func (server *Server) updateAccountProduct(ctx *gin.Context) {
+	var uri updateAccountProductUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req updateAccountProductRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	product, err := server.store.UpdateAccountProduct(ctx, db.UpdateAccountProductParams{
+		Code:          uri.Code,
+		AnnualRateBps: req.AnnualRateBps,
+		DayCount:      req.DayCount,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, product)
+}
+
+type accountInterestResponse struct {
+	AccountID     int64  `json:"account_id"`
+	Type          string `json:"type"`
+	AnnualRateBps int32  `json:"annual_rate_bps"`
+	DayCount      string `json:"day_count"`
+	// 아직 지급되지 않은 이자. 최소 단위의 1/1000000 단위이다.
+	AccruedMicros int64                `json:"accrued_micros"`
+	Postings      []db.InterestPosting `json:"postings"`
+}
+
+// getAccountInterest shows the rate, the unpaid accrued interest and the latest interest payments of an account.
+func (server *Server) getAccountInterest(ctx *gin.Context) {
+	var req getAccountRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	account, err := server.store.GetAccount(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if authPayload.Role != util.BankerRole {
+		if _, ok := server.holderRole(ctx, account); !ok {
+			return
+		}
+	}
+
+	product, err := server.store.GetAccountProduct(ctx, account.Type)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	postings, err := server.store.ListInterestPostings(ctx, db.ListInterestPostingsParams{
+		AccountID: account.ID,
+		Limit:     interestPostingsShown,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	accrued, err := server.store.SumUnpostedInterestAccruals(ctx, db.SumUnpostedInterestAccrualsParams{
+		AccountID: account.ID,
+		Before:    time.Now().AddDate(0, 0, 1),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	// 지난 지급에서 넘어온 나머지도 다음 지급에 포함된다.
+	if len(postings) > 0 {
+		accrued += postings[0].CarryMicros
+	}
+
+	ctx.JSON(http.StatusOK, accountInterestResponse{
+		AccountID:     account.ID,
+		Type:          account.Type,
+		AnnualRateBps: product.AnnualRateBps,
+		DayCount:      product.DayCount,
+		AccruedMicros: accrued,
+		Postings:      postings,
+	})
+}
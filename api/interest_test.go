@@ -0,0 +1,195 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/gyu-young-park/simplebank/db/mock"
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/gyu-young-park/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAccountInterestAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	other, _ := randomUser(t)
+	account := randomAccount(user.Username)
+	account.Type = db.AccountTypeSavings
+	product := db.AccountProduct{Code: db.AccountTypeSavings, AnnualRateBps: 150, DayCount: util.DayCountActual365}
+
+	testCases := []struct {
+		name          string
+		username      string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountProduct(gomock.Any(), gomock.Eq(db.AccountTypeSavings)).Times(1).Return(product, nil)
+				store.EXPECT().
+					ListInterestPostings(gomock.Any(), gomock.Eq(db.ListInterestPostingsParams{AccountID: account.ID, Limit: interestPostingsShown})).
+					Times(1).
+					Return([]db.InterestPosting{{AccountID: account.ID, Period: "2022-02", Amount: 12, CarryMicros: 300}}, nil)
+				store.EXPECT().SumUnpostedInterestAccruals(gomock.Any(), gomock.Any()).Times(1).Return(int64(5000000), nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var rsp accountInterestResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, int32(150), rsp.AnnualRateBps)
+				require.Equal(t, int64(5000300), rsp.AccruedMicros)
+				require.Len(t, rsp.Postings, 1)
+			},
+		},
+		{
+			name:     "UnauthorizedUser",
+			username: other.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountHolderByUsername(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountHolder{}, sql.ErrNoRows)
+				store.EXPECT().GetAccountProduct(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:     "NotFound",
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(db.Account{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			mockController := gomock.NewController(t)
+			defer mockController.Finish()
+
+			store := mockdb.NewMockStore(mockController)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/accounts/%d/interest", account.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.username, util.DepositorRole, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestUpdateAccountProductAPI(t *testing.T) {
+	banker, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		code          string
+		role          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			code: db.AccountTypeSavings,
+			role: util.BankerRole,
+			body: gin.H{"annual_rate_bps": 200, "day_count": util.DayCount30360},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateAccountProductParams{
+					Code:          db.AccountTypeSavings,
+					AnnualRateBps: 200,
+					DayCount:      util.DayCount30360,
+				}
+				store.EXPECT().
+					UpdateAccountProduct(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.AccountProduct{Code: arg.Code, AnnualRateBps: arg.AnnualRateBps, DayCount: arg.DayCount}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidDayCount",
+			code: db.AccountTypeSavings,
+			role: util.BankerRole,
+			body: gin.H{"annual_rate_bps": 200, "day_count": "ACT/364"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateAccountProduct(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			code: "gold",
+			role: util.BankerRole,
+			body: gin.H{"annual_rate_bps": 200, "day_count": util.DayCountActual365},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateAccountProduct(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountProduct{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "DepositorCannotUpdate",
+			code: db.AccountTypeSavings,
+			role: util.DepositorRole,
+			body: gin.H{"annual_rate_bps": 200, "day_count": util.DayCountActual365},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateAccountProduct(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			mockController := gomock.NewController(t)
+			defer mockController.Finish()
+
+			store := mockdb.NewMockStore(mockController)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/account_products/%s", tc.code)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, banker.Username, tc.role, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/gyu-young-park/simplebank/token"
+	"github.com/gyu-young-park/simplebank/util"
 )
 
 const (
@@ -50,3 +51,16 @@ func authMiddleware(tokenMaker token.TokenMaker) gin.HandlerFunc {
 		ctx.Next() // middleware 다음으로 넘어간다.
 	}
 }
+
+// bankerMiddleware는 authMiddleware 뒤에 와야 한다. banker role이 아닌 사용자는 막는다.
+func bankerMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+		if authPayload.Role != util.BankerRole {
+			err := errors.New("only bankers can access this resource")
+			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.Next()
+	}
+}
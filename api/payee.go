@@ -0,0 +1,245 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/gyu-young-park/simplebank/token"
+	"github.com/lib/pq"
+)
+
+type createPayeeRequest struct {
+	Nickname string `json:"nickname" binding:"required,max=64"`
+	recipientRequest
+	Currency string `json:"currency" binding:"required,currency"`
+}
+
+// payeeResponse는 payee의 계좌 ID 대신 받는 사람의 username을 보여준다.
+type payeeResponse struct {
+	ID        int64     `json:"id"`
+	Nickname  string    `json:"nickname"`
+	Recipient string    `json:"recipient"`
+	Currency  string    `json:"currency"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func newPayeeResponse(payee db.Payee) payeeResponse {
+	return payeeResponse{
+		ID:        payee.ID,
+		Nickname:  payee.Nickname,
+		Recipient: payee.Recipient,
+		Currency:  payee.Currency,
+		CreatedAt: payee.CreatedAt,
+	}
+}
+
+func (server *Server) createPayee(ctx *gin.Context) {
+	var req createPayeeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	account, valid := server.recipientAccount(ctx, req.recipientRequest, req.Currency)
+	if !valid {
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if account.Owner == authPayload.Username {
+		err := errors.New("cannot add your own account as a payee")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	payee, err := server.store.CreatePayee(ctx, db.CreatePayeeParams{
+		Owner:     authPayload.Username,
+		Nickname:  req.Nickname,
+		AccountID: account.ID,
+		Recipient: account.Owner,
+		Currency:  account.Currency,
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "foreign_key_violation", "unique_violation":
+				ctx.JSON(http.StatusForbidden, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, newPayeeResponse(payee))
+}
+
+type payeeURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getPayee(ctx *gin.Context) {
+	var uri payeeURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	payee, valid := server.ownPayee(ctx, uri.ID)
+	if !valid {
+		return
+	}
+	ctx.JSON(http.StatusOK, newPayeeResponse(payee))
+}
+
+type listPayeesRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+func (server *Server) listPayees(ctx *gin.Context) {
+	var req listPayeesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	payees, err := server.store.ListPayees(ctx, db.ListPayeesParams{
+		Owner:  authPayload.Username,
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := make([]payeeResponse, len(payees))
+	for i, payee := range payees {
+		rsp[i] = newPayeeResponse(payee)
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// 받는 계좌를 바꾸려면 payee를 지우고 다시 만들어야 한다. 그래야 cooling-off 기간이 다시 적용된다.
+type updatePayeeRequest struct {
+	Nickname string `json:"nickname" binding:"required,max=64"`
+}
+
+func (server *Server) updatePayee(ctx *gin.Context) {
+	var uri payeeURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req updatePayeeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	payee, valid := server.ownPayee(ctx, uri.ID)
+	if !valid {
+		return
+	}
+
+	payee, err := server.store.UpdatePayee(ctx, db.UpdatePayeeParams{
+		ID:       payee.ID,
+		Nickname: req.Nickname,
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "unique_violation":
+				ctx.JSON(http.StatusForbidden, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, newPayeeResponse(payee))
+}
+
+func (server *Server) deletePayee(ctx *gin.Context) {
+	var uri payeeURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	payee, valid := server.ownPayee(ctx, uri.ID)
+	if !valid {
+		return
+	}
+
+	if err := server.store.DeletePayee(ctx, payee.ID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{})
+}
+
+func (server *Server) ownPayee(ctx *gin.Context, payeeID int64) (db.Payee, bool) {
+	payee, err := server.store.GetPayee(ctx, payeeID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return payee, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return payee, false
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if payee.Owner != authPayload.Username {
+		err := errors.New("payee doesn't belong to the authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return payee, false
+	}
+	return payee, true
+}
+
+// payeeAccount resolves a saved payee to its account.
+func (server *Server) payeeAccount(ctx *gin.Context, payeeID int64, currency string) (db.Account, bool) {
+	payee, valid := server.ownPayee(ctx, payeeID)
+	if !valid {
+		return db.Account{}, false
+	}
+	if payee.Currency != currency {
+		err := fmt.Errorf("payee [%d] currency mismatch %s vs %s", payee.ID, payee.Currency, currency)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return db.Account{}, false
+	}
+
+	account, err := server.store.GetAccount(ctx, payee.AccountID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return account, false
+	}
+	return account, true
+}
+
+// payeeCoolingOff is the cooling-off rule every payment to another user's account goes through.
+func (server *Server) payeeCoolingOff() db.PayeeCoolingOff {
+	return db.PayeeCoolingOff{
+		Limits: server.payeeCoolingOffLimits,
+		Period: server.config.PayeeCoolingOffPeriod,
+	}
+}
+
+func (server *Server) coolingOffError(ctx context.Context, username string, fromAccount db.Account, toAccountID int64, toOwner string, amount int64, at time.Time) error {
+	return server.payeeCoolingOff().Check(ctx, server.store, username, fromAccount, toAccountID, toOwner, amount, at)
+}
+
+// checkCoolingOff responds with 403 if the payment breaks the payee cooling-off limit.
+func (server *Server) checkCoolingOff(ctx *gin.Context, username string, fromAccount db.Account, toAccountID int64, toOwner string, amount int64, at time.Time) bool {
+	if err := server.coolingOffError(ctx, username, fromAccount, toAccountID, toOwner, amount, at); err != nil {
+		if errors.Is(err, db.ErrCoolingOff) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+	return true
+}
@@ -0,0 +1,475 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/gyu-young-park/simplebank/db/mock"
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/gyu-young-park/simplebank/util"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatePayeeAPI(t *testing.T) {
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+	account2 := randomAccount(user2.Username)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"nickname":    "landlord",
+				"to_username": user2.Username,
+				"currency":    account2.Currency,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAccountByOwnerAndCurrency(gomock.Any(), gomock.Eq(db.GetAccountByOwnerAndCurrencyParams{
+						Owner:    user2.Username,
+						Currency: account2.Currency,
+					})).
+					Times(1).
+					Return(account2, nil)
+				arg := db.CreatePayeeParams{
+					Owner:     user1.Username,
+					Nickname:  "landlord",
+					AccountID: account2.ID,
+					Recipient: user2.Username,
+					Currency:  account2.Currency,
+				}
+				store.EXPECT().
+					CreatePayee(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.Payee{ID: 1, Owner: arg.Owner, Nickname: arg.Nickname, AccountID: arg.AccountID, Recipient: arg.Recipient, Currency: arg.Currency}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.NotContains(t, recorder.Body.String(), "account_id")
+
+				var payee payeeResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &payee))
+				require.Equal(t, "landlord", payee.Nickname)
+				require.Equal(t, user2.Username, payee.Recipient)
+			},
+		},
+		{
+			name: "OwnAccount",
+			body: gin.H{
+				"nickname":    "me",
+				"to_username": user1.Username,
+				"currency":    account2.Currency,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAccountByOwnerAndCurrency(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(randomAccount(user1.Username), nil)
+				store.EXPECT().CreatePayee(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "DuplicateNickname",
+			body: gin.H{
+				"nickname":    "landlord",
+				"to_username": user2.Username,
+				"currency":    account2.Currency,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAccountByOwnerAndCurrency(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(account2, nil)
+				store.EXPECT().
+					CreatePayee(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Payee{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "MissingRecipient",
+			body: gin.H{
+				"nickname": "landlord",
+				"currency": account2.Currency,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreatePayee(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			mockController := gomock.NewController(t)
+			defer mockController.Finish()
+
+			store := mockdb.NewMockStore(mockController)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/payees", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user1.Username, util.DepositorRole, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestTransferToPayeeAPI(t *testing.T) {
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+
+	account1 := randomAccount(user1.Username)
+	account2 := randomAccount(user2.Username)
+	account2.ID = account1.ID + 1
+	account2.Currency = account1.Currency
+
+	payee := db.Payee{
+		ID:        util.RandomInt(1, 1000),
+		Owner:     user1.Username,
+		Nickname:  "landlord",
+		AccountID: account2.ID,
+		Recipient: user2.Username,
+		Currency:  account2.Currency,
+		CreatedAt: time.Now(),
+	}
+	knownSinceArg := db.GetRecipientKnownSinceParams{
+		Owner:         user1.Username,
+		ToAccountID:   account2.ID,
+		FromAccountID: account1.ID,
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		noLimit       bool
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "SmallAmountDuringCoolingOff",
+			body: gin.H{"payee_id": payee.ID, "amount": 100},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetPayee(gomock.Any(), gomock.Eq(payee.ID)).Times(1).Return(payee, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				// 한도 이하라면 언제 알게 된 계좌인지 조회하지 않는다.
+				store.EXPECT().GetRecipientKnownSince(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).Return(db.TransferTxResult{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "LargeAmountDuringCoolingOff",
+			body: gin.H{"payee_id": payee.ID, "amount": 101},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetPayee(gomock.Any(), gomock.Eq(payee.ID)).Times(1).Return(payee, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().
+					GetRecipientKnownSince(gomock.Any(), gomock.Eq(knownSinceArg)).
+					Times(1).
+					Return(sql.NullTime{Time: payee.CreatedAt, Valid: true}, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "LargeAmountAfterCoolingOff",
+			body: gin.H{"payee_id": payee.ID, "amount": 101},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetPayee(gomock.Any(), gomock.Eq(payee.ID)).Times(1).Return(payee, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().
+					GetRecipientKnownSince(gomock.Any(), gomock.Eq(knownSinceArg)).
+					Times(1).
+					Return(sql.NullTime{Time: time.Now().Add(-2 * time.Hour), Valid: true}, nil)
+				arg := db.TransferTxParams{
+					FromAccountID: account1.ID,
+					ToAccountID:   account2.ID,
+					Amount:        101,
+				}
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.TransferTxResult{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			// payee로 저장하지 않고 계좌 ID로 보내도 cooling-off를 피할 수 없다.
+			name: "LargeAmountToNewAccountID",
+			body: gin.H{"to_account_id": account2.ID, "amount": 101},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().GetRecipientKnownSince(gomock.Any(), gomock.Eq(knownSinceArg)).Times(1).Return(sql.NullTime{}, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "LargeAmountToNewUsername",
+			body: gin.H{"to_username": user2.Username, "amount": 101},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccountByOwnerAndCurrency(gomock.Any(), gomock.Any()).Times(1).Return(account2, nil)
+				store.EXPECT().GetRecipientKnownSince(gomock.Any(), gomock.Eq(knownSinceArg)).Times(1).Return(sql.NullTime{}, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			// cooling-off 한도는 통화별이라 한도가 없는 통화에는 적용되지 않는다.
+			name:    "NoLimitForCurrency",
+			body:    gin.H{"to_account_id": account2.ID, "amount": 101},
+			noLimit: true,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().GetRecipientKnownSince(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).Return(db.TransferTxResult{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			mockController := gomock.NewController(t)
+			defer mockController.Finish()
+
+			store := mockdb.NewMockStore(mockController)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			server.config.PayeeCoolingOffPeriod = time.Hour
+			if !tc.noLimit {
+				server.payeeCoolingOffLimits = map[string]int64{account1.Currency: 100}
+			}
+			recorder := httptest.NewRecorder()
+
+			body := gin.H{
+				"from_account_id": account1.ID,
+				"currency":        account1.Currency,
+			}
+			for key, value := range tc.body {
+				body[key] = value
+			}
+			data, err := json.Marshal(body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/transfers", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user1.Username, util.DepositorRole, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+// TestPayeeCoolingOffAPI checks that every way of paying a new recipient is held to the cooling-off limit.
+func TestPayeeCoolingOffAPI(t *testing.T) {
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+
+	account1 := randomAccount(user1.Username)
+	account2 := randomAccount(user2.Username)
+	account2.ID = account1.ID + 1
+	account2.Currency = account1.Currency
+
+	paymentRequest := db.PaymentRequest{
+		ID:          util.RandomInt(1, 1000),
+		Requester:   user2.Username,
+		Payer:       user1.Username,
+		ToAccountID: account2.ID,
+		Amount:      101,
+		Currency:    account1.Currency,
+		Status:      db.PaymentRequestStatusPending,
+	}
+	knownSinceArg := db.GetRecipientKnownSinceParams{
+		Owner:         user1.Username,
+		ToAccountID:   account2.ID,
+		FromAccountID: account1.ID,
+	}
+
+	testCases := []struct {
+		name          string
+		url           string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			// 한도 이하로 나눠 보내도 같은 계좌로 가는 item의 합으로 검사한다.
+			name: "BatchSplitIntoSmallItems",
+			url:  "/transfers/batch",
+			body: gin.H{
+				"from_account_id": account1.ID,
+				"currency":        account1.Currency,
+				"mode":            "atomic",
+				"items": []gin.H{
+					{"to_account_id": account2.ID, "amount": 60},
+					{"to_account_id": account2.ID, "amount": 60},
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().ListAccountsByIDs(gomock.Any(), gomock.Eq([]int64{account2.ID})).Times(1).Return([]db.Account{account2}, nil)
+				store.EXPECT().GetRecipientKnownSince(gomock.Any(), gomock.Eq(knownSinceArg)).Times(1).Return(sql.NullTime{}, nil)
+				store.EXPECT().BatchTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "cooling-off")
+			},
+		},
+		{
+			name: "AcceptPaymentRequest",
+			url:  fmt.Sprintf("/payment_requests/%d/accept", paymentRequest.ID),
+			body: gin.H{"from_account_id": account1.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(paymentRequest.ID)).Times(1).Return(paymentRequest, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetRecipientKnownSince(gomock.Any(), gomock.Eq(knownSinceArg)).Times(1).Return(sql.NullTime{}, nil)
+				store.EXPECT().PayPaymentRequestTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "AuthorizeHold",
+			url:  "/holds",
+			body: gin.H{
+				"account_id":    account1.ID,
+				"to_account_id": account2.ID,
+				"currency":      account1.Currency,
+				"amount":        101,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().GetRecipientKnownSince(gomock.Any(), gomock.Eq(knownSinceArg)).Times(1).Return(sql.NullTime{}, nil)
+				store.EXPECT().AuthorizeHoldTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "StandingOrderStartingDuringCoolingOff",
+			url:  "/standing_orders",
+			body: gin.H{
+				"from_account_id": account1.ID,
+				"to_account_id":   account2.ID,
+				"currency":        account1.Currency,
+				"amount":          101,
+				"frequency":       util.Daily,
+				"start_date":      time.Now(),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().GetRecipientKnownSince(gomock.Any(), gomock.Eq(knownSinceArg)).Times(1).Return(sql.NullTime{}, nil)
+				store.EXPECT().CreateStandingOrder(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			// 첫 실행이 cooling-off가 끝난 뒤라면 만들 수 있다.
+			name: "StandingOrderStartingAfterCoolingOff",
+			url:  "/standing_orders",
+			body: gin.H{
+				"from_account_id": account1.ID,
+				"to_account_id":   account2.ID,
+				"currency":        account1.Currency,
+				"amount":          101,
+				"frequency":       util.Daily,
+				"start_date":      time.Now().Add(48 * time.Hour),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().GetRecipientKnownSince(gomock.Any(), gomock.Eq(knownSinceArg)).Times(1).Return(sql.NullTime{}, nil)
+				store.EXPECT().CreateStandingOrder(gomock.Any(), gomock.Any()).Times(1).Return(db.StandingOrder{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			mockController := gomock.NewController(t)
+			defer mockController.Finish()
+
+			store := mockdb.NewMockStore(mockController)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			server.config.PayeeCoolingOffPeriod = time.Hour
+			server.payeeCoolingOffLimits = map[string]int64{account1.Currency: 100}
+			recorder := httptest.NewRecorder()
+
+			var body bytes.Buffer
+			if tc.body != nil {
+				require.NoError(t, json.NewEncoder(&body).Encode(tc.body))
+			}
+			request, err := http.NewRequest(http.MethodPost, tc.url, &body)
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", gin.MIMEJSON)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user1.Username, util.DepositorRole, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gyu-young-park/simplebank/pain"
+	"github.com/gyu-young-park/simplebank/token"
+)
+
+// maxPaymentInitiationSize limits the size of an uploaded pain.001 document.
+const maxPaymentInitiationSize = 10 << 20
+
+// importPaymentInitiation runs the credit transfers of a pain.001 document sent as the request body
+// and answers with a pain.002 status report.
+// 거래별로 실행 결과가 다르므로 일부가 거절되어도 200으로 보고서를 돌려준다.
+func (server *Server) importPaymentInitiation(ctx *gin.Context) {
+	body := http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxPaymentInitiationSize)
+	initiation, err := pain.ParseInitiation(body)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if count := initiation.TransactionCount(); count == 0 || count > maxBatchItems {
+		err := fmt.Errorf("a payment initiation must have between 1 and %d transactions", maxBatchItems)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	report := pain.NewImporter(server.store, server.approvalThresholds, server.payeeCoolingOff()).Import(ctx, authPayload.Username, initiation)
+
+	var rsp bytes.Buffer
+	if err := pain.WriteStatusReport(&rsp, report); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.Data(http.StatusOK, "application/xml", rsp.Bytes())
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/gyu-young-park/simplebank/db/mock"
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/gyu-young-park/simplebank/util"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportPaymentInitiationAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	document, err := os.ReadFile("../pain/testdata/pain001.xml")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		body          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: string(document),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreatePaymentInitiation(gomock.Any(), gomock.Any()).Times(1).Return(db.PaymentInitiation{ID: 1}, nil)
+				store.EXPECT().CreatePaymentInstruction(gomock.Any(), gomock.Any()).Times(4).Return(db.PaymentInstruction{ID: 1}, nil)
+				store.EXPECT().DeletePaymentInstruction(gomock.Any(), gomock.Any()).Times(2).Return(nil)
+				store.EXPECT().ListAccountsByIDs(gomock.Any(), gomock.Any()).Times(1).Return([]db.Account{
+					{ID: 1, Owner: user.Username, Currency: util.USD},
+					{ID: 2, Owner: "bob", Currency: util.USD},
+					{ID: 3, Owner: "carol", Currency: util.USD},
+				}, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(2).Return(db.TransferTxResult{Transfer: db.Transfer{ID: 1}}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "application/xml", recorder.Header().Get("Content-Type"))
+				require.Contains(t, recorder.Body.String(), "<GrpSts>PART</GrpSts>")
+			},
+		},
+		{
+			name: "DuplicateMessage",
+			body: string(document),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreatePaymentInitiation(gomock.Any(), gomock.Any()).Times(1).Return(db.PaymentInitiation{}, &pq.Error{Code: "23505"})
+				store.EXPECT().ListAccountsByIDs(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "<GrpSts>RJCT</GrpSts>")
+				require.Contains(t, recorder.Body.String(), "<Cd>DUPL</Cd>")
+			},
+		},
+		{
+			name: "InvalidXML",
+			body: "not xml",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListAccountsByIDs(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			mockController := gomock.NewController(t)
+			defer mockController.Finish()
+
+			store := mockdb.NewMockStore(mockController)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodPost, "/transfers/pain001", strings.NewReader(tc.body))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, util.DepositorRole, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,277 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/gyu-young-park/simplebank/token"
+)
+
+// 돈을 보낼 사람은 payer_username이나 payer_email 중 하나로 지정한다.
+type createPaymentRequestRequest struct {
+	PayerUsername string `json:"payer_username" binding:"omitempty,alphanum"`
+	PayerEmail    string `json:"payer_email" binding:"omitempty,email"`
+	Currency      string `json:"currency" binding:"required,currency"`
+	Description   string `json:"description" binding:"max=140"`
+	amountRequest
+}
+
+// paymentRequestResponse는 요청한 사람의 계좌 ID를 payer에게 보여주지 않는다.
+type paymentRequestResponse struct {
+	ID          int64         `json:"id"`
+	Requester   string        `json:"requester"`
+	Payer       string        `json:"payer"`
+	Amount      int64         `json:"amount"`
+	Currency    string        `json:"currency"`
+	Description string        `json:"description"`
+	Status      string        `json:"status"`
+	TransferID  sql.NullInt64 `json:"transfer_id"`
+	ExpiresAt   time.Time     `json:"expires_at"`
+	ResolvedAt  sql.NullTime  `json:"resolved_at"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+func newPaymentRequestResponse(request db.PaymentRequest) paymentRequestResponse {
+	return paymentRequestResponse{
+		ID:          request.ID,
+		Requester:   request.Requester,
+		Payer:       request.Payer,
+		Amount:      request.Amount,
+		Currency:    request.Currency,
+		Description: request.Description,
+		Status:      request.Status,
+		TransferID:  request.TransferID,
+		ExpiresAt:   request.ExpiresAt,
+		ResolvedAt:  request.ResolvedAt,
+		CreatedAt:   request.CreatedAt,
+	}
+}
+
+// createPaymentRequest asks another user to pay the caller. 받는 계좌는 요청한 사람의 같은 통화 계좌이다.
+func (server *Server) createPaymentRequest(ctx *gin.Context) {
+	var req createPaymentRequestRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := req.resolve(req.Currency); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if (req.PayerUsername == "") == (req.PayerEmail == "") {
+		err := errors.New("exactly one of payer_username and payer_email is required")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var payer db.User
+	var err error
+	if req.PayerEmail != "" {
+		payer, err = server.store.GetUserByEmail(ctx, req.PayerEmail)
+	} else {
+		payer, err = server.store.GetUsers(ctx, req.PayerUsername)
+	}
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(errors.New("payer not found")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if payer.Username == authPayload.Username {
+		err := errors.New("cannot request money from yourself")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	toAccount, valid := server.currencyAccount(ctx, authPayload.Username, req.Currency)
+	if !valid {
+		return
+	}
+
+	request, err := server.store.CreatePaymentRequest(ctx, db.CreatePaymentRequestParams{
+		Requester:   authPayload.Username,
+		Payer:       payer.Username,
+		ToAccountID: toAccount.ID,
+		Amount:      req.Amount,
+		Currency:    req.Currency,
+		Description: req.Description,
+		ExpiresAt:   time.Now().Add(server.config.PaymentRequestTTL),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, newPaymentRequestResponse(request))
+}
+
+type listPaymentRequestsRequest struct {
+	Direction string `form:"direction" binding:"required,oneof=incoming outgoing"`
+	PageID    int32  `form:"page_id" binding:"required,min=1"`
+	PageSize  int32  `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// incoming은 내가 돈을 보내야 하는 request, outgoing은 내가 보낸 request이다.
+func (server *Server) listPaymentRequests(ctx *gin.Context) {
+	var req listPaymentRequestsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	var requests []db.PaymentRequest
+	var err error
+	if req.Direction == "incoming" {
+		requests, err = server.store.ListIncomingPaymentRequests(ctx, db.ListIncomingPaymentRequestsParams{
+			Payer:  authPayload.Username,
+			Limit:  req.PageSize,
+			Offset: (req.PageID - 1) * req.PageSize,
+		})
+	} else {
+		requests, err = server.store.ListOutgoingPaymentRequests(ctx, db.ListOutgoingPaymentRequestsParams{
+			Requester: authPayload.Username,
+			Limit:     req.PageSize,
+			Offset:    (req.PageID - 1) * req.PageSize,
+		})
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := make([]paymentRequestResponse, len(requests))
+	for i, request := range requests {
+		rsp[i] = newPaymentRequestResponse(request)
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type paymentRequestURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type acceptPaymentRequestResponse struct {
+	PaymentRequest paymentRequestResponse `json:"payment_request"`
+	FromAccount    db.Account             `json:"from_account"`
+	FromEntry      db.Entry               `json:"from_entry"`
+}
+
+// payer가 보낼 수 있는 계좌라면 joint 계좌에서도 지불할 수 있다.
+type acceptPaymentRequestRequest struct {
+	FromAccountID int64 `json:"from_account_id" binding:"required,min=1"`
+}
+
+// acceptPaymentRequest pays the request from one of the payer's accounts in the requested currency.
+func (server *Server) acceptPaymentRequest(ctx *gin.Context) {
+	var uri paymentRequestURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req acceptPaymentRequestRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	request, valid := server.paymentRequestFor(ctx, uri.ID, func(request db.PaymentRequest) string { return request.Payer })
+	if !valid {
+		return
+	}
+	fromAccount, valid := server.validAccount(ctx, req.FromAccountID, request.Currency)
+	if !valid {
+		return
+	}
+	if !server.canTransferFrom(ctx, fromAccount) {
+		return
+	}
+	if !server.checkCoolingOff(ctx, request.Payer, fromAccount, request.ToAccountID, request.Requester, request.Amount, time.Now()) {
+		return
+	}
+
+	result, err := server.store.PayPaymentRequestTx(ctx, db.PayPaymentRequestTxParams{
+		PaymentRequestID: request.ID,
+		FromAccountID:    fromAccount.ID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrPaymentRequestNotPending):
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		case errors.Is(err, db.ErrInsufficientFunds):
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		case errors.Is(err, db.ErrTransferLimitExceeded), errors.Is(err, db.ErrApprovalRequired), errors.Is(err, db.ErrAccountNotActive):
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		respondTxError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, acceptPaymentRequestResponse{
+		PaymentRequest: newPaymentRequestResponse(result.PaymentRequest),
+		FromAccount:    result.FromAccount,
+		FromEntry:      result.FromEntry,
+	})
+}
+
+func (server *Server) declinePaymentRequest(ctx *gin.Context) {
+	server.resolvePaymentRequest(ctx, db.PaymentRequestStatusDeclined, func(request db.PaymentRequest) string { return request.Payer })
+}
+
+func (server *Server) cancelPaymentRequest(ctx *gin.Context) {
+	server.resolvePaymentRequest(ctx, db.PaymentRequestStatusCancelled, func(request db.PaymentRequest) string { return request.Requester })
+}
+
+// payer는 decline, requester는 cancel만 할 수 있고, 둘 다 pending인 request에만 가능하다.
+func (server *Server) resolvePaymentRequest(ctx *gin.Context, status string, allowedUser func(db.PaymentRequest) string) {
+	var uri paymentRequestURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	request, valid := server.paymentRequestFor(ctx, uri.ID, allowedUser)
+	if !valid {
+		return
+	}
+
+	request, err := server.store.ResolvePendingPaymentRequest(ctx, db.ResolvePendingPaymentRequestParams{
+		ID:     request.ID,
+		Status: status,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusBadRequest, errorResponse(db.ErrPaymentRequestNotPending))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, newPaymentRequestResponse(request))
+}
+
+func (server *Server) paymentRequestFor(ctx *gin.Context, requestID int64, allowedUser func(db.PaymentRequest) string) (db.PaymentRequest, bool) {
+	request, err := server.store.GetPaymentRequest(ctx, requestID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return request, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return request, false
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if allowedUser(request) != authPayload.Username {
+		err := errors.New("payment request action is not allowed for the authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return request, false
+	}
+	return request, true
+}
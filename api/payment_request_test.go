@@ -0,0 +1,361 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/gyu-young-park/simplebank/db/mock"
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/gyu-young-park/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatePaymentRequestAPI(t *testing.T) {
+	requester, _ := randomUser(t)
+	payer, _ := randomUser(t)
+	account := randomAccount(requester.Username)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"payer_email": payer.Email,
+				"amount":      10,
+				"currency":    account.Currency,
+				"description": "dinner",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(payer.Email)).Times(1).Return(payer, nil)
+				store.EXPECT().
+					GetAccountByOwnerAndCurrency(gomock.Any(), gomock.Eq(db.GetAccountByOwnerAndCurrencyParams{
+						Owner:    requester.Username,
+						Currency: account.Currency,
+					})).
+					Times(1).
+					Return(account, nil)
+				store.EXPECT().
+					CreatePaymentRequest(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreatePaymentRequestParams) (db.PaymentRequest, error) {
+						require.Equal(t, requester.Username, arg.Requester)
+						require.Equal(t, payer.Username, arg.Payer)
+						require.Equal(t, account.ID, arg.ToAccountID)
+						require.Equal(t, "dinner", arg.Description)
+						return db.PaymentRequest{
+							ID:          1,
+							Requester:   arg.Requester,
+							Payer:       arg.Payer,
+							ToAccountID: arg.ToAccountID,
+							Amount:      arg.Amount,
+							Status:      db.PaymentRequestStatusPending,
+						}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.NotContains(t, recorder.Body.String(), "to_account_id")
+			},
+		},
+		{
+			name: "PayerNotFound",
+			body: gin.H{
+				"payer_username": payer.Username,
+				"amount":         10,
+				"currency":       account.Currency,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUsers(gomock.Any(), gomock.Eq(payer.Username)).Times(1).Return(db.User{}, sql.ErrNoRows)
+				store.EXPECT().CreatePaymentRequest(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "RequestFromYourself",
+			body: gin.H{
+				"payer_username": requester.Username,
+				"amount":         10,
+				"currency":       account.Currency,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUsers(gomock.Any(), gomock.Eq(requester.Username)).Times(1).Return(requester, nil)
+				store.EXPECT().CreatePaymentRequest(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "BothPayerFields",
+			body: gin.H{
+				"payer_username": payer.Username,
+				"payer_email":    payer.Email,
+				"amount":         10,
+				"currency":       account.Currency,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreatePaymentRequest(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			mockController := gomock.NewController(t)
+			defer mockController.Finish()
+
+			store := mockdb.NewMockStore(mockController)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/payment_requests", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, requester.Username, util.DepositorRole, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestResolvePaymentRequestAPI(t *testing.T) {
+	requester, _ := randomUser(t)
+	payer, _ := randomUser(t)
+	payerAccount := randomAccount(payer.Username)
+	owner, _ := randomUser(t)
+	jointAccount := randomAccount(owner.Username)
+	jointAccount.Currency = payerAccount.Currency
+	paymentRequest := db.PaymentRequest{
+		ID:          util.RandomInt(1, 1000),
+		Requester:   requester.Username,
+		Payer:       payer.Username,
+		ToAccountID: util.RandomInt(1, 1000),
+		Amount:      10,
+		Currency:    payerAccount.Currency,
+		Status:      db.PaymentRequestStatusPending,
+	}
+
+	testCases := []struct {
+		name          string
+		action        string
+		username      string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "Accept",
+			action:   "accept",
+			username: payer.Username,
+			body:     gin.H{"from_account_id": payerAccount.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(paymentRequest.ID)).Times(1).Return(paymentRequest, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(payerAccount.ID)).Times(1).Return(payerAccount, nil)
+				store.EXPECT().GetAccountHolderByUsername(gomock.Any(), gomock.Any()).Times(0)
+				arg := db.PayPaymentRequestTxParams{
+					PaymentRequestID: paymentRequest.ID,
+					FromAccountID:    payerAccount.ID,
+				}
+				store.EXPECT().PayPaymentRequestTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.PayPaymentRequestTxResult{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "AcceptTwice",
+			action:   "accept",
+			username: payer.Username,
+			body:     gin.H{"from_account_id": payerAccount.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(paymentRequest.ID)).Times(1).Return(paymentRequest, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(payerAccount.ID)).Times(1).Return(payerAccount, nil)
+				store.EXPECT().
+					PayPaymentRequestTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.PayPaymentRequestTxResult{}, db.ErrPaymentRequestNotPending)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "AcceptFromFrozenAccount",
+			action:   "accept",
+			username: payer.Username,
+			body:     gin.H{"from_account_id": payerAccount.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(paymentRequest.ID)).Times(1).Return(paymentRequest, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(payerAccount.ID)).Times(1).Return(payerAccount, nil)
+				store.EXPECT().
+					PayPaymentRequestTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.PayPaymentRequestTxResult{}, db.ErrAccountNotActive)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "AcceptFromJointAccount",
+			action:   "accept",
+			username: payer.Username,
+			body:     gin.H{"from_account_id": jointAccount.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(paymentRequest.ID)).Times(1).Return(paymentRequest, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(jointAccount.ID)).Times(1).Return(jointAccount, nil)
+				store.EXPECT().
+					GetAccountHolderByUsername(gomock.Any(), gomock.Eq(db.GetAccountHolderByUsernameParams{AccountID: jointAccount.ID, Username: payer.Username})).
+					Times(1).
+					Return(db.AccountHolder{Role: db.AccountHolderRoleJoint, Status: db.AccountHolderStatusActive}, nil)
+				arg := db.PayPaymentRequestTxParams{
+					PaymentRequestID: paymentRequest.ID,
+					FromAccountID:    jointAccount.ID,
+				}
+				store.EXPECT().PayPaymentRequestTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.PayPaymentRequestTxResult{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "AcceptFromViewerAccount",
+			action:   "accept",
+			username: payer.Username,
+			body:     gin.H{"from_account_id": jointAccount.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(paymentRequest.ID)).Times(1).Return(paymentRequest, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(jointAccount.ID)).Times(1).Return(jointAccount, nil)
+				store.EXPECT().
+					GetAccountHolderByUsername(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AccountHolder{Role: db.AccountHolderRoleViewer, Status: db.AccountHolderStatusActive}, nil)
+				store.EXPECT().PayPaymentRequestTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:     "AcceptFromOtherUsersAccount",
+			action:   "accept",
+			username: payer.Username,
+			body:     gin.H{"from_account_id": jointAccount.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(paymentRequest.ID)).Times(1).Return(paymentRequest, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(jointAccount.ID)).Times(1).Return(jointAccount, nil)
+				store.EXPECT().GetAccountHolderByUsername(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountHolder{}, sql.ErrNoRows)
+				store.EXPECT().PayPaymentRequestTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:     "AcceptWithoutFromAccount",
+			action:   "accept",
+			username: payer.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().PayPaymentRequestTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "RequesterCannotAccept",
+			action:   "accept",
+			username: requester.Username,
+			body:     gin.H{"from_account_id": payerAccount.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(paymentRequest.ID)).Times(1).Return(paymentRequest, nil)
+				store.EXPECT().PayPaymentRequestTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:     "Decline",
+			action:   "decline",
+			username: payer.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(paymentRequest.ID)).Times(1).Return(paymentRequest, nil)
+				arg := db.ResolvePendingPaymentRequestParams{
+					ID:     paymentRequest.ID,
+					Status: db.PaymentRequestStatusDeclined,
+				}
+				store.EXPECT().ResolvePendingPaymentRequest(gomock.Any(), gomock.Eq(arg)).Times(1).Return(paymentRequest, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "CancelNotPending",
+			action:   "cancel",
+			username: requester.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(paymentRequest.ID)).Times(1).Return(paymentRequest, nil)
+				store.EXPECT().
+					ResolvePendingPaymentRequest(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.PaymentRequest{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			mockController := gomock.NewController(t)
+			defer mockController.Finish()
+
+			store := mockdb.NewMockStore(mockController)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			var body bytes.Buffer
+			if tc.body != nil {
+				require.NoError(t, json.NewEncoder(&body).Encode(tc.body))
+			}
+
+			url := fmt.Sprintf("/payment_requests/%d/%s", paymentRequest.ID, tc.action)
+			request, err := http.NewRequest(http.MethodPost, url, &body)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.username, util.DepositorRole, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -17,6 +17,10 @@ type Server struct {
 	store      db.Store
 	tokenMaker token.TokenMaker
 	router     *gin.Engine
+	// 통화별로 이 금액을 넘는 이체는 banker의 승인이 필요하다.
+	approvalThresholds map[string]int64
+	// 새로 알게 된 받는 계좌에는 cooling-off 기간 동안 통화별로 이 금액까지만 보낼 수 있다.
+	payeeCoolingOffLimits map[string]int64
 }
 
 func NewServer(config util.Config, store db.Store) (*Server, error) {
@@ -24,10 +28,20 @@ func NewServer(config util.Config, store db.Store) (*Server, error) {
 	if err != nil {
 		return nil, fmt.Errorf("cannot create token maker: %w", err)
 	}
+	approvalThresholds, err := util.ParseApprovalThresholds(config.ApprovalThresholds)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse approval thresholds: %w", err)
+	}
+	payeeCoolingOffLimits, err := util.ParsePayeeCoolingOffLimits(config.PayeeCoolingOffLimits)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse payee cooling-off limits: %w", err)
+	}
 	server := &Server{
-		store:      store,
-		tokenMaker: toekenMaker,
-		config:     config,
+		store:                 store,
+		tokenMaker:            toekenMaker,
+		config:                config,
+		approvalThresholds:    approvalThresholds,
+		payeeCoolingOffLimits: payeeCoolingOffLimits,
 	}
 
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
@@ -50,7 +64,71 @@ func (server *Server) setupRouter() {
 	authRoutes.POST("/accounts", server.createAccount)
 	authRoutes.GET("/accounts/:id", server.getAccount)
 	authRoutes.GET("/accounts", server.listAccounts)
+	authRoutes.PATCH("/accounts/:id", server.updateAccount)
+	authRoutes.POST("/accounts/:id/close", server.closeAccount)
+	authRoutes.GET("/accounts/:id/holders", server.listAccountHolders)
+	authRoutes.POST("/accounts/:id/holders", server.inviteAccountHolder)
+	authRoutes.DELETE("/accounts/:id/holders/:username", server.removeAccountHolder)
+	authRoutes.GET("/account_invitations", server.listAccountInvitations)
+	authRoutes.POST("/account_invitations/:id/accept", server.acceptAccountInvitation)
+	authRoutes.POST("/account_invitations/:id/decline", server.declineAccountInvitation)
+	authRoutes.GET("/accounts/:id/balance", server.getAccountBalance)
+	authRoutes.GET("/accounts/:id/balance_history", server.getBalanceHistory)
+	authRoutes.GET("/accounts/:id/statements/:period", server.getStatement)
+	authRoutes.GET("/accounts/:id/limits", server.getAccountLimit)
+	authRoutes.GET("/accounts/:id/interest", server.getAccountInterest)
+	authRoutes.GET("/account_products", server.listAccountProducts)
 	authRoutes.POST("/transfers", server.createTransfer)
+	authRoutes.GET("/transfers", server.listTransfers)
+	authRoutes.GET("/transfers/:id", server.getTransfer)
+	authRoutes.POST("/transfers/:id/reverse", server.reverseTransfer)
+	authRoutes.POST("/transfers/batch", server.createTransferBatch)
+	authRoutes.GET("/transfers/batch/:id", server.getTransferBatch)
+	authRoutes.POST("/transfers/pain001", server.importPaymentInitiation)
+
+	authRoutes.POST("/standing_orders", server.createStandingOrder)
+	authRoutes.GET("/standing_orders", server.listStandingOrders)
+	authRoutes.DELETE("/standing_orders/:id", server.cancelStandingOrder)
+
+	authRoutes.POST("/payees", server.createPayee)
+	authRoutes.GET("/payees", server.listPayees)
+	authRoutes.GET("/payees/:id", server.getPayee)
+	authRoutes.PATCH("/payees/:id", server.updatePayee)
+	authRoutes.DELETE("/payees/:id", server.deletePayee)
+
+	authRoutes.POST("/payment_requests", server.createPaymentRequest)
+	authRoutes.GET("/payment_requests", server.listPaymentRequests)
+	authRoutes.POST("/payment_requests/:id/accept", server.acceptPaymentRequest)
+	authRoutes.POST("/payment_requests/:id/decline", server.declinePaymentRequest)
+	authRoutes.POST("/payment_requests/:id/cancel", server.cancelPaymentRequest)
+
+	authRoutes.POST("/holds", server.authorizeHold)
+	authRoutes.POST("/holds/:id/capture", server.captureHold)
+	authRoutes.POST("/holds/:id/void", server.voidHold)
+
+	authRoutes.GET("/transfer_approvals/:id", server.getTransferApproval)
+
+	// banker만 쓸 수 있는 route
+	bankerRoutes := router.Group("/").Use(authMiddleware(server.tokenMaker), bankerMiddleware())
+
+	bankerRoutes.PUT("/accounts/:id/limits", server.updateAccountLimit)
+	bankerRoutes.DELETE("/accounts/:id/limits", server.resetAccountLimit)
+	bankerRoutes.POST("/accounts/:id/freeze", server.freezeAccount)
+	bankerRoutes.POST("/accounts/:id/unfreeze", server.unfreezeAccount)
+	bankerRoutes.GET("/transfer_approvals", server.listTransferApprovals)
+	bankerRoutes.POST("/transfer_approvals/:id/approve", server.approveTransfer)
+	bankerRoutes.POST("/transfer_approvals/:id/reject", server.rejectTransfer)
+	bankerRoutes.GET("/stats/transactions", server.getTxStats)
+	bankerRoutes.POST("/accounts/:id/deposits", server.depositCash)
+	bankerRoutes.POST("/accounts/:id/withdrawals", server.withdrawCash)
+	bankerRoutes.GET("/ledger_accounts", server.listLedgerAccounts)
+	bankerRoutes.GET("/reconciliation_runs", server.listReconciliationRuns)
+	bankerRoutes.GET("/accounts/:id/entry_chain", server.verifyAccountEntryChain)
+	bankerRoutes.GET("/ledger_accounts/:id/entry_chain", server.verifyLedgerEntryChain)
+	bankerRoutes.PUT("/account_products/:code", server.updateAccountProduct)
+	bankerRoutes.GET("/fee_schedules", server.listFeeSchedules)
+	bankerRoutes.PUT("/fee_schedules", server.upsertFeeSchedule)
+	bankerRoutes.DELETE("/fee_schedules/:id", server.deleteFeeSchedule)
 
 	server.router = router
 }
@@ -0,0 +1,172 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/gyu-young-park/simplebank/token"
+	"github.com/gyu-young-park/simplebank/util"
+)
+
+// day는 weekly일 때 요일(0 = 일요일), monthly일 때 날짜이다. end_date와 max_occurrences는 둘 다 생략하면 취소할 때까지 실행된다.
+type createStandingOrderRequest struct {
+	FromAccountID  int64      `json:"from_account_id" binding:"required,min=1"`
+	ToAccountID    int64      `json:"to_account_id" binding:"required,min=1"`
+	Currency       string     `json:"currency" binding:"required,currency"`
+	Frequency      string     `json:"frequency" binding:"required,oneof=daily weekly monthly"`
+	Day            int32      `json:"day" binding:"min=0,max=31"`
+	StartDate      time.Time  `json:"start_date" binding:"required"`
+	EndDate        *time.Time `json:"end_date"`
+	MaxOccurrences *int32     `json:"max_occurrences" binding:"omitempty,min=1"`
+	amountRequest
+}
+
+func (server *Server) createStandingOrder(ctx *gin.Context) {
+	var req createStandingOrderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := req.resolve(req.Currency); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	recurrence := util.Recurrence{Frequency: req.Frequency, Day: int(req.Day)}
+	if err := recurrence.Validate(); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	nextRunAt := recurrence.First(req.StartDate)
+
+	arg := db.CreateStandingOrderParams{
+		FromAccountID: req.FromAccountID,
+		ToAccountID:   req.ToAccountID,
+		Amount:        req.Amount,
+		Frequency:     req.Frequency,
+		Day:           req.Day,
+		StartDate:     req.StartDate,
+		NextRunAt:     nextRunAt,
+	}
+	if req.EndDate != nil {
+		if req.EndDate.Before(nextRunAt) {
+			err := errors.New("end date is before the first occurrence")
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		arg.EndDate = sql.NullTime{Time: *req.EndDate, Valid: true}
+	}
+	if req.MaxOccurrences != nil {
+		arg.MaxOccurrences = sql.NullInt32{Int32: *req.MaxOccurrences, Valid: true}
+	}
+
+	// standing order는 worker가 실행하므로 banker 승인을 기다릴 수 없다.
+	if server.requiresApproval(req.Currency, req.Amount) {
+		err := fmt.Errorf("%w: standing orders cannot exceed the approval threshold", db.ErrApprovalRequired)
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+
+	fromAccount, valid := server.validAccount(ctx, req.FromAccountID, req.Currency)
+	if !valid {
+		return
+	}
+
+	if !server.canTransferFrom(ctx, fromAccount) {
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	toAccount, valid := server.validAccount(ctx, req.ToAccountID, req.Currency)
+	if !valid {
+		return
+	}
+	// 첫 실행 시점에 cooling-off가 끝나 있으면 된다.
+	if !server.checkCoolingOff(ctx, authPayload.Username, fromAccount, toAccount.ID, toAccount.Owner, req.Amount, nextRunAt) {
+		return
+	}
+
+	arg.Owner = authPayload.Username
+	order, err := server.store.CreateStandingOrder(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, order)
+}
+
+type listStandingOrdersRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+func (server *Server) listStandingOrders(ctx *gin.Context) {
+	var req listStandingOrdersRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.ListStandingOrdersParams{
+		Owner:  authPayload.Username,
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	}
+
+	orders, err := server.store.ListStandingOrders(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, orders)
+}
+
+type cancelStandingOrderRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) cancelStandingOrder(ctx *gin.Context) {
+	var req cancelStandingOrderRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	order, err := server.store.GetStandingOrder(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if order.Owner != authPayload.Username {
+		err := errors.New("standing order doesn't belong to the authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+	if order.Status != db.StandingOrderStatusActive {
+		err := errors.New("standing order is not active")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	order, err = server.store.UpdateStandingOrderStatus(ctx, db.UpdateStandingOrderStatusParams{
+		ID:     order.ID,
+		Status: db.StandingOrderStatusCancelled,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, order)
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/gyu-young-park/simplebank/statement"
+	"github.com/gyu-young-park/simplebank/statement/export"
+	"github.com/gyu-young-park/simplebank/token"
+	"github.com/gyu-young-park/simplebank/util"
+)
+
+type getStatementURI struct {
+	ID     int64  `uri:"id" binding:"required,min=1"`
+	Period string `uri:"period" binding:"required"`
+}
+
+type getStatementQuery struct {
+	Format string `form:"format" binding:"omitempty,oneof=json csv pdf camt053 mt940"`
+}
+
+// getStatement returns the statement of an account for one month.
+// job이 미리 만들어 둔 statement가 있으면 그것을, 없으면 지금 entries에서 만든 것을 보여준다.
+func (server *Server) getStatement(ctx *gin.Context) {
+	var uri getStatementURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var query getStatementQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if query.Format == "" {
+		query.Format = statement.FormatJSON
+	}
+	from, _, err := statement.ParsePeriod(uri.Period)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	now := time.Now()
+	if from.After(now) {
+		err := errors.New("statement period has not started yet")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, err := server.store.GetAccount(ctx, uri.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	// 감사를 위해 banker도 다른 사람의 statement를 볼 수 있다.
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if authPayload.Role != util.BankerRole {
+		if _, ok := server.holderRole(ctx, account); !ok {
+			return
+		}
+	}
+
+	var result statement.Statement
+	stored, err := server.store.GetStatement(ctx, db.GetStatementParams{
+		AccountID: account.ID,
+		Period:    uri.Period,
+	})
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(stored.Data, &result); err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	case err == sql.ErrNoRows:
+		result, err = statement.Generate(ctx, server.store, account, uri.Period, now)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	default:
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	var body bytes.Buffer
+	contentType, extension := statement.ContentType(query.Format), query.Format
+	if export.Supported(query.Format) {
+		contentType, extension = export.ContentType(query.Format), export.Extension(query.Format)
+		err = export.Render(&body, result, query.Format)
+	} else {
+		err = statement.Render(&body, result, query.Format)
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if query.Format != statement.FormatJSON {
+		ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="statement-%d-%s.%s"`, account.ID, uri.Period, extension))
+	}
+	ctx.Data(http.StatusOK, contentType, body.Bytes())
+}
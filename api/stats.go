@@ -0,0 +1,12 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// getTxStats shows bankers how often transactions had to be retried because of deadlocks or serialization failures.
+func (server *Server) getTxStats(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, server.store.TxStats())
+}
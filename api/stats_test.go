@@ -0,0 +1,45 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/gyu-young-park/simplebank/db/mock"
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/gyu-young-park/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTxStatsAPI(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	stats := db.TxStats{Retries: 5, Exhausted: 1}
+	store := mockdb.NewMockStore(mockController)
+	store.EXPECT().TxStats().Times(1).Return(stats)
+
+	server := newTestServer(t, store)
+
+	// banker만 볼 수 있다.
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/stats/transactions", nil)
+	require.NoError(t, err)
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomOwner(), util.DepositorRole, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusForbidden, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	request, err = http.NewRequest(http.MethodGet, "/stats/transactions", nil)
+	require.NoError(t, err)
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomOwner(), util.BankerRole, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var rsp db.TxStats
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+	require.Equal(t, stats, rsp)
+}
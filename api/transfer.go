@@ -2,20 +2,79 @@ package api
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	db "github.com/gyu-young-park/simplebank/db/sqlc"
 	"github.com/gyu-young-park/simplebank/token"
+	"github.com/gyu-young-park/simplebank/util"
 )
 
+const maxMetadataSize = 4096
+
+// 받는 사람은 to_account_id, to_username, to_email 중 하나로 지정한다.
+// username이나 email로 지정하면 그 사용자의 같은 통화 계좌로 보낸다.
+type recipientRequest struct {
+	ToAccountID int64  `json:"to_account_id" binding:"omitempty,min=1"`
+	ToUsername  string `json:"to_username" binding:"omitempty,alphanum"`
+	ToEmail     string `json:"to_email" binding:"omitempty,email"`
+}
+
+// 저장된 payee_id로 받는 사람을 지정할 수도 있다.
 type transferRequest struct {
-	FromAccountID int64  `json:"from_account_id" binding:"required,min=1"`
-	ToAccountID   int64  `json:"to_account_id" binding:"required,min=1"`
-	Amount        int64  `json:"amount" binding:"required,gt=0"`
-	Currency      string `json:"currency" binding:"required,currency"`
+	FromAccountID int64 `json:"from_account_id" binding:"required,min=1"`
+	recipientRequest
+	amountRequest
+	PayeeID     int64           `json:"payee_id" binding:"omitempty,min=1"`
+	Currency    string          `json:"currency" binding:"required,currency"`
+	Description string          `json:"description" binding:"max=140"`
+	Reference   string          `json:"reference" binding:"max=35"`
+	Metadata    json.RawMessage `json:"metadata"`
+}
+
+// transferResponse shows a transfer from the point of view of one of its accounts.
+// 상대방은 username으로만 보여주고 계좌 ID는 노출하지 않는다.
+type transferResponse struct {
+	ID           int64           `json:"id"`
+	AccountID    int64           `json:"account_id"`
+	Counterparty string          `json:"counterparty"`
+	Amount       int64           `json:"amount"`
+	Status       string          `json:"status"`
+	Description  string          `json:"description"`
+	Reference    string          `json:"reference"`
+	Metadata     json.RawMessage `json:"metadata"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
+// amount는 entry처럼 보낸 쪽에서는 음수, 받은 쪽에서는 양수이다.
+func newTransferResponse(accountID int64, transfer db.Transfer, fromOwner string, toOwner string) transferResponse {
+	rsp := transferResponse{
+		ID:           transfer.ID,
+		AccountID:    accountID,
+		Counterparty: toOwner,
+		Amount:       -transfer.Amount,
+		Status:       transfer.Status,
+		Description:  transfer.Description,
+		Reference:    transfer.Reference,
+		Metadata:     transfer.Metadata,
+		CreatedAt:    transfer.CreatedAt,
+	}
+	if transfer.ToAccountID == accountID {
+		rsp.Counterparty = fromOwner
+		rsp.Amount = transfer.Amount
+	}
+	return rsp
+}
+
+type createTransferResponse struct {
+	Transfer    transferResponse `json:"transfer"`
+	FromAccount db.Account       `json:"from_account"`
+	FromEntry   db.Entry         `json:"from_entry"`
 }
 
 func (server *Server) createTransfer(ctx *gin.Context) {
@@ -24,35 +83,137 @@ func (server *Server) createTransfer(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err)) // http status code와 응답으로 보낼 json값을 보낸다. key-value값으로 보내면 gin이 알아서 json으로 직렬화 해준다.
 		return
 	}
+	if err := req.resolve(req.Currency); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := validMetadata(req.Metadata); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
 	fromAccount, valid := server.validAccount(ctx, req.FromAccountID, req.Currency)
 
 	if !valid {
 		return
 	}
+	if !server.canTransferFrom(ctx, fromAccount) {
+		return
+	}
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-	if fromAccount.Owner != authPayload.Username {
-		err := errors.New("from account doesn't belong to the authenticated user")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
-
+	var toAccount db.Account
+	if req.PayeeID != 0 {
+		if req.recipientRequest != (recipientRequest{}) {
+			err := errors.New("payee_id cannot be combined with another recipient")
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		toAccount, valid = server.payeeAccount(ctx, req.PayeeID, req.Currency)
+	} else {
+		toAccount, valid = server.recipientAccount(ctx, req.recipientRequest, req.Currency)
 	}
-	_, valid = server.validAccount(ctx, req.ToAccountID, req.Currency)
 
 	if !valid {
 		return
 	}
+	if toAccount.ID == fromAccount.ID {
+		err := errors.New("cannot transfer to the same account")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	// payee_id, to_account_id, to_username, to_email 어느 쪽으로 보내도 받는 계좌 기준으로 cooling-off를 검사한다.
+	if !server.checkCoolingOff(ctx, authPayload.Username, fromAccount, toAccount.ID, toAccount.Owner, req.Amount, time.Now()) {
+		return
+	}
 
 	arg := db.TransferTxParams{
-		FromAccountID: req.FromAccountID,
-		ToAccountID:   req.ToAccountID,
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
 		Amount:        req.Amount,
+		Description:   req.Description,
+		Reference:     req.Reference,
+		Metadata:      req.Metadata,
+	}
+
+	if server.requiresApproval(req.Currency, req.Amount) {
+		server.createTransferApproval(ctx, authPayload.Username, arg, req.Currency)
+		return
 	}
 
 	result, err := server.store.TransferTx(ctx, arg)
 	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrInsufficientFunds):
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		case errors.Is(err, db.ErrTransferLimitExceeded):
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		case errors.Is(err, db.ErrAccountNotActive):
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		respondTxError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, createTransferResponse{
+		Transfer:    newTransferResponse(fromAccount.ID, result.Transfer, fromAccount.Owner, toAccount.Owner),
+		FromAccount: result.FromAccount,
+		FromEntry:   result.FromEntry,
+	})
+}
+
+// currencyAccount returns the account of owner in the given currency, preferring the checking account.
+func (server *Server) currencyAccount(ctx *gin.Context, owner string, currency string) (db.Account, bool) {
+	account, err := server.store.GetAccountByOwnerAndCurrency(ctx, db.GetAccountByOwnerAndCurrencyParams{
+		Owner:    owner,
+		Currency: currency,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err := fmt.Errorf("%s has no %s account", owner, currency)
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return account, false
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return account, false
+	}
+	return account, true
+}
+
+// recipientAccount resolves the recipient of a transfer to their account in the transfer currency.
+func (server *Server) recipientAccount(ctx *gin.Context, req recipientRequest, currency string) (db.Account, bool) {
+	var recipients int
+	for _, given := range []bool{req.ToAccountID != 0, req.ToUsername != "", req.ToEmail != ""} {
+		if given {
+			recipients++
+		}
+	}
+	if recipients != 1 {
+		err := errors.New("exactly one of to_account_id, to_username and to_email is required")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return db.Account{}, false
+	}
+	if req.ToAccountID != 0 {
+		return server.validAccount(ctx, req.ToAccountID, currency)
+	}
+
+	username := req.ToUsername
+	if req.ToEmail != "" {
+		user, err := server.store.GetUserByEmail(ctx, req.ToEmail)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusNotFound, errorResponse(errors.New("recipient not found")))
+				return db.Account{}, false
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return db.Account{}, false
+		}
+		username = user.Username
 	}
 
-	ctx.JSON(http.StatusOK, result)
+	// 통화별 계좌가 여러 개면 checking 계좌로 받는다.
+	return server.currencyAccount(ctx, username, currency)
 }
 
 func (server *Server) validAccount(ctx *gin.Context, accountID int64, currency string) (db.Account, bool) {
@@ -73,3 +234,258 @@ func (server *Server) validAccount(ctx *gin.Context, accountID int64, currency s
 	}
 	return account, true
 }
+
+// metadata는 생략할 수 있지만, 보낸다면 크기가 제한된 JSON object여야 한다.
+func validMetadata(metadata json.RawMessage) error {
+	if len(metadata) == 0 {
+		return nil
+	}
+	if len(metadata) > maxMetadataSize {
+		return fmt.Errorf("metadata must not exceed %d bytes", maxMetadataSize)
+	}
+	var object map[string]interface{}
+	if err := json.Unmarshal(metadata, &object); err != nil || object == nil {
+		return errors.New("metadata must be a JSON object")
+	}
+	return nil
+}
+
+type listTransfersRequest struct {
+	AccountID   int64  `form:"account_id" binding:"required,min=1"`
+	Reference   string `form:"reference"`
+	Description string `form:"description"`
+	Metadata    string `form:"metadata"`
+	PageID      int32  `form:"page_id" binding:"required,min=1"`
+	PageSize    int32  `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// listTransfers returns the transfer history of an account, newest first.
+// reference는 정확히 일치, description은 부분 일치, metadata는 주어진 JSON object를 포함하는 transfer만 보여준다.
+func (server *Server) listTransfers(ctx *gin.Context) {
+	var req listTransfersRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	metadata := json.RawMessage("{}")
+	if req.Metadata != "" {
+		metadata = json.RawMessage(req.Metadata)
+		if err := validMetadata(metadata); err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+
+	account, err := server.store.GetAccount(ctx, req.AccountID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if _, ok := server.holderRole(ctx, account); !ok {
+		return
+	}
+
+	rows, err := server.store.ListTransferHistory(ctx, db.ListTransferHistoryParams{
+		AccountID:   account.ID,
+		Reference:   req.Reference,
+		Description: req.Description,
+		Metadata:    metadata,
+		Limit:       req.PageSize,
+		Offset:      (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	transfers := make([]transferResponse, len(rows))
+	for i, row := range rows {
+		transfer := db.Transfer{
+			ID:            row.ID,
+			FromAccountID: row.FromAccountID,
+			ToAccountID:   row.ToAccountID,
+			Amount:        row.Amount,
+			Status:        row.Status,
+			Description:   row.Description,
+			Reference:     row.Reference,
+			Metadata:      row.Metadata,
+			CreatedAt:     row.CreatedAt,
+		}
+		transfers[i] = newTransferResponse(account.ID, transfer, row.FromOwner, row.ToOwner)
+	}
+	ctx.JSON(http.StatusOK, transfers)
+}
+
+type transferURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type statusHistoryResponse struct {
+	FromStatus sql.NullString `json:"from_status"`
+	ToStatus   string         `json:"to_status"`
+	Reason     string         `json:"reason"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+type getTransferResponse struct {
+	transferResponse
+	History []statusHistoryResponse `json:"history"`
+}
+
+// getTransfer returns a transfer with its status history. 보낸 사람과 받은 사람만 볼 수 있다.
+func (server *Server) getTransfer(ctx *gin.Context) {
+	var uri transferURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	transfer, err := server.store.GetTransfer(ctx, uri.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	fromAccount, err := server.store.GetAccount(ctx, transfer.FromAccountID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	toAccount, err := server.store.GetAccount(ctx, transfer.ToAccountID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// 보낸 계좌와 받은 계좌 중 사용자가 holder인 쪽에서 본 transfer를 보여준다.
+	var accountID int64
+	for _, account := range []db.Account{fromAccount, toAccount} {
+		role, err := server.accountRole(ctx, account)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		if role != "" {
+			accountID = account.ID
+			break
+		}
+	}
+	if accountID == 0 {
+		err := errors.New("transfer doesn't belong to the authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	history, err := server.store.ListStatusHistory(ctx, transfer.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := getTransferResponse{
+		transferResponse: newTransferResponse(accountID, transfer, fromAccount.Owner, toAccount.Owner),
+		History:          make([]statusHistoryResponse, len(history)),
+	}
+	for i, h := range history {
+		rsp.History[i] = statusHistoryResponse{
+			FromStatus: h.FromStatus,
+			ToStatus:   h.ToStatus,
+			Reason:     h.Reason,
+			CreatedAt:  h.CreatedAt,
+		}
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// amount를 생략하면 아직 되돌려지지 않은 금액 전부를 되돌린다.
+type reverseTransferRequest struct {
+	amountRequest
+}
+
+// reverseTransferResponse shows the reversal from the refunding account, the original transfer's recipient.
+// 원래 보낸 사람의 계좌 정보는 보여주지 않는다.
+type reverseTransferResponse struct {
+	OriginalTransfer transferResponse `json:"original_transfer"`
+	Transfer         transferResponse `json:"transfer"`
+	FromAccount      db.Account       `json:"from_account"`
+	FromEntry        db.Entry         `json:"from_entry"`
+}
+
+// reverseTransfer lets a banker reverse a mistaken transfer, or the recipient refund it.
+func (server *Server) reverseTransfer(ctx *gin.Context) {
+	var uri transferURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// body가 없으면 전액을 되돌린다.
+	var req reverseTransferRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil && err != io.EOF {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	transfer, err := server.store.GetTransfer(ctx, uri.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	toAccount, err := server.store.GetAccount(ctx, transfer.ToAccountID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	// 환불은 받는 계좌에서 돈이 나가므로 그 계좌에서 이체할 수 있는 holder만 할 수 있다.
+	if authPayload.Role != util.BankerRole && !server.canTransferFrom(ctx, toAccount) {
+		return
+	}
+	if err := req.resolveOptional(toAccount.Currency); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ReverseTransferTxParams{
+		TransferID:     transfer.ID,
+		Amount:         req.Amount,
+		AllowOverdraft: authPayload.Role == util.BankerRole,
+	}
+
+	result, err := server.store.ReverseTransferTx(ctx, arg)
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrReversalExceedsTransfer), errors.Is(err, db.ErrTransferIsReversal), errors.Is(err, db.ErrTransferNotCompleted):
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		case errors.Is(err, db.ErrInsufficientFunds):
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		case errors.Is(err, db.ErrTransferLimitExceeded), errors.Is(err, db.ErrAccountNotActive):
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		respondTxError(ctx, err)
+		return
+	}
+
+	fromAccount, toAccount := result.FromAccount, result.ToAccount
+	ctx.JSON(http.StatusOK, reverseTransferResponse{
+		OriginalTransfer: newTransferResponse(fromAccount.ID, result.OriginalTransfer, toAccount.Owner, fromAccount.Owner),
+		Transfer:         newTransferResponse(fromAccount.ID, result.Transfer, fromAccount.Owner, toAccount.Owner),
+		FromAccount:      fromAccount,
+		FromEntry:        result.FromEntry,
+	})
+}
@@ -0,0 +1,225 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/gyu-young-park/simplebank/token"
+	"github.com/gyu-young-park/simplebank/util"
+)
+
+// transferApprovalResponse는 요청한 사람에게 받는 계좌 ID를 보여주지 않는다.
+type transferApprovalResponse struct {
+	ID            int64           `json:"id"`
+	Initiator     string          `json:"initiator"`
+	FromAccountID int64           `json:"from_account_id"`
+	Amount        int64           `json:"amount"`
+	Currency      string          `json:"currency"`
+	Description   string          `json:"description"`
+	Reference     string          `json:"reference"`
+	Metadata      json.RawMessage `json:"metadata"`
+	Status        string          `json:"status"`
+	Reviewer      sql.NullString  `json:"reviewer"`
+	Reason        string          `json:"reason"`
+	TransferID    sql.NullInt64   `json:"transfer_id"`
+	ReviewedAt    sql.NullTime    `json:"reviewed_at"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+func newTransferApprovalResponse(approval db.TransferApproval) transferApprovalResponse {
+	return transferApprovalResponse{
+		ID:            approval.ID,
+		Initiator:     approval.Initiator,
+		FromAccountID: approval.FromAccountID,
+		Amount:        approval.Amount,
+		Currency:      approval.Currency,
+		Description:   approval.Description,
+		Reference:     approval.Reference,
+		Metadata:      approval.Metadata,
+		Status:        approval.Status,
+		Reviewer:      approval.Reviewer,
+		Reason:        approval.Reason,
+		TransferID:    approval.TransferID,
+		ReviewedAt:    approval.ReviewedAt,
+		CreatedAt:     approval.CreatedAt,
+	}
+}
+
+// requiresApproval reports whether a transfer is above the approval threshold of its currency.
+func (server *Server) requiresApproval(currency string, amount int64) bool {
+	threshold, ok := server.approvalThresholds[currency]
+	return ok && amount > threshold
+}
+
+// createTransferApproval holds a large transfer until a banker approves it. 돈은 아직 움직이지 않는다.
+func (server *Server) createTransferApproval(ctx *gin.Context, initiator string, arg db.TransferTxParams, currency string) {
+	metadata := arg.Metadata
+	if len(metadata) == 0 {
+		metadata = json.RawMessage("{}")
+	}
+	approval, err := server.store.CreateTransferApproval(ctx, db.CreateTransferApprovalParams{
+		Initiator:     initiator,
+		FromAccountID: arg.FromAccountID,
+		ToAccountID:   arg.ToAccountID,
+		Amount:        arg.Amount,
+		Currency:      currency,
+		Description:   arg.Description,
+		Reference:     arg.Reference,
+		Metadata:      metadata,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusAccepted, newTransferApprovalResponse(approval))
+}
+
+type transferApprovalURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// getTransferApproval lets the initiator or a banker check the state of an approval.
+func (server *Server) getTransferApproval(ctx *gin.Context) {
+	var uri transferApprovalURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	approval, err := server.store.GetTransferApproval(ctx, uri.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if approval.Initiator != authPayload.Username && authPayload.Role != util.BankerRole {
+		err := errors.New("transfer approval doesn't belong to the authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, newTransferApprovalResponse(approval))
+}
+
+type listTransferApprovalsRequest struct {
+	Status   string `form:"status" binding:"omitempty,oneof=pending_approval approved rejected"`
+	PageID   int32  `form:"page_id" binding:"required,min=1"`
+	PageSize int32  `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// listTransferApprovals shows bankers the approval queue, oldest first. status를 생략하면 대기 중인 것만 보여준다.
+func (server *Server) listTransferApprovals(ctx *gin.Context) {
+	var req listTransferApprovalsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.Status == "" {
+		req.Status = db.TransferApprovalStatusPending
+	}
+
+	approvals, err := server.store.ListTransferApprovals(ctx, db.ListTransferApprovalsParams{
+		Status: req.Status,
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, approvals)
+}
+
+// approveTransfer executes a pending transfer. 요청한 사람은 자신의 이체를 승인할 수 없다.
+func (server *Server) approveTransfer(ctx *gin.Context) {
+	var uri transferApprovalURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	result, err := server.store.ApproveTransferTx(ctx, db.ApproveTransferTxParams{
+		ApprovalID: uri.ID,
+		Reviewer:   authPayload.Username,
+	})
+	if err != nil {
+		switch {
+		case err == sql.ErrNoRows:
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		case errors.Is(err, db.ErrSelfApproval):
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		case errors.Is(err, db.ErrApprovalNotPending):
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		case errors.Is(err, db.ErrInsufficientFunds):
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		case errors.Is(err, db.ErrTransferLimitExceeded), errors.Is(err, db.ErrAccountNotActive):
+			// 요청한 뒤에 계좌가 frozen되거나 해지되었을 수 있다.
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		respondTxError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, result)
+}
+
+type rejectTransferRequest struct {
+	Reason string `json:"reason" binding:"required,max=255"`
+}
+
+// rejectTransfer closes a pending transfer without moving money.
+func (server *Server) rejectTransfer(ctx *gin.Context) {
+	var uri transferApprovalURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req rejectTransferRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	approval, err := server.store.GetTransferApproval(ctx, uri.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if approval.Initiator == authPayload.Username {
+		ctx.JSON(http.StatusForbidden, errorResponse(db.ErrSelfApproval))
+		return
+	}
+
+	approval, err = server.store.RejectTransferApproval(ctx, db.RejectTransferApprovalParams{
+		ID:       approval.ID,
+		Reviewer: sql.NullString{String: authPayload.Username, Valid: true},
+		Reason:   req.Reason,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusBadRequest, errorResponse(db.ErrApprovalNotPending))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, approval)
+}
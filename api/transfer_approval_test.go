@@ -0,0 +1,403 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/gyu-young-park/simplebank/db/mock"
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/gyu-young-park/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateTransferRequiresApproval(t *testing.T) {
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+
+	account1 := randomAccount(user1.Username)
+	account2 := randomAccount(user2.Username)
+	account2.ID = account1.ID + 1
+	account2.Currency = account1.Currency
+
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	store := mockdb.NewMockStore(mockController)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+	arg := db.CreateTransferApprovalParams{
+		Initiator:     user1.Username,
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        101,
+		Currency:      account1.Currency,
+		Metadata:      json.RawMessage("{}"),
+	}
+	store.EXPECT().
+		CreateTransferApproval(gomock.Any(), gomock.Eq(arg)).
+		Times(1).
+		Return(db.TransferApproval{ID: 1, Initiator: user1.Username, Amount: 101, Status: db.TransferApprovalStatusPending}, nil)
+	store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+
+	server := newTestServer(t, store)
+	server.approvalThresholds = map[string]int64{account1.Currency: 100}
+	recorder := httptest.NewRecorder()
+
+	data, err := json.Marshal(gin.H{
+		"from_account_id": account1.ID,
+		"to_account_id":   account2.ID,
+		"amount":          101,
+		"currency":        account1.Currency,
+	})
+	require.NoError(t, err)
+
+	request, err := http.NewRequest(http.MethodPost, "/transfers", bytes.NewReader(data))
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user1.Username, util.DepositorRole, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusAccepted, recorder.Code)
+
+	var rsp transferApprovalResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+	require.Equal(t, db.TransferApprovalStatusPending, rsp.Status)
+	require.NotContains(t, recorder.Body.String(), "to_account_id")
+}
+
+func TestReviewTransferAPI(t *testing.T) {
+	initiator, _ := randomUser(t)
+	banker, _ := randomUser(t)
+	approval := db.TransferApproval{
+		ID:        util.RandomInt(1, 1000),
+		Initiator: initiator.Username,
+		Amount:    1000,
+		Status:    db.TransferApprovalStatusPending,
+	}
+
+	testCases := []struct {
+		name          string
+		action        string
+		username      string
+		role          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "Approve",
+			action:   "approve",
+			username: banker.Username,
+			role:     util.BankerRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ApproveTransferTxParams{
+					ApprovalID: approval.ID,
+					Reviewer:   banker.Username,
+				}
+				store.EXPECT().ApproveTransferTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.ApproveTransferTxResult{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "ApproveOwnTransfer",
+			action:   "approve",
+			username: banker.Username,
+			role:     util.BankerRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ApproveTransferTx(gomock.Any(), gomock.Any()).Times(1).Return(db.ApproveTransferTxResult{}, db.ErrSelfApproval)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "ApproveInsufficientFunds",
+			action:   "approve",
+			username: banker.Username,
+			role:     util.BankerRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ApproveTransferTx(gomock.Any(), gomock.Any()).Times(1).Return(db.ApproveTransferTxResult{}, db.ErrInsufficientFunds)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name:     "ApproveAccountNotActive",
+			action:   "approve",
+			username: banker.Username,
+			role:     util.BankerRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ApproveTransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ApproveTransferTxResult{}, fmt.Errorf("%w: account 1 is frozen", db.ErrAccountNotActive))
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "DepositorCannotApprove",
+			action:   "approve",
+			username: initiator.Username,
+			role:     util.DepositorRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ApproveTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "Reject",
+			action:   "reject",
+			username: banker.Username,
+			role:     util.BankerRole,
+			body:     gin.H{"reason": "unusual recipient"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransferApproval(gomock.Any(), gomock.Eq(approval.ID)).Times(1).Return(approval, nil)
+				arg := db.RejectTransferApprovalParams{
+					ID:       approval.ID,
+					Reviewer: sql.NullString{String: banker.Username, Valid: true},
+					Reason:   "unusual recipient",
+				}
+				store.EXPECT().RejectTransferApproval(gomock.Any(), gomock.Eq(arg)).Times(1).Return(approval, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "RejectWithoutReason",
+			action:   "reject",
+			username: banker.Username,
+			role:     util.BankerRole,
+			body:     gin.H{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RejectTransferApproval(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "RejectOwnTransfer",
+			action:   "reject",
+			username: initiator.Username,
+			role:     util.BankerRole,
+			body:     gin.H{"reason": "changed my mind"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransferApproval(gomock.Any(), gomock.Eq(approval.ID)).Times(1).Return(approval, nil)
+				store.EXPECT().RejectTransferApproval(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "RejectAlreadyReviewed",
+			action:   "reject",
+			username: banker.Username,
+			role:     util.BankerRole,
+			body:     gin.H{"reason": "unusual recipient"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransferApproval(gomock.Any(), gomock.Eq(approval.ID)).Times(1).Return(approval, nil)
+				store.EXPECT().RejectTransferApproval(gomock.Any(), gomock.Any()).Times(1).Return(db.TransferApproval{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			mockController := gomock.NewController(t)
+			defer mockController.Finish()
+
+			store := mockdb.NewMockStore(mockController)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			var body bytes.Buffer
+			if tc.body != nil {
+				require.NoError(t, json.NewEncoder(&body).Encode(tc.body))
+			}
+
+			url := fmt.Sprintf("/transfer_approvals/%d/%s", approval.ID, tc.action)
+			request, err := http.NewRequest(http.MethodPost, url, &body)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.username, tc.role, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+// TestApprovalThresholdAPI checks that the paths other than POST /transfers cannot move more than the approval threshold.
+func TestApprovalThresholdAPI(t *testing.T) {
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+
+	account1 := randomAccount(user1.Username)
+	account2 := randomAccount(user2.Username)
+	account2.ID = account1.ID + 1
+	account2.Currency = account1.Currency
+
+	paymentRequest := db.PaymentRequest{
+		ID:          util.RandomInt(1, 1000),
+		Requester:   user2.Username,
+		Payer:       user1.Username,
+		ToAccountID: account2.ID,
+		Amount:      101,
+		Currency:    account1.Currency,
+		Status:      db.PaymentRequestStatusPending,
+	}
+	hold := db.AccountHold{
+		ID:          util.RandomInt(1, 1000),
+		AccountID:   account1.ID,
+		ToAccountID: account2.ID,
+		Amount:      101,
+		Status:      db.HoldStatusActive,
+	}
+
+	testCases := []struct {
+		name          string
+		url           string
+		username      string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "Batch",
+			url:      "/transfers/batch",
+			username: user1.Username,
+			body: gin.H{
+				"from_account_id": account1.ID,
+				"currency":        account1.Currency,
+				"mode":            "best_effort",
+				"items":           []gin.H{{"to_account_id": account2.ID, "amount": 101}},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().ListAccountsByIDs(gomock.Any(), gomock.Any()).Times(1).Return([]db.Account{account2}, nil)
+				store.EXPECT().BatchTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "requires banker approval")
+			},
+		},
+		{
+			name:     "AcceptPaymentRequest",
+			url:      fmt.Sprintf("/payment_requests/%d/accept", paymentRequest.ID),
+			username: user1.Username,
+			body:     gin.H{"from_account_id": account1.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(paymentRequest.ID)).Times(1).Return(paymentRequest, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().
+					PayPaymentRequestTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.PayPaymentRequestTxResult{}, db.ErrApprovalRequired)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "StandingOrder",
+			url:      "/standing_orders",
+			username: user1.Username,
+			body: gin.H{
+				"from_account_id": account1.ID,
+				"to_account_id":   account2.ID,
+				"currency":        account1.Currency,
+				"amount":          101,
+				"frequency":       util.Daily,
+				"start_date":      time.Now(),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateStandingOrder(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "AuthorizeHold",
+			url:      "/holds",
+			username: user1.Username,
+			body: gin.H{
+				"account_id":    account1.ID,
+				"to_account_id": account2.ID,
+				"currency":      account1.Currency,
+				"amount":        101,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().AuthorizeHoldTx(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountHold{}, db.ErrApprovalRequired)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "CaptureHold",
+			url:      fmt.Sprintf("/holds/%d/capture", hold.ID),
+			username: user2.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountHold(gomock.Any(), gomock.Eq(hold.ID)).Times(1).Return(hold, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().CaptureHoldTx(gomock.Any(), gomock.Any()).Times(1).Return(db.CaptureHoldTxResult{}, db.ErrApprovalRequired)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			mockController := gomock.NewController(t)
+			defer mockController.Finish()
+
+			store := mockdb.NewMockStore(mockController)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			server.approvalThresholds = map[string]int64{account1.Currency: 100}
+			recorder := httptest.NewRecorder()
+
+			var body bytes.Buffer
+			if tc.body != nil {
+				require.NoError(t, json.NewEncoder(&body).Encode(tc.body))
+			}
+			request, err := http.NewRequest(http.MethodPost, tc.url, &body)
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", gin.MIMEJSON)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.username, util.DepositorRole, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,265 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/gyu-young-park/simplebank/token"
+	"github.com/gyu-young-park/simplebank/util"
+)
+
+const maxBatchItems = 5000
+
+type batchTransferItemRequest struct {
+	ToAccountID int64 `json:"to_account_id" binding:"required,min=1"`
+	amountRequest
+}
+
+// JSON으로 items를 보내거나, multipart form에 "file" 필드로 to_account_id,amount CSV를 올린다.
+type batchTransferRequest struct {
+	FromAccountID int64                      `json:"from_account_id" form:"from_account_id" binding:"required,min=1"`
+	Currency      string                     `json:"currency" form:"currency" binding:"required,currency"`
+	Mode          string                     `json:"mode" form:"mode" binding:"required,oneof=atomic best_effort"`
+	Items         []batchTransferItemRequest `json:"items" form:"-" binding:"dive"`
+}
+
+type batchItemError struct {
+	LineNo int    `json:"line_no"`
+	Error  string `json:"error"`
+}
+
+// createTransferBatch validates every item before any money moves, then executes the batch.
+func (server *Server) createTransferBatch(ctx *gin.Context) {
+	var req batchTransferRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if ctx.ContentType() == gin.MIMEMultipartPOSTForm {
+		items, err := batchItemsFromCSV(ctx, req.Currency)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		req.Items = items
+	}
+	if len(req.Items) == 0 || len(req.Items) > maxBatchItems {
+		err := fmt.Errorf("a batch must have between 1 and %d items", maxBatchItems)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	for i := range req.Items {
+		if err := req.Items[i].resolve(req.Currency); err != nil {
+			err = fmt.Errorf("item %d: %w", i+1, err)
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+
+	fromAccount, valid := server.validAccount(ctx, req.FromAccountID, req.Currency)
+	if !valid {
+		return
+	}
+	if !server.canTransferFrom(ctx, fromAccount) {
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	itemErrors, err := server.validateBatchItems(ctx, authPayload.Username, fromAccount, req.Items)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if len(itemErrors) > 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "batch has invalid items",
+			"items": itemErrors,
+		})
+		return
+	}
+
+	arg := db.BatchTransferTxParams{
+		Owner:         authPayload.Username,
+		FromAccountID: fromAccount.ID,
+		Currency:      req.Currency,
+		Mode:          req.Mode,
+		Items:         make([]db.BatchTransferItem, len(req.Items)),
+	}
+	for i, item := range req.Items {
+		arg.Items[i] = db.BatchTransferItem{
+			ToAccountID: item.ToAccountID,
+			Amount:      item.Amount,
+		}
+	}
+
+	result, err := server.store.BatchTransferTx(ctx, arg)
+	if err != nil {
+		respondTxError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, result)
+}
+
+// 받는 계좌는 한 번의 쿼리로 가져와서 존재 여부와 통화를 검사한다.
+// cooling-off는 같은 계좌로 가는 item을 합한 금액으로 검사해서 작게 나눠 보내도 한도를 넘지 못하게 한다.
+func (server *Server) validateBatchItems(ctx *gin.Context, username string, fromAccount db.Account, items []batchTransferItemRequest) ([]batchItemError, error) {
+	ids := make([]int64, 0, len(items))
+	totals := make(map[int64]int64)
+	for _, item := range items {
+		if _, ok := totals[item.ToAccountID]; !ok {
+			ids = append(ids, item.ToAccountID)
+		}
+		totals[item.ToAccountID] += item.Amount
+	}
+
+	accounts, err := server.store.ListAccountsByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	accountByID := make(map[int64]db.Account, len(accounts))
+	for _, account := range accounts {
+		accountByID[account.ID] = account
+	}
+
+	coolingOff := make(map[int64]error)
+	now := time.Now()
+	var itemErrors []batchItemError
+	for i, item := range items {
+		account, ok := accountByID[item.ToAccountID]
+		var msg string
+		switch {
+		case item.ToAccountID == fromAccount.ID:
+			msg = "cannot transfer to the source account"
+		case !ok:
+			msg = fmt.Sprintf("account [%d] not found", item.ToAccountID)
+		case account.Currency != fromAccount.Currency:
+			msg = fmt.Sprintf("account [%d] currency mismatch %s vs %s", item.ToAccountID, account.Currency, fromAccount.Currency)
+		case server.requiresApproval(fromAccount.Currency, item.Amount):
+			// 승인이 필요한 금액은 batch로 보낼 수 없고 POST /transfers로 따로 보내야 한다.
+			msg = fmt.Sprintf("amount %d requires banker approval; send it as a single transfer", item.Amount)
+		default:
+			checkErr, checked := coolingOff[account.ID]
+			if !checked {
+				checkErr = server.coolingOffError(ctx, username, fromAccount, account.ID, account.Owner, totals[account.ID], now)
+				if checkErr != nil && !errors.Is(checkErr, db.ErrCoolingOff) {
+					return nil, checkErr
+				}
+				coolingOff[account.ID] = checkErr
+			}
+			if checkErr == nil {
+				continue
+			}
+			msg = checkErr.Error()
+		}
+		itemErrors = append(itemErrors, batchItemError{LineNo: i + 1, Error: msg})
+	}
+	return itemErrors, nil
+}
+
+func batchItemsFromCSV(ctx *gin.Context, currency string) ([]batchTransferItemRequest, error) {
+	file, err := ctx.FormFile("file")
+	if err != nil {
+		return nil, err
+	}
+	f, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parseBatchCSV(f, currency)
+}
+
+// parseBatchCSV reads a CSV with a "to_account_id,amount" header.
+// amount는 "12.30"처럼 batch 통화의 주 단위로 적는다.
+func parseBatchCSV(r io.Reader, currency string) ([]batchTransferItemRequest, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read csv header: %w", err)
+	}
+	if strings.TrimSpace(header[0]) != "to_account_id" || strings.TrimSpace(header[1]) != "amount" {
+		return nil, errors.New("csv header must be to_account_id,amount")
+	}
+
+	var items []batchTransferItemRequest
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		lineNo := len(items) + 1
+
+		toAccountID, err := strconv.ParseInt(record[0], 10, 64)
+		if err != nil || toAccountID < 1 {
+			return nil, fmt.Errorf("item %d: invalid to_account_id %q", lineNo, record[0])
+		}
+		money, err := util.ParseMoney(record[1], currency)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", lineNo, err)
+		}
+		if money.Amount <= 0 {
+			return nil, fmt.Errorf("item %d: invalid amount %q", lineNo, record[1])
+		}
+		items = append(items, batchTransferItemRequest{
+			ToAccountID:   toAccountID,
+			amountRequest: amountRequest{Amount: money.Amount},
+		})
+		if len(items) > maxBatchItems {
+			return nil, fmt.Errorf("a batch must have between 1 and %d items", maxBatchItems)
+		}
+	}
+	return items, nil
+}
+
+type getTransferBatchRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getTransferBatch(ctx *gin.Context) {
+	var req getTransferBatchRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	batch, err := server.store.GetTransferBatch(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if batch.Owner != authPayload.Username {
+		err := errors.New("batch doesn't belong to the authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	items, err := server.store.ListTransferBatchItems(ctx, batch.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, db.BatchTransferTxResult{
+		Batch: batch,
+		Items: items,
+	})
+}
@@ -0,0 +1,365 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/gyu-young-park/simplebank/db/mock"
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/gyu-young-park/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateTransferBatchAPI(t *testing.T) {
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+
+	account1 := randomAccount(user1.Username)
+	account2 := randomAccount(user2.Username)
+	account3 := randomAccount(user2.Username)
+	account2.ID = account1.ID + 1
+	account3.ID = account1.ID + 2
+	account2.Currency = account1.Currency
+	account3.Currency = account1.Currency
+
+	currencyBody := func(currency string, items []gin.H) func(t *testing.T) (*bytes.Buffer, string) {
+		return func(t *testing.T) (*bytes.Buffer, string) {
+			var body bytes.Buffer
+			err := json.NewEncoder(&body).Encode(gin.H{
+				"from_account_id": account1.ID,
+				"currency":        currency,
+				"mode":            db.BatchModeAtomic,
+				"items":           items,
+			})
+			require.NoError(t, err)
+			return &body, gin.MIMEJSON
+		}
+	}
+	jsonBody := func(items []gin.H) func(t *testing.T) (*bytes.Buffer, string) {
+		return currencyBody(account1.Currency, items)
+	}
+	// amount_decimal은 소수 자리가 있는 통화로 보낸다.
+	usdBody := func(items []gin.H) func(t *testing.T) (*bytes.Buffer, string) {
+		return currencyBody(util.USD, items)
+	}
+	// CSV의 amount는 batch 통화의 주 단위이다.
+	csvBody := func(rows string) func(t *testing.T) (*bytes.Buffer, string) {
+		return func(t *testing.T) (*bytes.Buffer, string) {
+			var body bytes.Buffer
+			writer := multipart.NewWriter(&body)
+			require.NoError(t, writer.WriteField("from_account_id", fmt.Sprint(account1.ID)))
+			require.NoError(t, writer.WriteField("currency", util.USD))
+			require.NoError(t, writer.WriteField("mode", db.BatchModeBestEffort))
+			part, err := writer.CreateFormFile("file", "payroll.csv")
+			require.NoError(t, err)
+			_, err = fmt.Fprintf(part, "to_account_id,amount\n%s", rows)
+			require.NoError(t, err)
+			require.NoError(t, writer.Close())
+			return &body, writer.FormDataContentType()
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		username      string
+		buildBody     func(t *testing.T) (*bytes.Buffer, string)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "JSON",
+			username: user1.Username,
+			buildBody: jsonBody([]gin.H{
+				{"to_account_id": account2.ID, "amount": 10},
+				{"to_account_id": account3.ID, "amount": 20},
+			}),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().
+					ListAccountsByIDs(gomock.Any(), gomock.Eq([]int64{account2.ID, account3.ID})).
+					Times(1).
+					Return([]db.Account{account2, account3}, nil)
+				arg := db.BatchTransferTxParams{
+					Owner:         user1.Username,
+					FromAccountID: account1.ID,
+					Currency:      account1.Currency,
+					Mode:          db.BatchModeAtomic,
+					Items: []db.BatchTransferItem{
+						{ToAccountID: account2.ID, Amount: 10},
+						{ToAccountID: account3.ID, Amount: 20},
+					},
+				}
+				store.EXPECT().BatchTransferTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.BatchTransferTxResult{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:      "CSV",
+			username:  user1.Username,
+			buildBody: csvBody(fmt.Sprintf("%d,0.10\n%d,12.30\n", account2.ID, account2.ID)),
+			buildStubs: func(store *mockdb.MockStore) {
+				account1, account2 := account1, account2
+				account1.Currency, account2.Currency = util.USD, util.USD
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().
+					ListAccountsByIDs(gomock.Any(), gomock.Eq([]int64{account2.ID})).
+					Times(1).
+					Return([]db.Account{account2}, nil)
+				arg := db.BatchTransferTxParams{
+					Owner:         user1.Username,
+					FromAccountID: account1.ID,
+					Currency:      util.USD,
+					Mode:          db.BatchModeBestEffort,
+					Items: []db.BatchTransferItem{
+						{ToAccountID: account2.ID, Amount: 10},
+						{ToAccountID: account2.ID, Amount: 1230},
+					},
+				}
+				store.EXPECT().BatchTransferTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.BatchTransferTxResult{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:      "CSVAmountTooPrecise",
+			username:  user1.Username,
+			buildBody: csvBody(fmt.Sprintf("%d,0.10\n%d,0.105\n", account2.ID, account2.ID)),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().BatchTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "item 2")
+				require.Contains(t, recorder.Body.String(), "more decimal places")
+			},
+		},
+		{
+			name:      "CSVInvalidAmount",
+			username:  user1.Username,
+			buildBody: csvBody(fmt.Sprintf("%d,0\n", account2.ID)),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().BatchTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "invalid amount")
+			},
+		},
+		{
+			name:     "UnknownRecipient",
+			username: user1.Username,
+			buildBody: jsonBody([]gin.H{
+				{"to_account_id": account2.ID, "amount": 10},
+				{"to_account_id": account3.ID, "amount": 20},
+			}),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().
+					ListAccountsByIDs(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.Account{account2}, nil)
+				store.EXPECT().BatchTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+				var body struct {
+					Items []batchItemError `json:"items"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+				require.Len(t, body.Items, 1)
+				require.Equal(t, 2, body.Items[0].LineNo)
+			},
+		},
+		{
+			name:     "TxConflict",
+			username: user1.Username,
+			buildBody: jsonBody([]gin.H{
+				{"to_account_id": account2.ID, "amount": 10},
+			}),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().ListAccountsByIDs(gomock.Any(), gomock.Any()).Times(1).Return([]db.Account{account2}, nil)
+				store.EXPECT().
+					BatchTransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.BatchTransferTxResult{}, fmt.Errorf("%w after 4 attempts: deadlock detected", db.ErrTxConflict))
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+			},
+		},
+		{
+			name:     "DecimalAmount",
+			username: user1.Username,
+			buildBody: usdBody([]gin.H{
+				{"to_account_id": account2.ID, "amount_decimal": "0.10"},
+			}),
+			buildStubs: func(store *mockdb.MockStore) {
+				account1, account2 := account1, account2
+				account1.Currency, account2.Currency = util.USD, util.USD
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().ListAccountsByIDs(gomock.Any(), gomock.Any()).Times(1).Return([]db.Account{account2}, nil)
+				arg := db.BatchTransferTxParams{
+					Owner:         user1.Username,
+					FromAccountID: account1.ID,
+					Currency:      util.USD,
+					Mode:          db.BatchModeAtomic,
+					Items:         []db.BatchTransferItem{{ToAccountID: account2.ID, Amount: 10}},
+				}
+				store.EXPECT().BatchTransferTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.BatchTransferTxResult{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "DecimalAmountTooPrecise",
+			username: user1.Username,
+			buildBody: usdBody([]gin.H{
+				{"to_account_id": account2.ID, "amount_decimal": "0.105"},
+			}),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().BatchTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "UnauthorizedUser",
+			username: user2.Username,
+			buildBody: jsonBody([]gin.H{
+				{"to_account_id": account2.ID, "amount": 10},
+			}),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccountHolderByUsername(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountHolder{}, sql.ErrNoRows)
+				store.EXPECT().BatchTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:      "EmptyBatch",
+			username:  user1.Username,
+			buildBody: jsonBody([]gin.H{}),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().BatchTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			mockController := gomock.NewController(t)
+			defer mockController.Finish()
+
+			store := mockdb.NewMockStore(mockController)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			body, contentType := tc.buildBody(t)
+			request, err := http.NewRequest(http.MethodPost, "/transfers/batch", body)
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", contentType)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.username, util.DepositorRole, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestGetTransferBatchAPI(t *testing.T) {
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+	batch := db.TransferBatch{
+		ID:     util.RandomInt(1, 1000),
+		Owner:  user1.Username,
+		Status: db.BatchStatusCompleted,
+	}
+	items := []db.TransferBatchItem{
+		{ID: 1, BatchID: batch.ID, LineNo: 1, Status: db.BatchItemStatusSucceeded},
+	}
+
+	testCases := []struct {
+		name          string
+		username      string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			username: user1.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransferBatch(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(batch, nil)
+				store.EXPECT().ListTransferBatchItems(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(items, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var result db.BatchTransferTxResult
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &result))
+				require.Equal(t, batch.ID, result.Batch.ID)
+				require.Len(t, result.Items, 1)
+			},
+		},
+		{
+			name:     "UnauthorizedUser",
+			username: user2.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransferBatch(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(batch, nil)
+				store.EXPECT().ListTransferBatchItems(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			mockController := gomock.NewController(t)
+			defer mockController.Finish()
+
+			store := mockdb.NewMockStore(mockController)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/transfers/batch/%d", batch.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.username, util.DepositorRole, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+)
+
+// respondTxError sends an error from a store transaction that no handler-specific case matched.
+// 재시도를 다 써도 충돌한 트랜잭션은 잠시 뒤에 다시 요청하면 되므로 503으로 알린다.
+func respondTxError(ctx *gin.Context, err error) {
+	if errors.Is(err, db.ErrTxConflict) {
+		ctx.JSON(http.StatusServiceUnavailable, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+}
@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS "transfers" DROP COLUMN IF EXISTS "standing_order_id";
+DROP TABLE IF EXISTS "standing_orders";
@@ -0,0 +1,43 @@
+CREATE TABLE "standing_orders" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "frequency" varchar NOT NULL,
+  "day" integer NOT NULL DEFAULT 0,
+  "start_date" timestamptz NOT NULL,
+  "end_date" timestamptz,
+  "max_occurrences" integer,
+  "occurrences" integer NOT NULL DEFAULT 0,
+  "next_run_at" timestamptz NOT NULL,
+  "failed_attempts" integer NOT NULL DEFAULT 0,
+  "last_attempt_at" timestamptz,
+  "last_error" varchar NOT NULL DEFAULT '',
+  "status" varchar NOT NULL DEFAULT 'active',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "standing_orders" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "standing_orders" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "standing_orders" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "standing_orders" ADD CONSTRAINT "standing_orders_amount_check" CHECK ("amount" > 0);
+
+ALTER TABLE "standing_orders" ADD CONSTRAINT "standing_orders_frequency_check" CHECK ("frequency" IN ('daily', 'weekly', 'monthly'));
+
+ALTER TABLE "standing_orders" ADD CONSTRAINT "standing_orders_status_check" CHECK ("status" IN ('active', 'completed', 'cancelled'));
+
+CREATE INDEX ON "standing_orders" ("owner");
+
+CREATE INDEX ON "standing_orders" ("status", "next_run_at");
+
+COMMENT ON COLUMN "standing_orders"."day" IS 'weekday (0 = sunday) for weekly, day of month for monthly';
+
+ALTER TABLE "transfers" ADD COLUMN "standing_order_id" bigint;
+
+ALTER TABLE "transfers" ADD FOREIGN KEY ("standing_order_id") REFERENCES "standing_orders" ("id");
+
+CREATE INDEX ON "transfers" ("standing_order_id");
@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS "transfers" DROP COLUMN IF EXISTS "reversal_of";
+ALTER TABLE IF EXISTS "users" DROP COLUMN IF EXISTS "role";
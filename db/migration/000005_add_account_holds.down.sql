@@ -0,0 +1,3 @@
+ALTER TABLE IF EXISTS "transfers" DROP COLUMN IF EXISTS "hold_id";
+
+DROP TABLE IF EXISTS "account_holds";
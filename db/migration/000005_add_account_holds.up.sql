@@ -0,0 +1,30 @@
+CREATE TABLE "account_holds" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "captured_amount" bigint NOT NULL DEFAULT 0,
+  "status" varchar NOT NULL DEFAULT 'active',
+  "expires_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "account_holds" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "account_holds" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "account_holds" ADD CONSTRAINT "account_holds_amount_check" CHECK ("amount" > 0);
+
+ALTER TABLE "account_holds" ADD CONSTRAINT "account_holds_status_check" CHECK ("status" IN ('active', 'captured', 'voided', 'expired'));
+
+CREATE INDEX ON "account_holds" ("account_id", "status");
+
+CREATE INDEX ON "account_holds" ("status", "expires_at");
+
+COMMENT ON COLUMN "account_holds"."captured_amount" IS 'amount settled by capture, the rest of the hold is released';
+
+ALTER TABLE "transfers" ADD COLUMN "hold_id" bigint;
+
+ALTER TABLE "transfers" ADD FOREIGN KEY ("hold_id") REFERENCES "account_holds" ("id");
+
+CREATE INDEX ON "transfers" ("hold_id");
@@ -0,0 +1,48 @@
+CREATE TABLE "transfer_batches" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "from_account_id" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "mode" varchar NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'processing',
+  "total_count" integer NOT NULL,
+  "total_amount" bigint NOT NULL,
+  "succeeded_count" integer NOT NULL DEFAULT 0,
+  "failed_count" integer NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "transfer_batch_items" (
+  "id" bigserial PRIMARY KEY,
+  "batch_id" bigint NOT NULL,
+  "line_no" integer NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "transfer_id" bigint,
+  "failure_reason" varchar NOT NULL DEFAULT ''
+);
+
+ALTER TABLE "transfer_batches" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "transfer_batches" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_batches" ADD CONSTRAINT "transfer_batches_mode_check" CHECK ("mode" IN ('atomic', 'best_effort'));
+
+ALTER TABLE "transfer_batches" ADD CONSTRAINT "transfer_batches_status_check" CHECK ("status" IN ('processing', 'completed', 'partially_completed', 'failed'));
+
+ALTER TABLE "transfer_batch_items" ADD FOREIGN KEY ("batch_id") REFERENCES "transfer_batches" ("id");
+
+ALTER TABLE "transfer_batch_items" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_batch_items" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "transfer_batch_items" ADD CONSTRAINT "transfer_batch_items_amount_check" CHECK ("amount" > 0);
+
+ALTER TABLE "transfer_batch_items" ADD CONSTRAINT "transfer_batch_items_status_check" CHECK ("status" IN ('pending', 'succeeded', 'failed'));
+
+CREATE INDEX ON "transfer_batches" ("owner");
+
+CREATE UNIQUE INDEX ON "transfer_batch_items" ("batch_id", "line_no");
+
+COMMENT ON COLUMN "transfer_batch_items"."line_no" IS 'position of the item in the submitted list, starting at 1';
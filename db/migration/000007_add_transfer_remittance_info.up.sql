@@ -0,0 +1,17 @@
+ALTER TABLE "transfers" ADD COLUMN "description" varchar NOT NULL DEFAULT '';
+
+ALTER TABLE "transfers" ADD COLUMN "reference" varchar NOT NULL DEFAULT '';
+
+ALTER TABLE "transfers" ADD COLUMN "metadata" jsonb NOT NULL DEFAULT '{}';
+
+ALTER TABLE "entries" ADD COLUMN "description" varchar NOT NULL DEFAULT '';
+
+ALTER TABLE "entries" ADD COLUMN "reference" varchar NOT NULL DEFAULT '';
+
+CREATE INDEX ON "transfers" ("reference");
+
+CREATE INDEX ON "transfers" USING GIN ("metadata");
+
+COMMENT ON COLUMN "transfers"."reference" IS 'remittance reference such as an invoice number';
+
+COMMENT ON COLUMN "entries"."description" IS 'copied from the transfer for statement display';
@@ -0,0 +1,19 @@
+CREATE TABLE "payees" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "nickname" varchar NOT NULL,
+  "account_id" bigint NOT NULL,
+  "recipient" varchar NOT NULL,
+  "currency" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "payees" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "payees" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "payees" ADD FOREIGN KEY ("recipient") REFERENCES "users" ("username");
+
+ALTER TABLE "payees" ADD CONSTRAINT "owner_nickname_key" UNIQUE ("owner", "nickname");
+
+COMMENT ON COLUMN "payees"."recipient" IS 'owner of the target account, shown instead of the account id';
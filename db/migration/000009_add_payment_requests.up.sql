@@ -0,0 +1,34 @@
+CREATE TABLE "payment_requests" (
+  "id" bigserial PRIMARY KEY,
+  "requester" varchar NOT NULL,
+  "payer" varchar NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "description" varchar NOT NULL DEFAULT '',
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "transfer_id" bigint,
+  "expires_at" timestamptz NOT NULL,
+  "resolved_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "payment_requests" ADD FOREIGN KEY ("requester") REFERENCES "users" ("username");
+
+ALTER TABLE "payment_requests" ADD FOREIGN KEY ("payer") REFERENCES "users" ("username");
+
+ALTER TABLE "payment_requests" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "payment_requests" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "payment_requests" ADD CONSTRAINT "payment_requests_amount_check" CHECK ("amount" > 0);
+
+ALTER TABLE "payment_requests" ADD CONSTRAINT "payment_requests_status_check" CHECK ("status" IN ('pending', 'paid', 'declined', 'cancelled', 'expired'));
+
+CREATE INDEX ON "payment_requests" ("payer", "status");
+
+CREATE INDEX ON "payment_requests" ("requester");
+
+CREATE INDEX ON "payment_requests" ("status", "expires_at");
+
+COMMENT ON COLUMN "payment_requests"."transfer_id" IS 'transfer that paid the request';
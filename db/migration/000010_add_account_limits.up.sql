@@ -0,0 +1,16 @@
+CREATE TABLE "account_limits" (
+  "account_id" bigint PRIMARY KEY,
+  "max_per_transfer" bigint NOT NULL DEFAULT 0,
+  "max_daily_amount" bigint NOT NULL DEFAULT 0,
+  "max_daily_count" integer NOT NULL DEFAULT 0,
+  "updated_by" varchar NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "account_limits" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "account_limits" ADD FOREIGN KEY ("updated_by") REFERENCES "users" ("username");
+
+ALTER TABLE "account_limits" ADD CONSTRAINT "account_limits_check" CHECK ("max_per_transfer" >= 0 AND "max_daily_amount" >= 0 AND "max_daily_count" >= 0);
+
+CREATE INDEX ON "transfers" ("from_account_id", "created_at");
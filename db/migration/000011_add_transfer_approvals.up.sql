@@ -0,0 +1,40 @@
+CREATE TABLE "transfer_approvals" (
+  "id" bigserial PRIMARY KEY,
+  "initiator" varchar NOT NULL,
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "description" varchar NOT NULL DEFAULT '',
+  "reference" varchar NOT NULL DEFAULT '',
+  "metadata" jsonb NOT NULL DEFAULT '{}',
+  "status" varchar NOT NULL DEFAULT 'pending_approval',
+  "reviewer" varchar,
+  "reason" varchar NOT NULL DEFAULT '',
+  "transfer_id" bigint,
+  "reviewed_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("initiator") REFERENCES "users" ("username");
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("reviewer") REFERENCES "users" ("username");
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "transfer_approvals" ADD CONSTRAINT "transfer_approvals_amount_check" CHECK ("amount" > 0);
+
+ALTER TABLE "transfer_approvals" ADD CONSTRAINT "transfer_approvals_status_check" CHECK ("status" IN ('pending_approval', 'approved', 'rejected'));
+
+-- 승인한 사람과 요청한 사람은 달라야 한다.
+ALTER TABLE "transfer_approvals" ADD CONSTRAINT "transfer_approvals_reviewer_check" CHECK ("reviewer" <> "initiator");
+
+CREATE INDEX ON "transfer_approvals" ("status", "created_at");
+
+CREATE INDEX ON "transfer_approvals" ("initiator");
+
+COMMENT ON COLUMN "transfer_approvals"."transfer_id" IS 'transfer executed after approval';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "status_history";
+ALTER TABLE "transfers" DROP COLUMN IF EXISTS "status";
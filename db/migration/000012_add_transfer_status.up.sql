@@ -0,0 +1,29 @@
+ALTER TABLE "transfers" ADD COLUMN "status" varchar NOT NULL DEFAULT 'completed';
+
+ALTER TABLE "transfers" ADD CONSTRAINT "transfers_status_check" CHECK ("status" IN ('pending', 'completed', 'failed', 'reversed', 'cancelled'));
+
+CREATE TABLE "status_history" (
+  "id" bigserial PRIMARY KEY,
+  "transfer_id" bigint NOT NULL,
+  "from_status" varchar,
+  "to_status" varchar NOT NULL,
+  "reason" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "status_history" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+CREATE INDEX ON "status_history" ("transfer_id");
+
+COMMENT ON COLUMN "status_history"."from_status" IS 'null for the status a transfer was created with';
+
+-- 이미 있는 transfer는 돈이 움직인 것이므로 completed로 시작하고, 전액 되돌려진 transfer는 reversed가 된다.
+INSERT INTO "status_history" ("transfer_id", "to_status", "created_at")
+SELECT "id", 'completed', "created_at" FROM "transfers";
+
+INSERT INTO "status_history" ("transfer_id", "from_status", "to_status", "reason", "created_at")
+SELECT t."id", 'completed', 'reversed', 'backfill', now() FROM "transfers" t
+WHERE t."amount" <= (SELECT COALESCE(SUM(r."amount"), 0) FROM "transfers" r WHERE r."reversal_of" = t."id");
+
+UPDATE "transfers" t SET "status" = 'reversed'
+WHERE t."amount" <= (SELECT COALESCE(SUM(r."amount"), 0) FROM "transfers" r WHERE r."reversal_of" = t."id");
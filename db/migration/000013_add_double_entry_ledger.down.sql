@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS "entries_journal_balanced" ON "entries";
+DROP FUNCTION IF EXISTS check_journal_balanced();
+DELETE FROM "entries" WHERE "ledger_account_id" IS NOT NULL;
+ALTER TABLE "entries" DROP COLUMN IF EXISTS "journal_id";
+ALTER TABLE "entries" DROP COLUMN IF EXISTS "ledger_account_id";
+ALTER TABLE "entries" DROP COLUMN IF EXISTS "currency";
+ALTER TABLE "entries" ALTER COLUMN "account_id" SET NOT NULL;
+DROP TABLE IF EXISTS "journal_transactions";
+DROP TABLE IF EXISTS "ledger_accounts";
@@ -0,0 +1,82 @@
+-- 회사 쪽 계정(현금, 수수료, 환전)의 chart of accounts. 고객 계좌는 accounts에 그대로 있다.
+CREATE TABLE "ledger_accounts" (
+  "id" bigserial PRIMARY KEY,
+  "code" varchar NOT NULL,
+  "name" varchar NOT NULL,
+  "type" varchar NOT NULL,
+  "currency" varchar NOT NULL,
+  "balance" bigint NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "ledger_accounts" ADD CONSTRAINT "code_currency_key" UNIQUE ("code", "currency");
+
+ALTER TABLE "ledger_accounts" ADD CONSTRAINT "ledger_accounts_type_check" CHECK ("type" IN ('asset', 'liability', 'equity', 'income', 'expense'));
+
+CREATE TABLE "journal_transactions" (
+  "id" bigserial PRIMARY KEY,
+  "kind" varchar NOT NULL,
+  "transfer_id" bigint,
+  "description" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "journal_transactions" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+CREATE INDEX ON "journal_transactions" ("transfer_id");
+
+-- entries는 journal transaction의 posting이 된다. 고객 계좌나 ledger account 중 하나에 기록된다.
+ALTER TABLE "entries" ADD COLUMN "journal_id" bigint;
+
+ALTER TABLE "entries" ADD COLUMN "ledger_account_id" bigint;
+
+ALTER TABLE "entries" ADD COLUMN "currency" varchar;
+
+UPDATE "entries" e SET "currency" = a."currency" FROM "accounts" a WHERE a."id" = e."account_id";
+
+ALTER TABLE "entries" ALTER COLUMN "currency" SET NOT NULL;
+
+ALTER TABLE "entries" ALTER COLUMN "account_id" DROP NOT NULL;
+
+ALTER TABLE "entries" ADD FOREIGN KEY ("journal_id") REFERENCES "journal_transactions" ("id");
+
+ALTER TABLE "entries" ADD FOREIGN KEY ("ledger_account_id") REFERENCES "ledger_accounts" ("id");
+
+ALTER TABLE "entries" ADD CONSTRAINT "entries_target_check" CHECK (("account_id" IS NULL) <> ("ledger_account_id" IS NULL));
+
+CREATE INDEX ON "entries" ("journal_id");
+
+CREATE INDEX ON "entries" ("ledger_account_id");
+
+COMMENT ON COLUMN "entries"."journal_id" IS 'null for entries posted before the double-entry ledger';
+
+-- commit 시점에 journal transaction의 posting 합이 통화별로 0인지 검사한다.
+CREATE FUNCTION check_journal_balanced() RETURNS trigger AS $$
+BEGIN
+  IF EXISTS (
+    SELECT 1 FROM entries
+    WHERE journal_id = NEW.journal_id
+    GROUP BY currency
+    HAVING SUM(amount) <> 0
+  ) THEN
+    RAISE EXCEPTION 'journal transaction % does not balance', NEW.journal_id
+      USING ERRCODE = 'check_violation';
+  END IF;
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER "entries_journal_balanced"
+AFTER INSERT OR UPDATE ON "entries"
+DEFERRABLE INITIALLY DEFERRED
+FOR EACH ROW WHEN (NEW.journal_id IS NOT NULL)
+EXECUTE PROCEDURE check_journal_balanced();
+
+INSERT INTO "ledger_accounts" ("code", "name", "type", "currency")
+SELECT a."code", a."name", a."type", c."currency"
+FROM (VALUES
+  ('cash', 'Cash', 'asset'),
+  ('fees', 'Fee income', 'income'),
+  ('fx', 'FX clearing', 'asset')
+) AS a ("code", "name", "type")
+CROSS JOIN (VALUES ('USD'), ('EUR'), ('CAD'), ('WON')) AS c ("currency");
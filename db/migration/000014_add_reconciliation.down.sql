@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "reconciliation_runs";
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "status";
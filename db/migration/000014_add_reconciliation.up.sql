@@ -0,0 +1,16 @@
+ALTER TABLE "accounts" ADD COLUMN "status" varchar NOT NULL DEFAULT 'active';
+
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_status_check" CHECK ("status" IN ('active', 'frozen'));
+
+CREATE TABLE "reconciliation_runs" (
+  "id" bigserial PRIMARY KEY,
+  "accounts_checked" bigint NOT NULL,
+  "transfers_checked" bigint NOT NULL,
+  "discrepancy_count" integer NOT NULL,
+  "frozen_count" integer NOT NULL DEFAULT 0,
+  "report" jsonb NOT NULL,
+  "started_at" timestamptz NOT NULL,
+  "finished_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "reconciliation_runs" ("started_at");
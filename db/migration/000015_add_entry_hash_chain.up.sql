@@ -0,0 +1,8 @@
+-- 계좌별로 entry를 이전 entry의 hash에 연결해서, 나중에 entries가 수정되거나 삭제되면 검증에서 드러나게 한다.
+ALTER TABLE "entries" ADD COLUMN "prev_hash" varchar NOT NULL DEFAULT '';
+
+ALTER TABLE "entries" ADD COLUMN "hash" varchar NOT NULL DEFAULT '';
+
+CREATE INDEX ON "entries" ("ledger_account_id", "id");
+
+COMMENT ON COLUMN "entries"."hash" IS 'empty for entries posted before the hash chain';
@@ -0,0 +1,16 @@
+-- 하루가 끝날 때의 잔액을 저장해서, 과거 시점의 잔액을 계산할 때 entries 전체를 더하지 않아도 되게 한다.
+CREATE TABLE "balance_snapshots" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "balance" bigint NOT NULL,
+  "taken_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "balance_snapshots" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "balance_snapshots" ADD CONSTRAINT "account_taken_at_key" UNIQUE ("account_id", "taken_at");
+
+CREATE INDEX ON "entries" ("account_id", "created_at");
+
+COMMENT ON COLUMN "balance_snapshots"."balance" IS 'sum of the account entries created before taken_at';
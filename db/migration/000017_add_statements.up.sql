@@ -0,0 +1,16 @@
+-- 지난 달 거래명세서를 미리 만들어 둔다. data에는 렌더링 전의 statement JSON이 그대로 들어간다.
+CREATE TABLE "statements" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "period" varchar NOT NULL,
+  "opening_balance" bigint NOT NULL,
+  "closing_balance" bigint NOT NULL,
+  "data" jsonb NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "statements" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "statements" ADD CONSTRAINT "account_period_key" UNIQUE ("account_id", "period");
+
+COMMENT ON COLUMN "statements"."period" IS 'calendar month in UTC, formatted as YYYY-MM';
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "interest_accruals";
+DROP TABLE IF EXISTS "interest_postings";
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "type";
+DROP TABLE IF EXISTS "account_products";
@@ -0,0 +1,69 @@
+-- 계좌 종류별 이자율. annual_rate_bps는 연 이자율을 basis point(0.01%) 단위로 저장한다.
+CREATE TABLE "account_products" (
+  "code" varchar PRIMARY KEY,
+  "name" varchar NOT NULL,
+  "annual_rate_bps" int NOT NULL DEFAULT 0,
+  "day_count" varchar NOT NULL DEFAULT 'ACT/365',
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "account_products" ADD CONSTRAINT "account_products_rate_check" CHECK ("annual_rate_bps" >= 0);
+
+ALTER TABLE "account_products" ADD CONSTRAINT "account_products_day_count_check" CHECK ("day_count" IN ('ACT/365', 'ACT/360', 'ACT/ACT', '30/360'));
+
+INSERT INTO "account_products" ("code", "name", "annual_rate_bps", "day_count") VALUES
+  ('checking', 'Checking', 0, 'ACT/365'),
+  ('savings', 'Savings', 150, 'ACT/365');
+
+ALTER TABLE "accounts" ADD COLUMN "type" varchar NOT NULL DEFAULT 'checking';
+
+ALTER TABLE "accounts" ADD FOREIGN KEY ("type") REFERENCES "account_products" ("code");
+
+-- 하루치 이자. 최소 단위의 1/1000000 단위로 저장해서 매일 반올림하면서 생기는 손실이 없게 한다.
+CREATE TABLE "interest_accruals" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "accrual_date" date NOT NULL,
+  "balance" bigint NOT NULL,
+  "annual_rate_bps" int NOT NULL,
+  "day_count" varchar NOT NULL,
+  "amount_micros" bigint NOT NULL,
+  "posting_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "interest_accruals" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "interest_accruals" ADD CONSTRAINT "account_accrual_date_key" UNIQUE ("account_id", "accrual_date");
+
+COMMENT ON COLUMN "interest_accruals"."balance" IS 'account balance at the end of accrual_date in UTC';
+
+-- 한 달 동안 쌓인 이자를 계좌에 지급한 기록. 최소 단위로 떨어지지 않는 나머지는 carry_micros로 다음 달에 넘긴다.
+CREATE TABLE "interest_postings" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "period" varchar NOT NULL,
+  "accrued_micros" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "carry_micros" bigint NOT NULL,
+  "journal_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "interest_postings" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "interest_postings" ADD FOREIGN KEY ("journal_id") REFERENCES "journal_transactions" ("id");
+
+ALTER TABLE "interest_postings" ADD CONSTRAINT "account_posting_period_key" UNIQUE ("account_id", "period");
+
+ALTER TABLE "interest_accruals" ADD FOREIGN KEY ("posting_id") REFERENCES "interest_postings" ("id");
+
+CREATE INDEX ON "interest_accruals" ("account_id", "posting_id");
+
+COMMENT ON COLUMN "interest_postings"."journal_id" IS 'null when the accrued interest was less than one minor unit';
+
+-- 이자는 은행의 비용이다. 이미 entries가 있을 수 있어서 down migration에서는 지우지 않는다.
+INSERT INTO "ledger_accounts" ("code", "name", "type", "currency")
+SELECT 'interest', 'Interest expense', 'expense', c."currency"
+FROM (VALUES ('USD'), ('EUR'), ('CAD'), ('WON')) AS c ("currency")
+ON CONFLICT ("code", "currency") DO NOTHING;
@@ -0,0 +1,42 @@
+-- 계좌 종류와 통화, 거래 종류별 수수료. 행이 없으면 수수료가 없다.
+-- 수수료는 flat_amount + 금액 * percentage_bps / 10000이고, min_amount와 max_amount(0이면 상한 없음) 사이로 맞춘다.
+CREATE TABLE "fee_schedules" (
+  "id" bigserial PRIMARY KEY,
+  "product" varchar NOT NULL,
+  "currency" varchar NOT NULL,
+  "operation" varchar NOT NULL,
+  "flat_amount" bigint NOT NULL DEFAULT 0,
+  "percentage_bps" int NOT NULL DEFAULT 0,
+  "min_amount" bigint NOT NULL DEFAULT 0,
+  "max_amount" bigint NOT NULL DEFAULT 0,
+  "updated_by" varchar NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "fee_schedules" ADD FOREIGN KEY ("product") REFERENCES "account_products" ("code");
+
+ALTER TABLE "fee_schedules" ADD FOREIGN KEY ("updated_by") REFERENCES "users" ("username");
+
+ALTER TABLE "fee_schedules" ADD CONSTRAINT "product_currency_operation_key" UNIQUE ("product", "currency", "operation");
+
+ALTER TABLE "fee_schedules" ADD CONSTRAINT "fee_schedules_operation_check" CHECK ("operation" IN ('transfer', 'maintenance'));
+
+ALTER TABLE "fee_schedules" ADD CONSTRAINT "fee_schedules_amount_check" CHECK ("flat_amount" >= 0 AND "percentage_bps" >= 0 AND "min_amount" >= 0 AND "max_amount" >= 0);
+
+-- 월 관리 수수료는 계좌마다 한 달에 한 번만 청구한다. 잔액이 모자라면 journal 없이 면제된 것으로 기록한다.
+CREATE TABLE "maintenance_fee_charges" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "period" varchar NOT NULL,
+  "amount" bigint NOT NULL,
+  "journal_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "maintenance_fee_charges" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "maintenance_fee_charges" ADD FOREIGN KEY ("journal_id") REFERENCES "journal_transactions" ("id");
+
+ALTER TABLE "maintenance_fee_charges" ADD CONSTRAINT "account_fee_period_key" UNIQUE ("account_id", "period");
+
+COMMENT ON COLUMN "maintenance_fee_charges"."journal_id" IS 'null when the fee was waived because the account could not cover it';
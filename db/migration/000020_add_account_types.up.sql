@@ -0,0 +1,9 @@
+INSERT INTO "account_products" ("code", "name", "annual_rate_bps", "day_count") VALUES
+  ('business', 'Business', 0, 'ACT/365');
+
+ALTER TABLE "accounts" ADD COLUMN "nickname" varchar NOT NULL DEFAULT '';
+
+-- 통화마다 계좌 종류별로 하나씩 만들 수 있다.
+ALTER TABLE "accounts" DROP CONSTRAINT IF EXISTS "owner_currency_key";
+
+ALTER TABLE "accounts" ADD CONSTRAINT "owner_currency_type_key" UNIQUE ("owner", "currency", "type");
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS "owner_currency_type_key";
+ALTER TABLE "accounts" ADD CONSTRAINT "owner_currency_type_key" UNIQUE ("owner", "currency", "type");
+DROP TABLE IF EXISTS "account_status_history";
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "closed_at";
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "status_reason";
+UPDATE "accounts" SET "status" = 'frozen' WHERE "status" = 'closed';
+ALTER TABLE "accounts" DROP CONSTRAINT IF EXISTS "accounts_status_check";
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_status_check" CHECK ("status" IN ('active', 'frozen'));
@@ -0,0 +1,28 @@
+ALTER TABLE "accounts" DROP CONSTRAINT IF EXISTS "accounts_status_check";
+
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_status_check" CHECK ("status" IN ('active', 'frozen', 'closed'));
+
+ALTER TABLE "accounts" ADD COLUMN "status_reason" varchar NOT NULL DEFAULT '';
+
+ALTER TABLE "accounts" ADD COLUMN "closed_at" timestamptz;
+
+CREATE TABLE "account_status_history" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "from_status" varchar NOT NULL,
+  "to_status" varchar NOT NULL,
+  "reason" varchar NOT NULL DEFAULT '',
+  "changed_by" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "account_status_history" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+CREATE INDEX ON "account_status_history" ("account_id");
+
+COMMENT ON COLUMN "account_status_history"."changed_by" IS 'username of the user or banker, or the job that changed the status';
+
+-- 해지된 계좌는 남겨두므로, 같은 통화와 종류의 계좌를 다시 만들 수 있도록 해지되지 않은 계좌에만 unique를 건다.
+ALTER TABLE "accounts" DROP CONSTRAINT IF EXISTS "owner_currency_type_key";
+
+CREATE UNIQUE INDEX "owner_currency_type_key" ON "accounts" ("owner", "currency", "type") WHERE "status" <> 'closed';
@@ -0,0 +1,32 @@
+CREATE TABLE "account_holders" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "username" varchar NOT NULL,
+  "role" varchar NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'invited',
+  "invited_by" varchar,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "accepted_at" timestamptz
+);
+
+ALTER TABLE "account_holders" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "account_holders" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "account_holders" ADD FOREIGN KEY ("invited_by") REFERENCES "users" ("username");
+
+ALTER TABLE "account_holders" ADD CONSTRAINT "account_holders_role_check" CHECK ("role" IN ('primary', 'joint', 'viewer'));
+
+ALTER TABLE "account_holders" ADD CONSTRAINT "account_holders_status_check" CHECK ("status" IN ('invited', 'active'));
+
+ALTER TABLE "account_holders" ADD CONSTRAINT "account_holder_key" UNIQUE ("account_id", "username");
+
+CREATE INDEX ON "account_holders" ("username");
+
+-- 계좌마다 primary holder는 하나뿐이고, accounts.owner와 같다.
+CREATE UNIQUE INDEX "account_primary_holder_key" ON "account_holders" ("account_id") WHERE "role" = 'primary';
+
+COMMENT ON COLUMN "account_holders"."invited_by" IS 'null for the primary holder';
+
+INSERT INTO "account_holders" ("account_id", "username", "role", "status", "created_at", "accepted_at")
+SELECT "id", "owner", 'primary', 'active', "created_at", "created_at" FROM "accounts";
@@ -0,0 +1,3 @@
+ALTER TABLE "entries" ADD COLUMN "metadata" jsonb NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN "entries"."metadata" IS 'copied from the transfer like description and reference';
@@ -0,0 +1 @@
+COMMENT ON COLUMN "transfers"."status" IS NULL;
@@ -0,0 +1,2 @@
+-- 돈이 움직인 transfer는 completed로 만들어진다. pending은 completed, failed, cancelled로만 갈 수 있고 completed는 reversed로만 갈 수 있다.
+COMMENT ON COLUMN "transfers"."status" IS 'pending -> completed | failed | cancelled, completed -> reversed';
@@ -0,0 +1,12 @@
+-- 아직 반영되지 않은 ledger entry는 balance에 더해 두어야 reconciliation이 맞는다.
+UPDATE "ledger_accounts"
+SET "balance" = "ledger_accounts"."balance" + "pending"."total"
+FROM (
+  SELECT "ledger_account_id", SUM("amount") AS "total" FROM "entries"
+  WHERE "ledger_account_id" IS NOT NULL AND "ledger_seq" IS NULL
+  GROUP BY "ledger_account_id"
+) AS "pending"
+WHERE "ledger_accounts"."id" = "pending"."ledger_account_id";
+
+ALTER TABLE IF EXISTS "entries" DROP COLUMN IF EXISTS "ledger_seq";
+DROP SEQUENCE IF EXISTS "entries_ledger_seq";
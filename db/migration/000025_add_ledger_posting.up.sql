@@ -0,0 +1,16 @@
+-- ledger account row를 posting마다 갱신하면 같은 통화의 deposit, withdrawal, fee, interest가 모두 그 row 하나에서 줄을 선다.
+-- 그래서 ledger 쪽 entry는 기록만 해 두고, ledger poster가 ledger account별로 모아서 balance와 hash chain에 반영한다.
+CREATE SEQUENCE "entries_ledger_seq";
+
+ALTER TABLE "entries" ADD COLUMN "ledger_seq" bigint;
+
+-- 지금까지의 ledger entry는 이미 balance에 반영됐고, hash chain도 id 순서로 연결돼 있다.
+UPDATE "entries" SET "ledger_seq" = "id" WHERE "ledger_account_id" IS NOT NULL;
+
+SELECT setval('entries_ledger_seq', COALESCE((SELECT MAX("id") FROM "entries"), 0) + 1, false);
+
+CREATE INDEX ON "entries" ("ledger_account_id", "ledger_seq");
+
+CREATE INDEX ON "entries" ("ledger_account_id") WHERE "ledger_account_id" IS NOT NULL AND "ledger_seq" IS NULL;
+
+COMMENT ON COLUMN "entries"."ledger_seq" IS 'position in the ledger account hash chain; NULL until the ledger poster applies the entry';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "payment_instructions";
+DROP TABLE IF EXISTS "payment_initiations";
@@ -0,0 +1,25 @@
+-- pain.001로 들어온 MsgId와 InstrId를 기록해서, 같은 파일이나 같은 거래가 다시 들어오면 두 번 실행하지 않고 DUPL로 거절한다.
+CREATE TABLE "payment_initiations" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "message_id" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "payment_initiations" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "payment_initiations" ADD CONSTRAINT "owner_message_id_key" UNIQUE ("owner", "message_id");
+
+CREATE TABLE "payment_instructions" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "instruction_id" varchar NOT NULL,
+  "message_id" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "payment_instructions" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "payment_instructions" ADD CONSTRAINT "owner_instruction_id_key" UNIQUE ("owner", "instruction_id");
+
+COMMENT ON COLUMN "payment_instructions"."message_id" IS 'MsgId of the pain.001 document the instruction came in';
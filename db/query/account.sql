@@ -2,9 +2,11 @@
 INSERT INTO accounts (
   owner,
   balance,
-  currency
+  currency,
+  type,
+  nickname
 ) VALUES (
-  $1, $2, $3
+  $1, $2, $3, $4, $5
 ) RETURNING *;
 
 -- name: GetAccount :one
@@ -16,12 +18,27 @@ SELECT * FROM accounts
 WHERE id = $1 LIMIT 1
 FOR NO KEY UPDATE;
 
--- name: ListAccount :many
+-- name: GetAccountByOwnerAndCurrency :one
 SELECT * FROM accounts
-WHERE owner = $1
-ORDER BY id
-LIMIT $2
-OFFSET $3;
+WHERE owner = $1 AND currency = $2 AND status <> 'closed'
+ORDER BY (type = 'checking') DESC, id
+LIMIT 1;
+
+-- name: ListAccount :many
+SELECT a.* FROM accounts a
+JOIN account_holders h ON h.account_id = a.id
+WHERE h.username = sqlc.arg(username) AND h.status = 'active'
+  AND (sqlc.arg(type)::varchar = '' OR a.type = sqlc.arg(type))
+  AND a.status <> 'closed'
+ORDER BY a.id
+LIMIT sqlc.arg(limit)
+OFFSET sqlc.arg(offset);
+
+-- name: UpdateAccountNickname :one
+UPDATE accounts
+SET nickname = $2
+WHERE id = $1
+RETURNING *;
 
 -- name: UpdateAccount :one
 UPDATE accounts
@@ -35,6 +52,35 @@ SET balance = balance + sqlc.arg(amount)
 WHERE id = sqlc.arg(id)
 RETURNING *;
 
--- name: DeleteAccount :exec
-DELETE FROM accounts
-WHERE id = $1;
\ No newline at end of file
+-- name: UpdateAccountStatus :one
+UPDATE accounts
+SET status = sqlc.arg(to_status),
+    status_reason = sqlc.arg(reason),
+    closed_at = CASE WHEN sqlc.arg(to_status) = 'closed' THEN now() ELSE closed_at END
+WHERE id = sqlc.arg(id) AND status = sqlc.arg(from_status)
+RETURNING *;
+
+-- name: CreateAccountStatusHistory :one
+INSERT INTO account_status_history (
+  account_id,
+  from_status,
+  to_status,
+  reason,
+  changed_by
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: ListAccountStatusHistory :many
+SELECT * FROM account_status_history
+WHERE account_id = $1
+ORDER BY id;
+
+-- name: ListAccountsByIDs :many
+SELECT * FROM accounts
+WHERE id = ANY(sqlc.arg(ids)::bigint[])
+ORDER BY id;
+
+-- name: ListAccountIDs :many
+SELECT id FROM accounts
+ORDER BY id;
@@ -0,0 +1,40 @@
+-- name: CreateAccountHold :one
+INSERT INTO account_holds (
+  account_id,
+  to_account_id,
+  amount,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetAccountHold :one
+SELECT * FROM account_holds
+WHERE id = $1 LIMIT 1;
+
+-- name: GetAccountHoldForUpdate :one
+SELECT * FROM account_holds
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: GetHeldAmount :one
+SELECT COALESCE(SUM(amount), 0)::bigint AS held_amount FROM account_holds
+WHERE account_id = $1 AND status = 'active' AND expires_at > now();
+
+-- name: CaptureAccountHold :one
+UPDATE account_holds
+SET status = 'captured', captured_amount = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateAccountHoldStatus :one
+UPDATE account_holds
+SET status = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: ExpireAccountHolds :many
+UPDATE account_holds
+SET status = 'expired'
+WHERE status = 'active' AND expires_at <= $1
+RETURNING *;
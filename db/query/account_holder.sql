@@ -0,0 +1,40 @@
+-- name: CreateAccountHolder :one
+INSERT INTO account_holders (
+  account_id,
+  username,
+  role,
+  status,
+  invited_by,
+  accepted_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetAccountHolder :one
+SELECT * FROM account_holders
+WHERE id = $1 LIMIT 1;
+
+-- name: GetAccountHolderByUsername :one
+SELECT * FROM account_holders
+WHERE account_id = $1 AND username = $2 LIMIT 1;
+
+-- name: ListAccountHolders :many
+SELECT * FROM account_holders
+WHERE account_id = $1
+ORDER BY id;
+
+-- name: ListAccountInvitations :many
+SELECT * FROM account_holders
+WHERE username = $1 AND status = 'invited'
+ORDER BY id;
+
+-- name: AcceptAccountInvitation :one
+UPDATE account_holders
+SET status = 'active',
+    accepted_at = now()
+WHERE id = $1 AND status = 'invited'
+RETURNING *;
+
+-- name: DeleteAccountHolder :exec
+DELETE FROM account_holders
+WHERE id = $1;
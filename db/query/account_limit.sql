@@ -0,0 +1,24 @@
+-- name: GetAccountLimit :one
+SELECT * FROM account_limits
+WHERE account_id = $1 LIMIT 1;
+
+-- name: UpsertAccountLimit :one
+INSERT INTO account_limits (
+  account_id,
+  max_per_transfer,
+  max_daily_amount,
+  max_daily_count,
+  updated_by
+) VALUES (
+  $1, $2, $3, $4, $5
+) ON CONFLICT (account_id) DO UPDATE
+SET max_per_transfer = EXCLUDED.max_per_transfer,
+    max_daily_amount = EXCLUDED.max_daily_amount,
+    max_daily_count = EXCLUDED.max_daily_count,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = now()
+RETURNING *;
+
+-- name: DeleteAccountLimit :exec
+DELETE FROM account_limits
+WHERE account_id = $1;
@@ -0,0 +1,33 @@
+-- name: CreateBalanceSnapshot :one
+INSERT INTO balance_snapshots (
+  account_id,
+  balance,
+  taken_at
+) VALUES (
+  $1, $2, $3
+)
+ON CONFLICT (account_id, taken_at) DO NOTHING
+RETURNING *;
+
+-- name: GetLatestBalanceSnapshot :one
+SELECT * FROM balance_snapshots
+WHERE account_id = sqlc.arg(account_id) AND taken_at <= sqlc.arg(before)
+ORDER BY taken_at DESC
+LIMIT 1;
+
+-- name: SumAccountEntries :one
+SELECT COALESCE(SUM(amount), 0)::bigint AS total
+FROM entries
+WHERE account_id = sqlc.arg(account_id)::bigint
+  AND created_at >= sqlc.arg(from_time)
+  AND created_at < sqlc.arg(to_time);
+
+-- name: ListDailyEntryTotals :many
+SELECT (date_trunc('day', created_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC')::timestamptz AS day,
+  SUM(amount)::bigint AS total
+FROM entries
+WHERE account_id = sqlc.arg(account_id)::bigint
+  AND created_at >= sqlc.arg(from_time)
+  AND created_at < sqlc.arg(to_time)
+GROUP BY day
+ORDER BY day;
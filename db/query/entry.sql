@@ -1,9 +1,15 @@
 -- name: CreateEntry :one
 INSERT INTO entries (
+  journal_id,
   account_id,
-  amount
+  ledger_account_id,
+  currency,
+  amount,
+  description,
+  reference,
+  metadata
 ) VALUES (
-  $1, $2
+  $1, $2, $3, $4, $5, $6, $7, $8
 ) RETURNING *;
 
 -- name: GetEntry :one
@@ -12,7 +18,55 @@ WHERE id = $1 LIMIT 1;
 
 -- name: ListEntries :many
 SELECT * FROM entries
-WHERE account_id = $1
+WHERE account_id = sqlc.arg(account_id)::bigint
 ORDER BY id
-LIMIT $2
-OFFSET $3;
\ No newline at end of file
+LIMIT sqlc.arg(limit)
+OFFSET sqlc.arg(offset);
+
+-- name: GetLastAccountEntryHash :one
+SELECT hash FROM entries
+WHERE account_id = sqlc.arg(account_id)::bigint AND hash <> ''
+ORDER BY id DESC
+LIMIT 1;
+
+-- name: GetLastLedgerEntryHash :one
+SELECT hash FROM entries
+WHERE ledger_account_id = sqlc.arg(ledger_account_id)::bigint AND ledger_seq IS NOT NULL AND hash <> ''
+ORDER BY ledger_seq DESC
+LIMIT 1;
+
+-- name: SetEntryHash :one
+UPDATE entries
+SET prev_hash = $2, hash = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: ListAccountEntryChain :many
+SELECT * FROM entries
+WHERE account_id = sqlc.arg(account_id)::bigint AND id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg(limit);
+
+-- name: ListLedgerEntryChain :many
+SELECT * FROM entries
+WHERE ledger_account_id = sqlc.arg(ledger_account_id)::bigint AND ledger_seq > sqlc.arg(after_seq)::bigint
+ORDER BY ledger_seq
+LIMIT sqlc.arg(limit);
+
+-- name: ListPendingLedgerAccountIDs :many
+SELECT ledger_account_id::bigint AS ledger_account_id FROM entries
+WHERE ledger_account_id IS NOT NULL AND ledger_seq IS NULL
+GROUP BY ledger_account_id
+ORDER BY ledger_account_id;
+
+-- name: ListPendingLedgerEntries :many
+SELECT * FROM entries
+WHERE ledger_account_id = sqlc.arg(ledger_account_id)::bigint AND ledger_seq IS NULL
+ORDER BY id
+LIMIT sqlc.arg(limit);
+
+-- name: SetLedgerEntryHash :one
+UPDATE entries
+SET prev_hash = $2, hash = $3, ledger_seq = nextval('entries_ledger_seq')
+WHERE id = $1
+RETURNING *;
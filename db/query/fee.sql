@@ -0,0 +1,58 @@
+-- name: GetFeeSchedule :one
+SELECT * FROM fee_schedules
+WHERE product = $1 AND currency = $2 AND operation = $3 LIMIT 1;
+
+-- name: ListFeeSchedules :many
+SELECT * FROM fee_schedules
+ORDER BY product, currency, operation;
+
+-- name: UpsertFeeSchedule :one
+INSERT INTO fee_schedules (
+  product,
+  currency,
+  operation,
+  flat_amount,
+  percentage_bps,
+  min_amount,
+  max_amount,
+  updated_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+) ON CONFLICT (product, currency, operation) DO UPDATE
+SET flat_amount = EXCLUDED.flat_amount,
+    percentage_bps = EXCLUDED.percentage_bps,
+    min_amount = EXCLUDED.min_amount,
+    max_amount = EXCLUDED.max_amount,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = now()
+RETURNING *;
+
+-- name: DeleteFeeSchedule :one
+DELETE FROM fee_schedules
+WHERE id = $1
+RETURNING *;
+
+-- name: ListMaintenanceFeeAccounts :many
+SELECT a.id FROM accounts a
+JOIN fee_schedules f ON f.product = a.type AND f.currency = a.currency
+WHERE f.operation = 'maintenance'
+  AND a.created_at < sqlc.arg(created_before)
+  AND a.status <> 'closed'
+ORDER BY a.id;
+
+-- name: CreateMaintenanceFeeCharge :one
+INSERT INTO maintenance_fee_charges (
+  account_id,
+  period,
+  amount
+) VALUES (
+  $1, $2, $3
+)
+ON CONFLICT (account_id, period) DO NOTHING
+RETURNING *;
+
+-- name: SetMaintenanceFeeChargeJournal :one
+UPDATE maintenance_fee_charges
+SET journal_id = $2
+WHERE id = $1
+RETURNING *;
@@ -0,0 +1,90 @@
+-- name: GetAccountProduct :one
+SELECT * FROM account_products
+WHERE code = $1 LIMIT 1;
+
+-- name: ListAccountProducts :many
+SELECT * FROM account_products
+ORDER BY code;
+
+-- name: UpdateAccountProduct :one
+UPDATE account_products
+SET annual_rate_bps = $2, day_count = $3, updated_at = now()
+WHERE code = $1
+RETURNING *;
+
+-- name: ListInterestBearingAccounts :many
+SELECT a.* FROM accounts a
+JOIN account_products p ON p.code = a.type
+WHERE p.annual_rate_bps > 0 AND a.status <> 'closed'
+ORDER BY a.id;
+
+-- name: GetLastInterestAccrualDate :one
+SELECT accrual_date FROM interest_accruals
+WHERE account_id = $1
+ORDER BY accrual_date DESC
+LIMIT 1;
+
+-- name: CreateInterestAccrual :one
+INSERT INTO interest_accruals (
+  account_id,
+  accrual_date,
+  balance,
+  annual_rate_bps,
+  day_count,
+  amount_micros
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (account_id, accrual_date) DO NOTHING
+RETURNING *;
+
+-- name: ListAccountsWithUnpostedInterest :many
+SELECT account_id FROM interest_accruals
+WHERE posting_id IS NULL AND accrual_date < sqlc.arg(before)
+GROUP BY account_id
+ORDER BY account_id;
+
+-- name: SumUnpostedInterestAccruals :one
+SELECT COALESCE(SUM(amount_micros), 0)::bigint AS total
+FROM interest_accruals
+WHERE account_id = sqlc.arg(account_id)::bigint
+  AND posting_id IS NULL
+  AND accrual_date < sqlc.arg(before);
+
+-- name: MarkInterestAccrualsPosted :exec
+UPDATE interest_accruals
+SET posting_id = sqlc.arg(posting_id)
+WHERE account_id = sqlc.arg(account_id)
+  AND posting_id IS NULL
+  AND accrual_date < sqlc.arg(before);
+
+-- name: GetLastInterestPosting :one
+SELECT * FROM interest_postings
+WHERE account_id = $1
+ORDER BY id DESC
+LIMIT 1;
+
+-- name: CreateInterestPosting :one
+INSERT INTO interest_postings (
+  account_id,
+  period,
+  accrued_micros,
+  amount,
+  carry_micros
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+ON CONFLICT (account_id, period) DO NOTHING
+RETURNING *;
+
+-- name: SetInterestPostingJournal :one
+UPDATE interest_postings
+SET journal_id = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: ListInterestPostings :many
+SELECT * FROM interest_postings
+WHERE account_id = $1
+ORDER BY id DESC
+LIMIT $2;
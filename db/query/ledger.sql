@@ -0,0 +1,40 @@
+-- name: GetLedgerAccount :one
+SELECT * FROM ledger_accounts
+WHERE code = $1 AND currency = $2 LIMIT 1;
+
+-- name: ListLedgerAccounts :many
+SELECT * FROM ledger_accounts
+ORDER BY code, currency;
+
+-- name: AddLedgerAccountBalance :one
+UPDATE ledger_accounts
+SET balance = balance + sqlc.arg(amount)
+WHERE code = sqlc.arg(code) AND currency = sqlc.arg(currency)
+RETURNING *;
+
+-- name: CreateJournalTransaction :one
+INSERT INTO journal_transactions (
+  kind,
+  transfer_id,
+  description
+) VALUES (
+  $1, $2, $3
+) RETURNING *;
+
+-- name: GetJournalTransaction :one
+SELECT * FROM journal_transactions
+WHERE id = $1 LIMIT 1;
+
+-- name: ListJournalEntries :many
+SELECT * FROM entries
+WHERE journal_id = sqlc.arg(journal_id)::bigint
+ORDER BY id;
+
+-- name: GetLedgerAccountByID :one
+SELECT * FROM ledger_accounts
+WHERE id = $1 LIMIT 1;
+
+-- name: GetLedgerAccountForUpdate :one
+SELECT * FROM ledger_accounts
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
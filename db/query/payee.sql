@@ -0,0 +1,44 @@
+-- name: CreatePayee :one
+INSERT INTO payees (
+  owner,
+  nickname,
+  account_id,
+  recipient,
+  currency
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetPayee :one
+SELECT * FROM payees
+WHERE id = $1 LIMIT 1;
+
+-- name: ListPayees :many
+SELECT * FROM payees
+WHERE owner = $1
+ORDER BY nickname
+LIMIT $2
+OFFSET $3;
+
+-- name: UpdatePayee :one
+UPDATE payees
+SET nickname = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: DeletePayee :exec
+DELETE FROM payees
+WHERE id = $1;
+
+
+-- name: GetRecipientKnownSince :one
+SELECT min(known.created_at)::timestamptz AS known_since
+FROM (
+  SELECT created_at FROM payees
+  WHERE payees.owner = sqlc.arg(owner)::varchar AND payees.account_id = sqlc.arg(to_account_id)::bigint
+  UNION ALL
+  SELECT created_at FROM transfers
+  WHERE transfers.from_account_id = sqlc.arg(from_account_id)::bigint
+    AND transfers.to_account_id = sqlc.arg(to_account_id)::bigint
+    AND transfers.status = 'completed'
+) AS known;
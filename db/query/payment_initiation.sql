@@ -0,0 +1,20 @@
+-- name: CreatePaymentInitiation :one
+INSERT INTO payment_initiations (
+  owner,
+  message_id
+) VALUES (
+  $1, $2
+) RETURNING *;
+
+-- name: CreatePaymentInstruction :one
+INSERT INTO payment_instructions (
+  owner,
+  instruction_id,
+  message_id
+) VALUES (
+  $1, $2, $3
+) RETURNING *;
+
+-- name: DeletePaymentInstruction :exec
+DELETE FROM payment_instructions
+WHERE id = $1;
@@ -0,0 +1,60 @@
+-- name: CountAccounts :one
+SELECT COUNT(*) FROM accounts;
+
+-- name: ListAccountBalanceMismatches :many
+SELECT accounts.id, accounts.balance, COALESCE(SUM(entries.amount), 0)::bigint AS entries_total
+FROM accounts
+LEFT JOIN entries ON entries.account_id = accounts.id
+GROUP BY accounts.id
+HAVING accounts.balance <> COALESCE(SUM(entries.amount), 0)
+ORDER BY accounts.id;
+
+-- name: ListLedgerAccountBalanceMismatches :many
+SELECT ledger_accounts.id, ledger_accounts.code, ledger_accounts.currency, ledger_accounts.balance, COALESCE(SUM(entries.amount), 0)::bigint AS entries_total
+FROM ledger_accounts
+LEFT JOIN entries ON entries.ledger_account_id = ledger_accounts.id AND entries.ledger_seq IS NOT NULL
+GROUP BY ledger_accounts.id
+HAVING ledger_accounts.balance <> COALESCE(SUM(entries.amount), 0)
+ORDER BY ledger_accounts.id;
+
+-- name: CountJournalTransfers :one
+SELECT COUNT(DISTINCT transfer_id) FROM journal_transactions
+WHERE transfer_id IS NOT NULL;
+
+-- name: CountTransfersWithoutJournal :one
+SELECT COUNT(*) FROM transfers
+WHERE NOT EXISTS (
+  SELECT 1 FROM journal_transactions WHERE journal_transactions.transfer_id = transfers.id
+);
+
+-- name: ListTransferEntryMismatches :many
+SELECT transfers.id, transfers.from_account_id, transfers.to_account_id, transfers.amount,
+  COUNT(entries.id) AS entry_count,
+  COALESCE(SUM(CASE WHEN entries.account_id = transfers.from_account_id THEN entries.amount ELSE 0 END), 0)::bigint AS from_amount,
+  COALESCE(SUM(CASE WHEN entries.account_id = transfers.to_account_id THEN entries.amount ELSE 0 END), 0)::bigint AS to_amount
+FROM transfers
+JOIN journal_transactions ON journal_transactions.transfer_id = transfers.id AND journal_transactions.kind = 'transfer'
+LEFT JOIN entries ON entries.journal_id = journal_transactions.id
+GROUP BY transfers.id
+HAVING COUNT(entries.id) <> 2
+  OR COALESCE(SUM(CASE WHEN entries.account_id = transfers.from_account_id THEN entries.amount ELSE 0 END), 0) <> -transfers.amount
+  OR COALESCE(SUM(CASE WHEN entries.account_id = transfers.to_account_id THEN entries.amount ELSE 0 END), 0) <> transfers.amount
+ORDER BY transfers.id;
+
+-- name: CreateReconciliationRun :one
+INSERT INTO reconciliation_runs (
+  accounts_checked,
+  transfers_checked,
+  discrepancy_count,
+  frozen_count,
+  report,
+  started_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: ListReconciliationRuns :many
+SELECT * FROM reconciliation_runs
+ORDER BY id DESC
+LIMIT $1
+OFFSET $2;
@@ -0,0 +1,67 @@
+-- name: CreateStandingOrder :one
+INSERT INTO standing_orders (
+  owner,
+  from_account_id,
+  to_account_id,
+  amount,
+  frequency,
+  day,
+  start_date,
+  end_date,
+  max_occurrences,
+  next_run_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+) RETURNING *;
+
+-- name: GetStandingOrder :one
+SELECT * FROM standing_orders
+WHERE id = $1 LIMIT 1;
+
+-- name: GetStandingOrderForUpdate :one
+SELECT * FROM standing_orders
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListStandingOrders :many
+SELECT * FROM standing_orders
+WHERE owner = $1
+ORDER BY id
+LIMIT $2
+OFFSET $3;
+
+-- name: ListDueStandingOrders :many
+SELECT * FROM standing_orders
+WHERE status = 'active' AND
+    next_run_at <= $1 AND
+    (failed_attempts = 0 OR last_attempt_at <= $2)
+ORDER BY next_run_at
+LIMIT $3;
+
+-- name: RecordStandingOrderFailure :one
+UPDATE standing_orders
+SET failed_attempts = failed_attempts + 1,
+    last_attempt_at = $2,
+    last_error = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateStandingOrderSchedule :one
+UPDATE standing_orders
+SET next_run_at = $2,
+    occurrences = $3,
+    status = $4,
+    failed_attempts = 0
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateStandingOrderStatus :one
+UPDATE standing_orders
+SET status = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: CancelAccountStandingOrders :execrows
+UPDATE standing_orders
+SET status = 'cancelled'
+WHERE status = 'active' AND (from_account_id = $1 OR to_account_id = $1);
@@ -0,0 +1,32 @@
+-- name: ListStatementEntries :many
+SELECT e.id, e.amount, e.description, e.reference, e.created_at,
+  j.kind AS journal_kind,
+  t.id AS transfer_id,
+  c.id AS counterparty_account_id,
+  u.full_name AS counterparty_name
+FROM entries e
+LEFT JOIN journal_transactions j ON j.id = e.journal_id
+LEFT JOIN transfers t ON t.id = j.transfer_id
+LEFT JOIN accounts c ON j.kind = 'transfer' AND c.id = (CASE WHEN e.amount < 0 THEN t.to_account_id ELSE t.from_account_id END)
+LEFT JOIN users u ON u.username = c.owner
+WHERE e.account_id = sqlc.arg(account_id)::bigint
+  AND e.created_at >= sqlc.arg(from_time)
+  AND e.created_at < sqlc.arg(to_time)
+ORDER BY e.id;
+
+-- name: CreateStatement :one
+INSERT INTO statements (
+  account_id,
+  period,
+  opening_balance,
+  closing_balance,
+  data
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+ON CONFLICT (account_id, period) DO NOTHING
+RETURNING *;
+
+-- name: GetStatement :one
+SELECT * FROM statements
+WHERE account_id = $1 AND period = $2 LIMIT 1;
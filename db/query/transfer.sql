@@ -2,15 +2,26 @@
 INSERT INTO transfers (
   from_account_id,
   to_account_id,
-  amount
+  amount,
+  standing_order_id,
+  reversal_of,
+  hold_id,
+  description,
+  reference,
+  metadata
 ) VALUES (
-  $1, $2, $3
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
 ) RETURNING *;
 
 -- name: GetTransfer :one
 SELECT * FROM transfers
 WHERE id = $1 LIMIT 1;
 
+-- name: GetTransferForUpdate :one
+SELECT * FROM transfers
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
 -- name: ListTransfers :many
 SELECT * FROM transfers
 WHERE 
@@ -19,3 +30,46 @@ WHERE
 ORDER BY id
 LIMIT $3
 OFFSET $4;
+
+-- name: GetReversedAmount :one
+SELECT COALESCE(SUM(amount), 0)::bigint AS reversed_amount FROM transfers
+WHERE reversal_of = sqlc.arg(transfer_id)::bigint;
+
+-- name: ListTransferHistory :many
+SELECT transfers.*, from_accounts.owner AS from_owner, to_accounts.owner AS to_owner
+FROM transfers
+JOIN accounts AS from_accounts ON from_accounts.id = transfers.from_account_id
+JOIN accounts AS to_accounts ON to_accounts.id = transfers.to_account_id
+WHERE
+    (transfers.from_account_id = sqlc.arg(account_id) OR transfers.to_account_id = sqlc.arg(account_id)) AND
+    (sqlc.arg(reference)::varchar = '' OR transfers.reference = sqlc.arg(reference)) AND
+    (sqlc.arg(description)::varchar = '' OR transfers.description ILIKE '%' || sqlc.arg(description) || '%') AND
+    transfers.metadata @> sqlc.arg(metadata)::jsonb
+ORDER BY transfers.id DESC
+LIMIT sqlc.arg(limit)
+OFFSET sqlc.arg(offset);
+
+-- name: GetOutgoingTransferTotal :one
+SELECT COALESCE(SUM(amount), 0)::bigint AS total_amount, COUNT(*) AS transfer_count FROM transfers
+WHERE from_account_id = $1 AND created_at >= $2 AND status NOT IN ('failed', 'cancelled');
+
+-- name: UpdateTransferStatus :one
+UPDATE transfers
+SET status = sqlc.arg(to_status)
+WHERE id = sqlc.arg(id) AND status = sqlc.arg(from_status)
+RETURNING *;
+
+-- name: CreateStatusHistory :one
+INSERT INTO status_history (
+  transfer_id,
+  from_status,
+  to_status,
+  reason
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING *;
+
+-- name: ListStatusHistory :many
+SELECT * FROM status_history
+WHERE transfer_id = $1
+ORDER BY id;
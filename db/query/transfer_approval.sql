@@ -0,0 +1,41 @@
+-- name: CreateTransferApproval :one
+INSERT INTO transfer_approvals (
+  initiator,
+  from_account_id,
+  to_account_id,
+  amount,
+  currency,
+  description,
+  reference,
+  metadata
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
+
+-- name: GetTransferApproval :one
+SELECT * FROM transfer_approvals
+WHERE id = $1 LIMIT 1;
+
+-- name: GetTransferApprovalForUpdate :one
+SELECT * FROM transfer_approvals
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListTransferApprovals :many
+SELECT * FROM transfer_approvals
+WHERE status = $1
+ORDER BY id
+LIMIT $2
+OFFSET $3;
+
+-- name: ApproveTransferApproval :one
+UPDATE transfer_approvals
+SET status = 'approved', reviewer = $2, transfer_id = $3, reviewed_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: RejectTransferApproval :one
+UPDATE transfer_approvals
+SET status = 'rejected', reviewer = $2, reason = $3, reviewed_at = now()
+WHERE id = $1 AND status = 'pending_approval'
+RETURNING *;
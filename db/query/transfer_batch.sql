@@ -0,0 +1,42 @@
+-- name: CreateTransferBatch :one
+INSERT INTO transfer_batches (
+  owner,
+  from_account_id,
+  currency,
+  mode,
+  total_count,
+  total_amount
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetTransferBatch :one
+SELECT * FROM transfer_batches
+WHERE id = $1 LIMIT 1;
+
+-- name: UpdateTransferBatchResult :one
+UPDATE transfer_batches
+SET status = $2, succeeded_count = $3, failed_count = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateTransferBatchItem :one
+INSERT INTO transfer_batch_items (
+  batch_id,
+  line_no,
+  to_account_id,
+  amount
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING *;
+
+-- name: ListTransferBatchItems :many
+SELECT * FROM transfer_batch_items
+WHERE batch_id = $1
+ORDER BY line_no;
+
+-- name: UpdateTransferBatchItem :one
+UPDATE transfer_batch_items
+SET status = $2, transfer_id = $3, failure_reason = $4
+WHERE id = $1
+RETURNING *;
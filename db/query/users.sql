@@ -10,4 +10,8 @@ INSERT INTO users (
 
 -- name: GetUsers :one
 SELECT * FROM users
-WHERE username = $1 LIMIT 1;
\ No newline at end of file
+WHERE username = $1 LIMIT 1;
+
+-- name: GetUserByEmail :one
+SELECT * FROM users
+WHERE email = $1 LIMIT 1;
\ No newline at end of file
@@ -5,13 +5,15 @@ package db
 
 import (
 	"context"
+
+	"github.com/lib/pq"
 )
 
 const addAccountBalance = `-- name: AddAccountBalance :one
 UPDATE accounts
 SET balance = balance + $1
 WHERE id = $2
-RETURNING id, owner, currency, balance, created_at
+RETURNING id, owner, currency, balance, created_at, status, type, nickname, status_reason, closed_at
 `
 
 type AddAccountBalanceParams struct {
@@ -28,6 +30,11 @@ func (q *Queries) AddAccountBalance(ctx context.Context, arg AddAccountBalancePa
 		&i.Currency,
 		&i.Balance,
 		&i.CreatedAt,
+		&i.Status,
+		&i.Type,
+		&i.Nickname,
+		&i.StatusReason,
+		&i.ClosedAt,
 	)
 	return i, err
 }
@@ -36,20 +43,30 @@ const createAccount = `-- name: CreateAccount :one
 INSERT INTO accounts (
   owner,
   balance,
-  currency
+  currency,
+  type,
+  nickname
 ) VALUES (
-  $1, $2, $3
-) RETURNING id, owner, currency, balance, created_at
+  $1, $2, $3, $4, $5
+) RETURNING id, owner, currency, balance, created_at, status, type, nickname, status_reason, closed_at
 `
 
 type CreateAccountParams struct {
 	Owner    string `json:"owner"`
 	Balance  int64  `json:"balance"`
 	Currency string `json:"currency"`
+	Type     string `json:"type"`
+	Nickname string `json:"nickname"`
 }
 
 func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error) {
-	row := q.db.QueryRowContext(ctx, createAccount, arg.Owner, arg.Balance, arg.Currency)
+	row := q.db.QueryRowContext(ctx, createAccount,
+		arg.Owner,
+		arg.Balance,
+		arg.Currency,
+		arg.Type,
+		arg.Nickname,
+	)
 	var i Account
 	err := row.Scan(
 		&i.ID,
@@ -57,22 +74,58 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (A
 		&i.Currency,
 		&i.Balance,
 		&i.CreatedAt,
+		&i.Status,
+		&i.Type,
+		&i.Nickname,
+		&i.StatusReason,
+		&i.ClosedAt,
 	)
 	return i, err
 }
 
-const deleteAccount = `-- name: DeleteAccount :exec
-DELETE FROM accounts
-WHERE id = $1
+const createAccountStatusHistory = `-- name: CreateAccountStatusHistory :one
+INSERT INTO account_status_history (
+  account_id,
+  from_status,
+  to_status,
+  reason,
+  changed_by
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING id, account_id, from_status, to_status, reason, changed_by, created_at
 `
 
-func (q *Queries) DeleteAccount(ctx context.Context, id int64) error {
-	_, err := q.db.ExecContext(ctx, deleteAccount, id)
-	return err
+type CreateAccountStatusHistoryParams struct {
+	AccountID  int64  `json:"account_id"`
+	FromStatus string `json:"from_status"`
+	ToStatus   string `json:"to_status"`
+	Reason     string `json:"reason"`
+	ChangedBy  string `json:"changed_by"`
+}
+
+func (q *Queries) CreateAccountStatusHistory(ctx context.Context, arg CreateAccountStatusHistoryParams) (AccountStatusHistory, error) {
+	row := q.db.QueryRowContext(ctx, createAccountStatusHistory,
+		arg.AccountID,
+		arg.FromStatus,
+		arg.ToStatus,
+		arg.Reason,
+		arg.ChangedBy,
+	)
+	var i AccountStatusHistory
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.FromStatus,
+		&i.ToStatus,
+		&i.Reason,
+		&i.ChangedBy,
+		&i.CreatedAt,
+	)
+	return i, err
 }
 
 const getAccount = `-- name: GetAccount :one
-SELECT id, owner, currency, balance, created_at FROM accounts
+SELECT id, owner, currency, balance, created_at, status, type, nickname, status_reason, closed_at FROM accounts
 WHERE id = $1 LIMIT 1
 `
 
@@ -85,12 +138,47 @@ func (q *Queries) GetAccount(ctx context.Context, id int64) (Account, error) {
 		&i.Currency,
 		&i.Balance,
 		&i.CreatedAt,
+		&i.Status,
+		&i.Type,
+		&i.Nickname,
+		&i.StatusReason,
+		&i.ClosedAt,
+	)
+	return i, err
+}
+
+const getAccountByOwnerAndCurrency = `-- name: GetAccountByOwnerAndCurrency :one
+SELECT id, owner, currency, balance, created_at, status, type, nickname, status_reason, closed_at FROM accounts
+WHERE owner = $1 AND currency = $2 AND status <> 'closed'
+ORDER BY (type = 'checking') DESC, id
+LIMIT 1
+`
+
+type GetAccountByOwnerAndCurrencyParams struct {
+	Owner    string `json:"owner"`
+	Currency string `json:"currency"`
+}
+
+func (q *Queries) GetAccountByOwnerAndCurrency(ctx context.Context, arg GetAccountByOwnerAndCurrencyParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, getAccountByOwnerAndCurrency, arg.Owner, arg.Currency)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Currency,
+		&i.Balance,
+		&i.CreatedAt,
+		&i.Status,
+		&i.Type,
+		&i.Nickname,
+		&i.StatusReason,
+		&i.ClosedAt,
 	)
 	return i, err
 }
 
 const getAccountForUpdate = `-- name: GetAccountForUpdate :one
-SELECT id, owner, currency, balance, created_at FROM accounts
+SELECT id, owner, currency, balance, created_at, status, type, nickname, status_reason, closed_at FROM accounts
 WHERE id = $1 LIMIT 1
 FOR NO KEY UPDATE
 `
@@ -104,26 +192,145 @@ func (q *Queries) GetAccountForUpdate(ctx context.Context, id int64) (Account, e
 		&i.Currency,
 		&i.Balance,
 		&i.CreatedAt,
+		&i.Status,
+		&i.Type,
+		&i.Nickname,
+		&i.StatusReason,
+		&i.ClosedAt,
 	)
 	return i, err
 }
 
 const listAccount = `-- name: ListAccount :many
-SELECT id, owner, currency, balance, created_at FROM accounts
-WHERE owner = $1
-ORDER BY id
-LIMIT $2
-OFFSET $3
+SELECT a.id, a.owner, a.currency, a.balance, a.created_at, a.status, a.type, a.nickname, a.status_reason, a.closed_at FROM accounts a
+JOIN account_holders h ON h.account_id = a.id
+WHERE h.username = $1 AND h.status = 'active'
+  AND ($2::varchar = '' OR a.type = $2)
+  AND a.status <> 'closed'
+ORDER BY a.id
+LIMIT $3
+OFFSET $4
 `
 
 type ListAccountParams struct {
-	Owner  string `json:"owner"`
-	Limit  int32  `json:"limit"`
-	Offset int32  `json:"offset"`
+	Username string `json:"username"`
+	Type     string `json:"type"`
+	Limit    int32  `json:"limit"`
+	Offset   int32  `json:"offset"`
 }
 
 func (q *Queries) ListAccount(ctx context.Context, arg ListAccountParams) ([]Account, error) {
-	rows, err := q.db.QueryContext(ctx, listAccount, arg.Owner, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listAccount,
+		arg.Username,
+		arg.Type,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Account
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Currency,
+			&i.Balance,
+			&i.CreatedAt,
+			&i.Status,
+			&i.Type,
+			&i.Nickname,
+			&i.StatusReason,
+			&i.ClosedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAccountIDs = `-- name: ListAccountIDs :many
+SELECT id FROM accounts
+ORDER BY id
+`
+
+func (q *Queries) ListAccountIDs(ctx context.Context) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAccountStatusHistory = `-- name: ListAccountStatusHistory :many
+SELECT id, account_id, from_status, to_status, reason, changed_by, created_at FROM account_status_history
+WHERE account_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListAccountStatusHistory(ctx context.Context, accountID int64) ([]AccountStatusHistory, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountStatusHistory, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AccountStatusHistory
+	for rows.Next() {
+		var i AccountStatusHistory
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.FromStatus,
+			&i.ToStatus,
+			&i.Reason,
+			&i.ChangedBy,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAccountsByIDs = `-- name: ListAccountsByIDs :many
+SELECT id, owner, currency, balance, created_at, status, type, nickname, status_reason, closed_at FROM accounts
+WHERE id = ANY($1::bigint[])
+ORDER BY id
+`
+
+func (q *Queries) ListAccountsByIDs(ctx context.Context, ids []int64) ([]Account, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountsByIDs, pq.Array(ids))
 	if err != nil {
 		return nil, err
 	}
@@ -137,6 +344,11 @@ func (q *Queries) ListAccount(ctx context.Context, arg ListAccountParams) ([]Acc
 			&i.Currency,
 			&i.Balance,
 			&i.CreatedAt,
+			&i.Status,
+			&i.Type,
+			&i.Nickname,
+			&i.StatusReason,
+			&i.ClosedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -155,7 +367,7 @@ const updateAccount = `-- name: UpdateAccount :one
 UPDATE accounts
 SET balance = $2
 WHERE id = $1
-RETURNING id, owner, currency, balance, created_at
+RETURNING id, owner, currency, balance, created_at, status, type, nickname, status_reason, closed_at
 `
 
 type UpdateAccountParams struct {
@@ -172,6 +384,80 @@ func (q *Queries) UpdateAccount(ctx context.Context, arg UpdateAccountParams) (A
 		&i.Currency,
 		&i.Balance,
 		&i.CreatedAt,
+		&i.Status,
+		&i.Type,
+		&i.Nickname,
+		&i.StatusReason,
+		&i.ClosedAt,
+	)
+	return i, err
+}
+
+const updateAccountNickname = `-- name: UpdateAccountNickname :one
+UPDATE accounts
+SET nickname = $2
+WHERE id = $1
+RETURNING id, owner, currency, balance, created_at, status, type, nickname, status_reason, closed_at
+`
+
+type UpdateAccountNicknameParams struct {
+	ID       int64  `json:"id"`
+	Nickname string `json:"nickname"`
+}
+
+func (q *Queries) UpdateAccountNickname(ctx context.Context, arg UpdateAccountNicknameParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, updateAccountNickname, arg.ID, arg.Nickname)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Currency,
+		&i.Balance,
+		&i.CreatedAt,
+		&i.Status,
+		&i.Type,
+		&i.Nickname,
+		&i.StatusReason,
+		&i.ClosedAt,
+	)
+	return i, err
+}
+
+const updateAccountStatus = `-- name: UpdateAccountStatus :one
+UPDATE accounts
+SET status = $1,
+    status_reason = $2,
+    closed_at = CASE WHEN $1 = 'closed' THEN now() ELSE closed_at END
+WHERE id = $3 AND status = $4
+RETURNING id, owner, currency, balance, created_at, status, type, nickname, status_reason, closed_at
+`
+
+type UpdateAccountStatusParams struct {
+	ToStatus   string `json:"to_status"`
+	Reason     string `json:"reason"`
+	ID         int64  `json:"id"`
+	FromStatus string `json:"from_status"`
+}
+
+func (q *Queries) UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, updateAccountStatus,
+		arg.ToStatus,
+		arg.Reason,
+		arg.ID,
+		arg.FromStatus,
+	)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Currency,
+		&i.Balance,
+		&i.CreatedAt,
+		&i.Status,
+		&i.Type,
+		&i.Nickname,
+		&i.StatusReason,
+		&i.ClosedAt,
 	)
 	return i, err
 }
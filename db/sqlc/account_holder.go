@@ -0,0 +1,45 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const (
+	AccountHolderRolePrimary = "primary"
+	AccountHolderRoleJoint   = "joint"
+	AccountHolderRoleViewer  = "viewer"
+)
+
+const (
+	AccountHolderStatusInvited = "invited"
+	AccountHolderStatusActive  = "active"
+)
+
+// CanTransferFrom reports whether a holder with the given role may move money out of the account.
+// viewer는 계좌를 볼 수만 있다.
+func CanTransferFrom(role string) bool {
+	return role == AccountHolderRolePrimary || role == AccountHolderRoleJoint
+}
+
+// CreateAccountTx creates an account and makes its owner the primary holder.
+func (store *SQLStore) CreateAccountTx(ctx context.Context, arg CreateAccountParams) (Account, error) {
+	var account Account
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		var err error
+		account, err = q.CreateAccount(ctx, arg)
+		if err != nil {
+			return err
+		}
+		_, err = q.CreateAccountHolder(ctx, CreateAccountHolderParams{
+			AccountID:  account.ID,
+			Username:   account.Owner,
+			Role:       AccountHolderRolePrimary,
+			Status:     AccountHolderStatusActive,
+			AcceptedAt: sql.NullTime{Time: time.Now(), Valid: true},
+		})
+		return err
+	})
+	return account, err
+}
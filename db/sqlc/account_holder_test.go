@@ -0,0 +1,79 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanTransferFrom(t *testing.T) {
+	require.True(t, CanTransferFrom(AccountHolderRolePrimary))
+	require.True(t, CanTransferFrom(AccountHolderRoleJoint))
+	require.False(t, CanTransferFrom(AccountHolderRoleViewer))
+}
+
+func TestJointAccount(t *testing.T) {
+	ctx := context.Background()
+	account := createRandomAccount(t)
+	partner := createRandomUser(t)
+
+	primary, err := testQueries.GetAccountHolderByUsername(ctx, GetAccountHolderByUsernameParams{
+		AccountID: account.ID,
+		Username:  account.Owner,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountHolderRolePrimary, primary.Role)
+	require.Equal(t, AccountHolderStatusActive, primary.Status)
+
+	invitation, err := testQueries.CreateAccountHolder(ctx, CreateAccountHolderParams{
+		AccountID: account.ID,
+		Username:  partner.Username,
+		Role:      AccountHolderRoleJoint,
+		Status:    AccountHolderStatusInvited,
+		InvitedBy: sql.NullString{String: account.Owner, Valid: true},
+	})
+	require.NoError(t, err)
+
+	invitations, err := testQueries.ListAccountInvitations(ctx, partner.Username)
+	require.NoError(t, err)
+	require.Len(t, invitations, 1)
+	require.Equal(t, invitation.ID, invitations[0].ID)
+
+	// 수락하기 전에는 계좌 목록에 나오지 않는다.
+	accounts, err := testQueries.ListAccount(ctx, ListAccountParams{Username: partner.Username, Limit: 5})
+	require.NoError(t, err)
+	require.Empty(t, accounts)
+
+	accepted, err := testQueries.AcceptAccountInvitation(ctx, invitation.ID)
+	require.NoError(t, err)
+	require.Equal(t, AccountHolderStatusActive, accepted.Status)
+	require.True(t, accepted.AcceptedAt.Valid)
+
+	_, err = testQueries.AcceptAccountInvitation(ctx, invitation.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	accounts, err = testQueries.ListAccount(ctx, ListAccountParams{Username: partner.Username, Limit: 5})
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	require.Equal(t, account.ID, accounts[0].ID)
+
+	// 같은 사용자를 두 번 초대할 수 없다.
+	_, err = testQueries.CreateAccountHolder(ctx, CreateAccountHolderParams{
+		AccountID: account.ID,
+		Username:  partner.Username,
+		Role:      AccountHolderRoleViewer,
+		Status:    AccountHolderStatusInvited,
+	})
+	require.Error(t, err)
+
+	holders, err := testQueries.ListAccountHolders(ctx, account.ID)
+	require.NoError(t, err)
+	require.Len(t, holders, 2)
+
+	require.NoError(t, testQueries.DeleteAccountHolder(ctx, accepted.ID))
+	accounts, err = testQueries.ListAccount(ctx, ListAccountParams{Username: partner.Username, Limit: 5})
+	require.NoError(t, err)
+	require.Empty(t, accounts)
+}
@@ -0,0 +1,124 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gyu-young-park/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpsertAccountLimit(t *testing.T) {
+	account := createRandomAccount(t)
+
+	arg := UpsertAccountLimitParams{
+		AccountID:      account.ID,
+		MaxPerTransfer: 100,
+		MaxDailyAmount: 1000,
+		MaxDailyCount:  5,
+		UpdatedBy:      account.Owner,
+	}
+	limit, err := testQueries.UpsertAccountLimit(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.MaxPerTransfer, limit.MaxPerTransfer)
+
+	arg.MaxPerTransfer = 200
+	limit, err = testQueries.UpsertAccountLimit(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int64(200), limit.MaxPerTransfer)
+
+	err = testQueries.DeleteAccountLimit(context.Background(), account.ID)
+	require.NoError(t, err)
+}
+
+func TestTransferTxLimits(t *testing.T) {
+	account1 := createRandomAccountWithBalance(t, 1000)
+	account2 := createRandomAccountWithBalance(t, 0)
+
+	store := NewStore(testDB, StoreConfig{
+		TransferLimits: map[string]util.TransferLimit{
+			account1.Currency: {MaxPerTransfer: 100, MaxDailyAmount: 150},
+		},
+	})
+
+	limit, err := store.GetTransferLimit(context.Background(), account1)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), limit.MaxPerTransfer)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        101,
+	})
+	require.ErrorIs(t, err, ErrTransferLimitExceeded)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        100,
+	})
+	require.NoError(t, err)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        51,
+	})
+	require.ErrorIs(t, err, ErrTransferLimitExceeded)
+
+	// 계좌별 한도가 통화 기본값보다 우선한다.
+	_, err = testQueries.UpsertAccountLimit(context.Background(), UpsertAccountLimitParams{
+		AccountID:     account1.ID,
+		MaxDailyCount: 2,
+		UpdatedBy:     account1.Owner,
+	})
+	require.NoError(t, err)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        500,
+	})
+	require.NoError(t, err)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        1,
+	})
+	require.ErrorIs(t, err, ErrTransferLimitExceeded)
+}
+
+func TestTransferTxDailyLimitConcurrent(t *testing.T) {
+	account1 := createRandomAccountWithBalance(t, 1000)
+	account2 := createRandomAccountWithBalance(t, 0)
+
+	store := NewStore(testDB, StoreConfig{
+		TransferLimits: map[string]util.TransferLimit{
+			account1.Currency: {MaxDailyAmount: 50},
+		},
+	})
+
+	n := 10
+	errs := make(chan error)
+	for i := 0; i < n; i++ {
+		go func() {
+			_, err := store.TransferTx(context.Background(), TransferTxParams{
+				FromAccountID: account1.ID,
+				ToAccountID:   account2.ID,
+				Amount:        10,
+			})
+			errs <- err
+		}()
+	}
+
+	var succeeded int
+	for i := 0; i < n; i++ {
+		if err := <-errs; err == nil {
+			succeeded++
+		} else {
+			require.ErrorIs(t, err, ErrTransferLimitExceeded)
+		}
+	}
+	require.Equal(t, 5, succeeded)
+}
@@ -0,0 +1,101 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+const (
+	AccountStatusActive = "active"
+	AccountStatusFrozen = "frozen"
+	AccountStatusClosed = "closed"
+)
+
+// frozen이나 closed 계좌에는 돈이 들어가거나 나갈 수 없다.
+var ErrAccountNotActive = errors.New("account is not active")
+
+var ErrInvalidAccountTransition = errors.New("invalid account status transition")
+
+// 잔액이나 hold가 남아있는 계좌는 해지할 수 없다.
+var ErrAccountNotEmpty = errors.New("account balance must be zero to close it")
+
+// accountTransitions는 각 상태에서 갈 수 있는 다음 상태이다. closed는 끝 상태이다.
+var accountTransitions = map[string][]string{
+	AccountStatusActive: {AccountStatusFrozen, AccountStatusClosed},
+	AccountStatusFrozen: {AccountStatusActive},
+}
+
+// CanTransitionAccount reports whether an account may move from one status to another.
+func CanTransitionAccount(from string, to string) bool {
+	for _, next := range accountTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+type ChangeAccountStatusTxParams struct {
+	AccountID int64  `json:"account_id"`
+	ToStatus  string `json:"to_status"`
+	Reason    string `json:"reason"`
+	// 상태를 바꾼 사용자나 banker의 username, 또는 job 이름이다.
+	ChangedBy string `json:"changed_by"`
+}
+
+type ChangeAccountStatusTxResult struct {
+	Account Account              `json:"account"`
+	History AccountStatusHistory `json:"history"`
+	// 해지할 때 취소된 standing order 수이다.
+	CancelledStandingOrders int64 `json:"cancelled_standing_orders"`
+}
+
+// ChangeAccountStatusTx freezes, unfreezes or closes an account and records it in the account status history.
+// 해지는 잔액과 hold가 모두 0일 때만 되고, 계좌에 걸린 active standing order도 함께 취소한다.
+func (store *SQLStore) ChangeAccountStatusTx(ctx context.Context, arg ChangeAccountStatusTxParams) (ChangeAccountStatusTxResult, error) {
+	var result ChangeAccountStatusTxResult
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		account, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		if !CanTransitionAccount(account.Status, arg.ToStatus) {
+			return fmt.Errorf("%w: %s to %s", ErrInvalidAccountTransition, account.Status, arg.ToStatus)
+		}
+
+		if arg.ToStatus == AccountStatusClosed {
+			held, err := q.GetHeldAmount(ctx, account.ID)
+			if err != nil {
+				return err
+			}
+			if account.Balance != 0 || held != 0 {
+				return ErrAccountNotEmpty
+			}
+			result.CancelledStandingOrders, err = q.CancelAccountStandingOrders(ctx, account.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		result.Account, err = q.UpdateAccountStatus(ctx, UpdateAccountStatusParams{
+			ID:         account.ID,
+			FromStatus: account.Status,
+			ToStatus:   arg.ToStatus,
+			Reason:     arg.Reason,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.History, err = q.CreateAccountStatusHistory(ctx, CreateAccountStatusHistoryParams{
+			AccountID:  account.ID,
+			FromStatus: account.Status,
+			ToStatus:   arg.ToStatus,
+			Reason:     arg.Reason,
+			ChangedBy:  arg.ChangedBy,
+		})
+		return err
+	})
+	return result, err
+}
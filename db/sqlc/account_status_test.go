@@ -0,0 +1,124 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanTransitionAccount(t *testing.T) {
+	require.True(t, CanTransitionAccount(AccountStatusActive, AccountStatusFrozen))
+	require.True(t, CanTransitionAccount(AccountStatusActive, AccountStatusClosed))
+	require.True(t, CanTransitionAccount(AccountStatusFrozen, AccountStatusActive))
+
+	require.False(t, CanTransitionAccount(AccountStatusFrozen, AccountStatusClosed))
+	require.False(t, CanTransitionAccount(AccountStatusClosed, AccountStatusActive))
+	require.False(t, CanTransitionAccount(AccountStatusActive, AccountStatusActive))
+}
+
+func TestFreezeAccountTx(t *testing.T) {
+	store := NewStore(testDB, StoreConfig{})
+	account := createRandomAccount(t)
+
+	result, err := store.ChangeAccountStatusTx(context.Background(), ChangeAccountStatusTxParams{
+		AccountID: account.ID,
+		ToStatus:  AccountStatusFrozen,
+		Reason:    "suspected fraud",
+		ChangedBy: "banker",
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountStatusFrozen, result.Account.Status)
+	require.Equal(t, "suspected fraud", result.Account.StatusReason)
+	require.Equal(t, AccountStatusActive, result.History.FromStatus)
+
+	_, err = store.DepositTx(context.Background(), CashTxParams{AccountID: account.ID, Amount: 10})
+	require.ErrorIs(t, err, ErrAccountNotActive)
+
+	// frozen 계좌는 바로 해지할 수 없다.
+	_, err = store.ChangeAccountStatusTx(context.Background(), ChangeAccountStatusTxParams{
+		AccountID: account.ID,
+		ToStatus:  AccountStatusClosed,
+		ChangedBy: account.Owner,
+	})
+	require.ErrorIs(t, err, ErrInvalidAccountTransition)
+
+	result, err = store.ChangeAccountStatusTx(context.Background(), ChangeAccountStatusTxParams{
+		AccountID: account.ID,
+		ToStatus:  AccountStatusActive,
+		Reason:    "cleared",
+		ChangedBy: "banker",
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountStatusActive, result.Account.Status)
+
+	_, err = store.DepositTx(context.Background(), CashTxParams{AccountID: account.ID, Amount: 10})
+	require.NoError(t, err)
+
+	history, err := testQueries.ListAccountStatusHistory(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	require.Equal(t, AccountStatusFrozen, history[0].ToStatus)
+	require.Equal(t, AccountStatusActive, history[1].ToStatus)
+	require.Equal(t, "cleared", history[1].Reason)
+}
+
+func TestCloseAccountTx(t *testing.T) {
+	store := NewStore(testDB, StoreConfig{})
+	account := createRandomAccount(t)
+	other := createRandomAccount(t)
+	order := createRandomStandingOrder(t, account, other, time.Now().Add(time.Hour))
+
+	_, err := store.DepositTx(context.Background(), CashTxParams{AccountID: account.ID, Amount: 10})
+	require.NoError(t, err)
+
+	closeArg := ChangeAccountStatusTxParams{
+		AccountID: account.ID,
+		ToStatus:  AccountStatusClosed,
+		Reason:    "closed by owner",
+		ChangedBy: account.Owner,
+	}
+	_, err = store.ChangeAccountStatusTx(context.Background(), closeArg)
+	require.ErrorIs(t, err, ErrAccountNotEmpty)
+
+	_, err = store.WithdrawTx(context.Background(), CashTxParams{AccountID: account.ID, Amount: 10})
+	require.NoError(t, err)
+
+	result, err := store.ChangeAccountStatusTx(context.Background(), closeArg)
+	require.NoError(t, err)
+	require.Equal(t, AccountStatusClosed, result.Account.Status)
+	require.True(t, result.Account.ClosedAt.Valid)
+	require.Equal(t, int64(1), result.CancelledStandingOrders)
+
+	order, err = testQueries.GetStandingOrder(context.Background(), order.ID)
+	require.NoError(t, err)
+	require.Equal(t, StandingOrderStatusCancelled, order.Status)
+
+	// 해지된 계좌는 지워지지 않지만 목록에서 빠지고, 돈이 들어올 수 없다.
+	closed, err := testQueries.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, AccountStatusClosed, closed.Status)
+
+	accounts, err := testQueries.ListAccount(context.Background(), ListAccountParams{
+		Username: account.Owner,
+		Limit:    5,
+	})
+	require.NoError(t, err)
+	require.Empty(t, accounts)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: other.ID,
+		ToAccountID:   account.ID,
+		Amount:        1,
+	})
+	require.ErrorIs(t, err, ErrAccountNotActive)
+
+	// 같은 통화와 종류의 계좌를 다시 만들 수 있다.
+	_, err = testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    account.Owner,
+		Currency: account.Currency,
+		Type:     account.Type,
+	})
+	require.NoError(t, err)
+}
@@ -2,7 +2,6 @@ package db
 
 import (
 	"context"
-	"database/sql"
 	"testing"
 	"time"
 
@@ -12,23 +11,37 @@ import (
 
 func createRandomAccount(t *testing.T) Account {
 	user := createRandomUser(t)
+	// 이체는 같은 통화끼리만 journal이 맞으므로 테스트 계좌는 모두 USD로 만든다.
 	arg := CreateAccountParams{
 		Owner:    user.Username,
 		Balance:  util.RandomMoney(),
-		Currency: util.RandomCurrency(),
+		Currency: util.USD,
+		Type:     AccountTypeChecking,
 	}
-	account, err := testQueries.CreateAccount(context.Background(), arg)
+	// account holder도 함께 만들어야 ListAccount에 나온다.
+	account, err := NewStore(testDB, StoreConfig{}).CreateAccountTx(context.Background(), arg)
 	require.NoError(t, err)
 	require.NotEmpty(t, account)
 	require.Equal(t, arg.Owner, account.Owner)
 	require.Equal(t, arg.Balance, account.Balance)
 	require.Equal(t, arg.Currency, account.Currency)
+	require.Equal(t, arg.Type, account.Type)
 
 	require.NotZero(t, account.ID)
 	require.NotZero(t, account.CreatedAt)
 	return account
 }
 
+func createRandomAccountWithBalance(t *testing.T, balance int64) Account {
+	account := createRandomAccount(t)
+	account, err := testQueries.UpdateAccount(context.Background(), UpdateAccountParams{
+		ID:      account.ID,
+		Balance: balance,
+	})
+	require.NoError(t, err)
+	return account
+}
+
 func TestCreateAccount(t *testing.T) {
 	createRandomAccount(t)
 }
@@ -46,6 +59,49 @@ func TestGetAccount(t *testing.T) {
 	require.WithinDuration(t, account1.CreatedAt, account2.CreatedAt, time.Second)
 }
 
+func TestGetAccountByOwnerAndCurrency(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2, err := testQueries.GetAccountByOwnerAndCurrency(context.Background(), GetAccountByOwnerAndCurrencyParams{
+		Owner:    account1.Owner,
+		Currency: account1.Currency,
+	})
+	require.NoError(t, err)
+	require.Equal(t, account1.ID, account2.ID)
+}
+
+func TestAccountTypes(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore(testDB, StoreConfig{})
+	owner := createRandomUser(t).Username
+
+	// checking보다 먼저 만든 savings 계좌가 있어도 통화별 기본 계좌는 checking이다.
+	savings, err := store.CreateAccountTx(ctx, CreateAccountParams{Owner: owner, Currency: util.USD, Type: AccountTypeSavings})
+	require.NoError(t, err)
+	checking, err := store.CreateAccountTx(ctx, CreateAccountParams{Owner: owner, Currency: util.USD, Type: AccountTypeChecking, Nickname: "daily"})
+	require.NoError(t, err)
+	require.Equal(t, "daily", checking.Nickname)
+
+	_, err = testQueries.CreateAccount(ctx, CreateAccountParams{Owner: owner, Currency: util.USD, Type: AccountTypeSavings})
+	require.Error(t, err)
+
+	account, err := testQueries.GetAccountByOwnerAndCurrency(ctx, GetAccountByOwnerAndCurrencyParams{Owner: owner, Currency: util.USD})
+	require.NoError(t, err)
+	require.Equal(t, checking.ID, account.ID)
+
+	accounts, err := testQueries.ListAccount(ctx, ListAccountParams{Username: owner, Type: AccountTypeSavings, Limit: 5})
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	require.Equal(t, savings.ID, accounts[0].ID)
+
+	accounts, err = testQueries.ListAccount(ctx, ListAccountParams{Username: owner, Limit: 5})
+	require.NoError(t, err)
+	require.Len(t, accounts, 2)
+
+	renamed, err := testQueries.UpdateAccountNickname(ctx, UpdateAccountNicknameParams{ID: savings.ID, Nickname: "rainy day"})
+	require.NoError(t, err)
+	require.Equal(t, "rainy day", renamed.Nickname)
+}
+
 func TestUpdateAccount(t *testing.T) {
 	account1 := createRandomAccount(t)
 
@@ -64,26 +120,15 @@ func TestUpdateAccount(t *testing.T) {
 	require.WithinDuration(t, account1.CreatedAt, account2.CreatedAt, time.Second)
 }
 
-func TestDeleteAccount(t *testing.T) {
-	account1 := createRandomAccount(t)
-	err := testQueries.DeleteAccount(context.Background(), account1.ID)
-	require.NoError(t, err)
-
-	account2, err := testQueries.GetAccount(context.Background(), account1.ID)
-	require.Error(t, err)
-	require.EqualError(t, err, sql.ErrNoRows.Error())
-	require.Empty(t, account2)
-}
-
 func TestListAccounts(t *testing.T) {
 	var lastAccount Account
 	for i := 0; i < 10; i++ {
 		lastAccount = createRandomAccount(t)
 	}
 	arg := ListAccountParams{
-		Owner:  lastAccount.Owner,
-		Limit:  5,
-		Offset: 0,
+		Username: lastAccount.Owner,
+		Limit:    5,
+		Offset:   0,
 	}
 	accounts, err := testQueries.ListAccount(context.Background(), arg)
 	require.NoError(t, err)
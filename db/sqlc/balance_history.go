@@ -0,0 +1,157 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+const (
+	BalanceIntervalDay   = "day"
+	BalanceIntervalWeek  = "week"
+	BalanceIntervalMonth = "month"
+)
+
+var ErrInvalidBalanceInterval = errors.New("interval must be day, week or month")
+
+type AccountBalanceAtParams struct {
+	AccountID int64     `json:"account_id"`
+	AsOf      time.Time `json:"as_of"`
+}
+
+// AccountBalanceAt returns the account balance including every entry created at or before AsOf.
+func (store *SQLStore) AccountBalanceAt(ctx context.Context, arg AccountBalanceAtParams) (int64, error) {
+	// DB는 microsecond까지만 저장하므로 그 다음 microsecond 전까지의 entries를 더하면 AsOf를 포함하게 된다.
+	return balanceBefore(ctx, store.Queries, arg.AccountID, arg.AsOf.Truncate(time.Microsecond).Add(time.Microsecond))
+}
+
+// balanceBefore sums the entries created before the given time, starting from the latest snapshot.
+func balanceBefore(ctx context.Context, q *Queries, accountID int64, before time.Time) (int64, error) {
+	var balance int64
+	var from time.Time
+	snapshot, err := q.GetLatestBalanceSnapshot(ctx, GetLatestBalanceSnapshotParams{
+		AccountID: accountID,
+		Before:    before,
+	})
+	switch {
+	case err == nil:
+		balance, from = snapshot.Balance, snapshot.TakenAt
+	case err != sql.ErrNoRows:
+		return 0, err
+	}
+
+	total, err := q.SumAccountEntries(ctx, SumAccountEntriesParams{
+		AccountID: accountID,
+		FromTime:  from,
+		ToTime:    before,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return balance + total, nil
+}
+
+type AccountBalanceHistoryParams struct {
+	AccountID int64 `json:"account_id"`
+	// From과 To는 UTC 자정으로 맞춰서 넘긴다. To가 속한 기간까지 포함된다.
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+	Interval string    `json:"interval"`
+}
+
+// BalancePoint is the balance at the end of one period.
+type BalancePoint struct {
+	PeriodStart time.Time `json:"period_start"`
+	PeriodEnd   time.Time `json:"period_end"`
+	Balance     int64     `json:"balance"`
+}
+
+// AccountBalanceHistory returns the end-of-period balances of an account between From and To.
+// 시작 잔액은 snapshot에서, 그 이후 변화는 하루 단위로 묶은 entries 합계에서 계산한다.
+func (store *SQLStore) AccountBalanceHistory(ctx context.Context, arg AccountBalanceHistoryParams) ([]BalancePoint, error) {
+	periods, err := BalancePeriods(arg.From, arg.To, arg.Interval)
+	if err != nil {
+		return nil, err
+	}
+	points := make([]BalancePoint, 0, len(periods))
+	if len(periods) == 0 {
+		return points, nil
+	}
+	end := periods[len(periods)-1].PeriodEnd
+
+	opts := &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+	err = store.execTx(ctx, opts, func(q *Queries) error {
+		points = points[:0]
+		balance, err := balanceBefore(ctx, q, arg.AccountID, arg.From)
+		if err != nil {
+			return err
+		}
+		totals, err := q.ListDailyEntryTotals(ctx, ListDailyEntryTotalsParams{
+			AccountID: arg.AccountID,
+			FromTime:  arg.From,
+			ToTime:    end,
+		})
+		if err != nil {
+			return err
+		}
+
+		next := 0
+		for _, period := range periods {
+			for next < len(totals) && totals[next].Day.Before(period.PeriodEnd) {
+				balance += totals[next].Total
+				next++
+			}
+			period.Balance = balance
+			points = append(points, period)
+		}
+		return nil
+	})
+	return points, err
+}
+
+// BalancePeriods splits [from, to] into consecutive periods of the given interval.
+// 각 기간은 from에서 시작하는 만큼씩 이어지므로 week는 from의 요일에서, month는 from의 날짜에서 시작한다.
+func BalancePeriods(from, to time.Time, interval string) ([]BalancePoint, error) {
+	var step func(time.Time) time.Time
+	switch interval {
+	case BalanceIntervalDay:
+		step = func(t time.Time) time.Time { return t.AddDate(0, 0, 1) }
+	case BalanceIntervalWeek:
+		step = func(t time.Time) time.Time { return t.AddDate(0, 0, 7) }
+	case BalanceIntervalMonth:
+		step = func(t time.Time) time.Time { return t.AddDate(0, 1, 0) }
+	default:
+		return nil, ErrInvalidBalanceInterval
+	}
+
+	var periods []BalancePoint
+	for start := from; !start.After(to); start = step(start) {
+		periods = append(periods, BalancePoint{PeriodStart: start, PeriodEnd: step(start)})
+	}
+	return periods, nil
+}
+
+type SnapshotBalanceParams struct {
+	AccountID int64     `json:"account_id"`
+	TakenAt   time.Time `json:"taken_at"`
+}
+
+// SnapshotBalance stores the account balance from before TakenAt.
+// 이미 같은 시각의 snapshot이 있으면 sql.ErrNoRows를 돌려준다.
+func (store *SQLStore) SnapshotBalance(ctx context.Context, arg SnapshotBalanceParams) (BalanceSnapshot, error) {
+	var snapshot BalanceSnapshot
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		balance, err := balanceBefore(ctx, q, arg.AccountID, arg.TakenAt)
+		if err != nil {
+			return err
+		}
+		snapshot, err = q.CreateBalanceSnapshot(ctx, CreateBalanceSnapshotParams{
+			AccountID: arg.AccountID,
+			Balance:   balance,
+			TakenAt:   arg.TakenAt,
+		})
+		return err
+	})
+	return snapshot, err
+}
@@ -0,0 +1,67 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBalancePeriods(t *testing.T) {
+	from := time.Date(2022, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	periods, err := BalancePeriods(from, from.AddDate(0, 0, 2), BalanceIntervalDay)
+	require.NoError(t, err)
+	require.Len(t, periods, 3)
+	require.Equal(t, from.AddDate(0, 0, 3), periods[2].PeriodEnd)
+
+	periods, err = BalancePeriods(from, from.AddDate(0, 0, 13), BalanceIntervalWeek)
+	require.NoError(t, err)
+	require.Len(t, periods, 2)
+
+	_, err = BalancePeriods(from, from, "hour")
+	require.ErrorIs(t, err, ErrInvalidBalanceInterval)
+}
+
+func TestAccountBalanceHistory(t *testing.T) {
+	store := NewStore(testDB, StoreConfig{})
+	account := createRandomAccountWithBalance(t, 0)
+
+	before := time.Now()
+	_, err := store.DepositTx(context.Background(), CashTxParams{AccountID: account.ID, Amount: 100})
+	require.NoError(t, err)
+	after := time.Now()
+
+	balance, err := store.AccountBalanceAt(context.Background(), AccountBalanceAtParams{AccountID: account.ID, AsOf: before.Add(-time.Second)})
+	require.NoError(t, err)
+	require.Zero(t, balance)
+
+	balance, err = store.AccountBalanceAt(context.Background(), AccountBalanceAtParams{AccountID: account.ID, AsOf: after})
+	require.NoError(t, err)
+	require.Equal(t, int64(100), balance)
+
+	// snapshot 이후의 잔액은 snapshot과 그 뒤의 entries로 계산된다.
+	snapshot, err := store.SnapshotBalance(context.Background(), SnapshotBalanceParams{AccountID: account.ID, TakenAt: after})
+	require.NoError(t, err)
+	require.Equal(t, int64(100), snapshot.Balance)
+
+	_, err = store.WithdrawTx(context.Background(), CashTxParams{AccountID: account.ID, Amount: 30})
+	require.NoError(t, err)
+
+	balance, err = store.AccountBalanceAt(context.Background(), AccountBalanceAtParams{AccountID: account.ID, AsOf: time.Now()})
+	require.NoError(t, err)
+	require.Equal(t, int64(70), balance)
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	points, err := store.AccountBalanceHistory(context.Background(), AccountBalanceHistoryParams{
+		AccountID: account.ID,
+		From:      today.AddDate(0, 0, -1),
+		To:        today,
+		Interval:  BalanceIntervalDay,
+	})
+	require.NoError(t, err)
+	require.Len(t, points, 2)
+	require.Zero(t, points[0].Balance)
+	require.Equal(t, int64(70), points[1].Balance)
+}
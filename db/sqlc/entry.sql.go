@@ -5,36 +5,68 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 )
 
 const createEntry = `-- name: CreateEntry :one
 INSERT INTO entries (
+  journal_id,
   account_id,
-  amount
+  ledger_account_id,
+  currency,
+  amount,
+  description,
+  reference,
+  metadata
 ) VALUES (
-  $1, $2
-) RETURNING id, account_id, amount, created_at
+  $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING id, account_id, amount, created_at, description, reference, journal_id, ledger_account_id, currency, prev_hash, hash, metadata, ledger_seq
 `
 
 type CreateEntryParams struct {
-	AccountID int64 `json:"account_id"`
-	Amount    int64 `json:"amount"`
+	JournalID       sql.NullInt64   `json:"journal_id"`
+	AccountID       sql.NullInt64   `json:"account_id"`
+	LedgerAccountID sql.NullInt64   `json:"ledger_account_id"`
+	Currency        string          `json:"currency"`
+	Amount          int64           `json:"amount"`
+	Description     string          `json:"description"`
+	Reference       string          `json:"reference"`
+	Metadata        json.RawMessage `json:"metadata"`
 }
 
 func (q *Queries) CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error) {
-	row := q.db.QueryRowContext(ctx, createEntry, arg.AccountID, arg.Amount)
+	row := q.db.QueryRowContext(ctx, createEntry,
+		arg.JournalID,
+		arg.AccountID,
+		arg.LedgerAccountID,
+		arg.Currency,
+		arg.Amount,
+		arg.Description,
+		arg.Reference,
+		arg.Metadata,
+	)
 	var i Entry
 	err := row.Scan(
 		&i.ID,
 		&i.AccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.Description,
+		&i.Reference,
+		&i.JournalID,
+		&i.LedgerAccountID,
+		&i.Currency,
+		&i.PrevHash,
+		&i.Hash,
+		&i.Metadata,
+		&i.LedgerSeq,
 	)
 	return i, err
 }
 
 const getEntry = `-- name: GetEntry :one
-SELECT id, account_id, amount, created_at FROM entries
+SELECT id, account_id, amount, created_at, description, reference, journal_id, ledger_account_id, currency, prev_hash, hash, metadata, ledger_seq FROM entries
 WHERE id = $1 LIMIT 1
 `
 
@@ -46,13 +78,100 @@ func (q *Queries) GetEntry(ctx context.Context, id int64) (Entry, error) {
 		&i.AccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.Description,
+		&i.Reference,
+		&i.JournalID,
+		&i.LedgerAccountID,
+		&i.Currency,
+		&i.PrevHash,
+		&i.Hash,
+		&i.Metadata,
+		&i.LedgerSeq,
 	)
 	return i, err
 }
 
+const getLastAccountEntryHash = `-- name: GetLastAccountEntryHash :one
+SELECT hash FROM entries
+WHERE account_id = $1::bigint AND hash <> ''
+ORDER BY id DESC
+LIMIT 1
+`
+
+func (q *Queries) GetLastAccountEntryHash(ctx context.Context, accountID int64) (string, error) {
+	row := q.db.QueryRowContext(ctx, getLastAccountEntryHash, accountID)
+	var hash string
+	err := row.Scan(&hash)
+	return hash, err
+}
+
+const getLastLedgerEntryHash = `-- name: GetLastLedgerEntryHash :one
+SELECT hash FROM entries
+WHERE ledger_account_id = $1::bigint AND ledger_seq IS NOT NULL AND hash <> ''
+ORDER BY ledger_seq DESC
+LIMIT 1
+`
+
+func (q *Queries) GetLastLedgerEntryHash(ctx context.Context, ledgerAccountID int64) (string, error) {
+	row := q.db.QueryRowContext(ctx, getLastLedgerEntryHash, ledgerAccountID)
+	var hash string
+	err := row.Scan(&hash)
+	return hash, err
+}
+
+const listAccountEntryChain = `-- name: ListAccountEntryChain :many
+SELECT id, account_id, amount, created_at, description, reference, journal_id, ledger_account_id, currency, prev_hash, hash, metadata, ledger_seq FROM entries
+WHERE account_id = $1::bigint AND id > $2
+ORDER BY id
+LIMIT $3
+`
+
+type ListAccountEntryChainParams struct {
+	AccountID int64 `json:"account_id"`
+	AfterID   int64 `json:"after_id"`
+	Limit     int32 `json:"limit"`
+}
+
+func (q *Queries) ListAccountEntryChain(ctx context.Context, arg ListAccountEntryChainParams) ([]Entry, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountEntryChain, arg.AccountID, arg.AfterID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Entry
+	for rows.Next() {
+		var i Entry
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.Description,
+			&i.Reference,
+			&i.JournalID,
+			&i.LedgerAccountID,
+			&i.Currency,
+			&i.PrevHash,
+			&i.Hash,
+			&i.Metadata,
+			&i.LedgerSeq,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listEntries = `-- name: ListEntries :many
-SELECT id, account_id, amount, created_at FROM entries
-WHERE account_id = $1
+SELECT id, account_id, amount, created_at, description, reference, journal_id, ledger_account_id, currency, prev_hash, hash, metadata, ledger_seq FROM entries
+WHERE account_id = $1::bigint
 ORDER BY id
 LIMIT $2
 OFFSET $3
@@ -78,6 +197,144 @@ func (q *Queries) ListEntries(ctx context.Context, arg ListEntriesParams) ([]Ent
 			&i.AccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.Description,
+			&i.Reference,
+			&i.JournalID,
+			&i.LedgerAccountID,
+			&i.Currency,
+			&i.PrevHash,
+			&i.Hash,
+			&i.Metadata,
+			&i.LedgerSeq,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLedgerEntryChain = `-- name: ListLedgerEntryChain :many
+SELECT id, account_id, amount, created_at, description, reference, journal_id, ledger_account_id, currency, prev_hash, hash, metadata, ledger_seq FROM entries
+WHERE ledger_account_id = $1::bigint AND ledger_seq > $2::bigint
+ORDER BY ledger_seq
+LIMIT $3
+`
+
+type ListLedgerEntryChainParams struct {
+	LedgerAccountID int64 `json:"ledger_account_id"`
+	AfterSeq        int64 `json:"after_seq"`
+	Limit           int32 `json:"limit"`
+}
+
+func (q *Queries) ListLedgerEntryChain(ctx context.Context, arg ListLedgerEntryChainParams) ([]Entry, error) {
+	rows, err := q.db.QueryContext(ctx, listLedgerEntryChain, arg.LedgerAccountID, arg.AfterSeq, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Entry
+	for rows.Next() {
+		var i Entry
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.Description,
+			&i.Reference,
+			&i.JournalID,
+			&i.LedgerAccountID,
+			&i.Currency,
+			&i.PrevHash,
+			&i.Hash,
+			&i.Metadata,
+			&i.LedgerSeq,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPendingLedgerAccountIDs = `-- name: ListPendingLedgerAccountIDs :many
+SELECT ledger_account_id::bigint AS ledger_account_id FROM entries
+WHERE ledger_account_id IS NOT NULL AND ledger_seq IS NULL
+GROUP BY ledger_account_id
+ORDER BY ledger_account_id
+`
+
+func (q *Queries) ListPendingLedgerAccountIDs(ctx context.Context) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, listPendingLedgerAccountIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var ledgerAccountID int64
+		if err := rows.Scan(&ledgerAccountID); err != nil {
+			return nil, err
+		}
+		items = append(items, ledgerAccountID)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPendingLedgerEntries = `-- name: ListPendingLedgerEntries :many
+SELECT id, account_id, amount, created_at, description, reference, journal_id, ledger_account_id, currency, prev_hash, hash, metadata, ledger_seq FROM entries
+WHERE ledger_account_id = $1::bigint AND ledger_seq IS NULL
+ORDER BY id
+LIMIT $2
+`
+
+type ListPendingLedgerEntriesParams struct {
+	LedgerAccountID int64 `json:"ledger_account_id"`
+	Limit           int32 `json:"limit"`
+}
+
+func (q *Queries) ListPendingLedgerEntries(ctx context.Context, arg ListPendingLedgerEntriesParams) ([]Entry, error) {
+	rows, err := q.db.QueryContext(ctx, listPendingLedgerEntries, arg.LedgerAccountID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Entry
+	for rows.Next() {
+		var i Entry
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.Description,
+			&i.Reference,
+			&i.JournalID,
+			&i.LedgerAccountID,
+			&i.Currency,
+			&i.PrevHash,
+			&i.Hash,
+			&i.Metadata,
+			&i.LedgerSeq,
 		); err != nil {
 			return nil, err
 		}
@@ -91,3 +348,71 @@ func (q *Queries) ListEntries(ctx context.Context, arg ListEntriesParams) ([]Ent
 	}
 	return items, nil
 }
+
+const setEntryHash = `-- name: SetEntryHash :one
+UPDATE entries
+SET prev_hash = $2, hash = $3
+WHERE id = $1
+RETURNING id, account_id, amount, created_at, description, reference, journal_id, ledger_account_id, currency, prev_hash, hash, metadata, ledger_seq
+`
+
+type SetEntryHashParams struct {
+	ID       int64  `json:"id"`
+	PrevHash string `json:"prev_hash"`
+	Hash     string `json:"hash"`
+}
+
+func (q *Queries) SetEntryHash(ctx context.Context, arg SetEntryHashParams) (Entry, error) {
+	row := q.db.QueryRowContext(ctx, setEntryHash, arg.ID, arg.PrevHash, arg.Hash)
+	var i Entry
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.Description,
+		&i.Reference,
+		&i.JournalID,
+		&i.LedgerAccountID,
+		&i.Currency,
+		&i.PrevHash,
+		&i.Hash,
+		&i.Metadata,
+		&i.LedgerSeq,
+	)
+	return i, err
+}
+
+const setLedgerEntryHash = `-- name: SetLedgerEntryHash :one
+UPDATE entries
+SET prev_hash = $2, hash = $3, ledger_seq = nextval('entries_ledger_seq')
+WHERE id = $1
+RETURNING id, account_id, amount, created_at, description, reference, journal_id, ledger_account_id, currency, prev_hash, hash, metadata, ledger_seq
+`
+
+type SetLedgerEntryHashParams struct {
+	ID       int64  `json:"id"`
+	PrevHash string `json:"prev_hash"`
+	Hash     string `json:"hash"`
+}
+
+func (q *Queries) SetLedgerEntryHash(ctx context.Context, arg SetLedgerEntryHashParams) (Entry, error) {
+	row := q.db.QueryRowContext(ctx, setLedgerEntryHash, arg.ID, arg.PrevHash, arg.Hash)
+	var i Entry
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.Description,
+		&i.Reference,
+		&i.JournalID,
+		&i.LedgerAccountID,
+		&i.Currency,
+		&i.PrevHash,
+		&i.Hash,
+		&i.Metadata,
+		&i.LedgerSeq,
+	)
+	return i, err
+}
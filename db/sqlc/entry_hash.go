@@ -0,0 +1,167 @@
+package db
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// entryChainPageSize is how many entries VerifyEntryChain reads per query.
+const entryChainPageSize = 1000
+
+// entryHashContent is what an entry's hash covers. 필드 순서가 바뀌면 기존 hash가 전부 깨지므로 바꾸면 안 된다.
+type entryHashContent struct {
+	PrevHash        string `json:"prev_hash"`
+	ID              int64  `json:"id"`
+	JournalID       int64  `json:"journal_id"`
+	AccountID       int64  `json:"account_id"`
+	LedgerAccountID int64  `json:"ledger_account_id"`
+	Currency        string `json:"currency"`
+	Amount          int64  `json:"amount"`
+	Description     string `json:"description"`
+	Reference       string `json:"reference"`
+	CreatedAt       string `json:"created_at"`
+	// metadata가 비어 있으면 빠지므로 metadata가 생기기 전의 hash도 그대로 맞는다.
+	Metadata json.RawMessage `json:"metadata,omitempty"`
+}
+
+// EntryHash returns the hex SHA-256 of the entry's contents chained to prevHash.
+func EntryHash(prevHash string, entry Entry) string {
+	data, _ := json.Marshal(entryHashContent{
+		PrevHash:        prevHash,
+		ID:              entry.ID,
+		JournalID:       entry.JournalID.Int64,
+		AccountID:       entry.AccountID.Int64,
+		LedgerAccountID: entry.LedgerAccountID.Int64,
+		Currency:        entry.Currency,
+		Amount:          entry.Amount,
+		Description:     entry.Description,
+		Reference:       entry.Reference,
+		// DB는 microsecond까지 저장하므로 읽어 온 값 그대로 UTC로 맞춘다.
+		CreatedAt: entry.CreatedAt.UTC().Format(time.RFC3339Nano),
+		Metadata:  hashedMetadata(entry.Metadata),
+	})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// hashedMetadata returns nil for empty metadata. 값은 jsonb가 정규화한 텍스트 그대로 쓴다.
+func hashedMetadata(metadata json.RawMessage) json.RawMessage {
+	switch string(metadata) {
+	case "", "{}", "null":
+		return nil
+	}
+	return metadata
+}
+
+// chainEntry links a newly created customer account entry to the last hashed entry of the same account.
+// postJournal이 계좌 row를 먼저 갱신해서 lock을 잡고 있으므로, 같은 계좌의 chain에 동시에 두 entry가 붙지 않는다.
+func chainEntry(ctx context.Context, q *Queries, entry Entry) (Entry, error) {
+	prevHash, err := q.GetLastAccountEntryHash(ctx, entry.AccountID.Int64)
+	if err != nil && err != sql.ErrNoRows {
+		return entry, err
+	}
+	return q.SetEntryHash(ctx, SetEntryHashParams{
+		ID:       entry.ID,
+		PrevHash: prevHash,
+		Hash:     EntryHash(prevHash, entry),
+	})
+}
+
+// VerifyEntryChainParams picks the chain to verify. AccountID와 LedgerAccountID 중 하나만 지정한다.
+type VerifyEntryChainParams struct {
+	AccountID       int64 `json:"account_id"`
+	LedgerAccountID int64 `json:"ledger_account_id"`
+}
+
+// EntryChainVerification reports whether an account's entries still match their hashes.
+type EntryChainVerification struct {
+	AccountID       int64 `json:"account_id,omitempty"`
+	LedgerAccountID int64 `json:"ledger_account_id,omitempty"`
+	EntriesChecked  int64 `json:"entries_checked"`
+	// hash chain이 생기기 전에 기록된 entry는 검증할 수 없다.
+	LegacyEntries int64  `json:"legacy_entries"`
+	Valid         bool   `json:"valid"`
+	BrokenEntryID int64  `json:"broken_entry_id,omitempty"`
+	Reason        string `json:"reason,omitempty"`
+}
+
+// VerifyEntryChain walks an account's entries in order and reports the first one whose hash does not hold.
+// 수정된 entry는 자기 hash가, 삭제된 entry는 다음 entry의 prev_hash가 맞지 않게 된다.
+func (store *SQLStore) VerifyEntryChain(ctx context.Context, arg VerifyEntryChainParams) (EntryChainVerification, error) {
+	result := EntryChainVerification{
+		AccountID:       arg.AccountID,
+		LedgerAccountID: arg.LedgerAccountID,
+	}
+	opts := &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+	err := store.execTx(ctx, opts, func(q *Queries) error {
+		// 없는 계좌면 sql.ErrNoRows를 돌려준다.
+		var err error
+		if arg.AccountID != 0 {
+			_, err = q.GetAccount(ctx, arg.AccountID)
+		} else {
+			_, err = q.GetLedgerAccountByID(ctx, arg.LedgerAccountID)
+		}
+		if err != nil {
+			return err
+		}
+
+		result.EntriesChecked = 0
+		result.LegacyEntries = 0
+		prevHash := ""
+		chained := false
+		var after int64
+		for {
+			var entries []Entry
+			if arg.AccountID != 0 {
+				entries, err = q.ListAccountEntryChain(ctx, ListAccountEntryChainParams{
+					AccountID: arg.AccountID,
+					AfterID:   after,
+					Limit:     entryChainPageSize,
+				})
+			} else {
+				entries, err = q.ListLedgerEntryChain(ctx, ListLedgerEntryChainParams{
+					LedgerAccountID: arg.LedgerAccountID,
+					AfterSeq:        after,
+					Limit:           entryChainPageSize,
+				})
+			}
+			if err != nil {
+				return err
+			}
+
+			for _, entry := range entries {
+				// ledger account chain은 ledger poster가 붙인 순서(ledger_seq)로 따라간다.
+				after = entry.ID
+				if arg.LedgerAccountID != 0 {
+					after = entry.LedgerSeq.Int64
+				}
+				if !chained && entry.Hash == "" && entry.PrevHash == "" {
+					result.LegacyEntries++
+					continue
+				}
+				chained = true
+				result.EntriesChecked++
+				if entry.PrevHash != prevHash {
+					result.BrokenEntryID = entry.ID
+					result.Reason = "prev_hash does not match the previous entry"
+					return nil
+				}
+				if EntryHash(entry.PrevHash, entry) != entry.Hash {
+					result.BrokenEntryID = entry.ID
+					result.Reason = "hash does not match the entry contents"
+					return nil
+				}
+				prevHash = entry.Hash
+			}
+			if len(entries) < entryChainPageSize {
+				result.Valid = true
+				return nil
+			}
+		}
+	})
+	return result, err
+}
@@ -0,0 +1,77 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntryHashChain(t *testing.T) {
+	store := NewStore(testDB, StoreConfig{})
+	account1 := createRandomAccountWithBalance(t, 0)
+	account2 := createRandomAccountWithBalance(t, 0)
+
+	deposit, err := store.DepositTx(context.Background(), CashTxParams{AccountID: account1.ID, Amount: 100})
+	require.NoError(t, err)
+	require.Empty(t, deposit.Entry.PrevHash)
+	require.Equal(t, EntryHash("", deposit.Entry), deposit.Entry.Hash)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        30,
+	})
+	require.NoError(t, err)
+	require.Equal(t, deposit.Entry.Hash, result.FromEntry.PrevHash)
+	require.Equal(t, EntryHash(result.FromEntry.PrevHash, result.FromEntry), result.FromEntry.Hash)
+
+	verification, err := store.VerifyEntryChain(context.Background(), VerifyEntryChainParams{AccountID: account1.ID})
+	require.NoError(t, err)
+	require.True(t, verification.Valid)
+	require.Equal(t, int64(2), verification.EntriesChecked)
+
+	// 누군가 entry를 직접 고치면 그 entry에서 chain이 끊긴다.
+	_, err = testDB.Exec("UPDATE entries SET description = 'edited' WHERE id = $1", deposit.Entry.ID)
+	require.NoError(t, err)
+
+	verification, err = store.VerifyEntryChain(context.Background(), VerifyEntryChainParams{AccountID: account1.ID})
+	require.NoError(t, err)
+	require.False(t, verification.Valid)
+	require.Equal(t, deposit.Entry.ID, verification.BrokenEntryID)
+}
+
+func TestEntryHashChainMetadata(t *testing.T) {
+	store := NewStore(testDB, StoreConfig{})
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccountWithBalance(t, 0)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+		Metadata:      json.RawMessage(`{"order_id": "A-1"}`),
+	})
+	require.NoError(t, err)
+	require.Equal(t, EntryHash(result.ToEntry.PrevHash, result.ToEntry), result.ToEntry.Hash)
+
+	verification, err := store.VerifyEntryChain(context.Background(), VerifyEntryChainParams{AccountID: account2.ID})
+	require.NoError(t, err)
+	require.True(t, verification.Valid)
+
+	// metadata만 고쳐도 chain이 끊긴다.
+	_, err = testDB.Exec(`UPDATE entries SET metadata = '{"order_id": "B-2"}' WHERE id = $1`, result.ToEntry.ID)
+	require.NoError(t, err)
+
+	verification, err = store.VerifyEntryChain(context.Background(), VerifyEntryChainParams{AccountID: account2.ID})
+	require.NoError(t, err)
+	require.False(t, verification.Valid)
+	require.Equal(t, result.ToEntry.ID, verification.BrokenEntryID)
+}
+
+func TestVerifyEntryChainAccountNotFound(t *testing.T) {
+	store := NewStore(testDB, StoreConfig{})
+	_, err := store.VerifyEntryChain(context.Background(), VerifyEntryChainParams{AccountID: -1})
+	require.Error(t, err)
+}
@@ -2,6 +2,8 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -11,8 +13,10 @@ import (
 
 func createRandomEntry(t *testing.T, account Account) Entry {
 	arg := CreateEntryParams{
-		AccountID: account.ID,
+		AccountID: sql.NullInt64{Int64: account.ID, Valid: true},
+		Currency:  account.Currency,
 		Amount:    util.RandomMoney(),
+		Metadata:  json.RawMessage(`{}`),
 	}
 	entry, err := testQueries.CreateEntry(context.Background(), arg)
 	require.NoError(t, err)
@@ -63,6 +67,6 @@ func TestListEntries(t *testing.T) {
 
 	for _, entry := range entries {
 		require.NotEmpty(t, entry)
-		require.Equal(t, arg.AccountID, entry.AccountID)
+		require.Equal(t, arg.AccountID, entry.AccountID.Int64)
 	}
 }
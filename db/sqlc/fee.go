@@ -0,0 +1,142 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+// fee_schedules의 operation
+// FX margin 수수료는 아직 없다. 이체는 같은 통화의 계좌 사이에서만 되고 환전하는 곳이 없어서 margin을 붙일 거래가 없다.
+// 환율과 통화가 다른 이체가 생기면 그 환전에서 "fx" operation으로 청구한다.
+const (
+	FeeOperationTransfer    = "transfer"
+	FeeOperationMaintenance = "maintenance"
+)
+
+// FeeLine is one fee charged to an account, posted to the fees ledger account.
+type FeeLine struct {
+	Operation string `json:"operation"`
+	Amount    int64  `json:"amount"`
+	Currency  string `json:"currency"`
+	Entry     Entry  `json:"entry"`
+}
+
+// CalculateFee applies a fee schedule to the amount of an operation.
+// 퍼센트 수수료는 최소 단위에서 반올림한다.
+func CalculateFee(schedule FeeSchedule, amount int64) int64 {
+	fee := schedule.FlatAmount + (amount*int64(schedule.PercentageBps)+5000)/10000
+	if fee < schedule.MinAmount {
+		fee = schedule.MinAmount
+	}
+	if schedule.MaxAmount > 0 && fee > schedule.MaxAmount {
+		fee = schedule.MaxAmount
+	}
+	return fee
+}
+
+// accountFee returns the fee the account's product charges for an operation. 수수료 일정이 없으면 0이다.
+func accountFee(ctx context.Context, q *Queries, account Account, operation string, amount int64) (int64, error) {
+	schedule, err := q.GetFeeSchedule(ctx, GetFeeScheduleParams{
+		Product:   account.Type,
+		Currency:  account.Currency,
+		Operation: operation,
+	})
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return CalculateFee(schedule, amount), nil
+}
+
+// postFee moves the fee from the account to the fees ledger account as its own journal transaction.
+// transfer 수수료는 transferID로 원래 이체와 연결된다. 갱신된 계좌를 함께 돌려준다.
+func postFee(ctx context.Context, q *Queries, account Account, operation string, fee int64, transferID sql.NullInt64) (FeeLine, Account, error) {
+	journal, err := postJournal(ctx, q, JournalParams{
+		Kind:        JournalKindFee,
+		TransferID:  transferID,
+		Description: operation + " fee",
+		Postings: []Posting{
+			{AccountID: account.ID, Currency: account.Currency, Amount: -fee},
+			{LedgerCode: LedgerAccountFees, Currency: account.Currency, Amount: fee},
+		},
+	})
+	if err != nil {
+		return FeeLine{}, account, err
+	}
+	line := FeeLine{
+		Operation: operation,
+		Amount:    fee,
+		Currency:  account.Currency,
+		Entry:     journal.Entries[0],
+	}
+	return line, journal.Accounts[0], nil
+}
+
+type ChargeMaintenanceFeeTxParams struct {
+	AccountID int64 `json:"account_id"`
+	// YYYY-MM. 계좌마다 한 기간에 한 번만 청구된다.
+	Period string `json:"period"`
+}
+
+type ChargeMaintenanceFeeTxResult struct {
+	Charge MaintenanceFeeCharge `json:"charge"`
+	// 면제된 경우에는 비어 있다.
+	Fee     FeeLine `json:"fee"`
+	Account Account `json:"account"`
+}
+
+// ChargeMaintenanceFeeTx charges the monthly maintenance fee of the account's product.
+// 청구할 수수료가 없거나 이미 그 기간에 청구했으면 sql.ErrNoRows를 돌려준다.
+// 잔액이 모자라거나 active가 아닌 계좌는 journal 없이 면제된 것으로 기록한다.
+func (store *SQLStore) ChargeMaintenanceFeeTx(ctx context.Context, arg ChargeMaintenanceFeeTxParams) (ChargeMaintenanceFeeTxResult, error) {
+	var result ChargeMaintenanceFeeTxResult
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		account, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		result.Account = account
+
+		// 관리 수수료에는 기준 금액이 없으므로 flat_amount와 min_amount만 의미가 있다.
+		fee, err := accountFee(ctx, q, account, FeeOperationMaintenance, 0)
+		if err != nil {
+			return err
+		}
+		if fee == 0 {
+			return sql.ErrNoRows
+		}
+
+		result.Charge, err = q.CreateMaintenanceFeeCharge(ctx, CreateMaintenanceFeeChargeParams{
+			AccountID: account.ID,
+			Period:    arg.Period,
+			Amount:    fee,
+		})
+		if err != nil {
+			return err
+		}
+
+		if account.Status != AccountStatusActive {
+			return nil
+		}
+		held, err := q.GetHeldAmount(ctx, account.ID)
+		if err != nil {
+			return err
+		}
+		if account.Balance-held < fee {
+			return nil
+		}
+
+		result.Fee, result.Account, err = postFee(ctx, q, account, FeeOperationMaintenance, fee, sql.NullInt64{})
+		if err != nil {
+			return err
+		}
+		result.Charge, err = q.SetMaintenanceFeeChargeJournal(ctx, SetMaintenanceFeeChargeJournalParams{
+			ID:        result.Charge.ID,
+			JournalID: sql.NullInt64{Int64: result.Fee.Entry.JournalID.Int64, Valid: true},
+		})
+		return err
+	})
+	return result, err
+}
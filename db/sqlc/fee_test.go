@@ -0,0 +1,111 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/gyu-young-park/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculateFee(t *testing.T) {
+	schedule := FeeSchedule{FlatAmount: 25, PercentageBps: 10, MinAmount: 50, MaxAmount: 500}
+	require.Equal(t, int64(50), CalculateFee(schedule, 1000))
+	require.Equal(t, int64(125), CalculateFee(schedule, 100000))
+	require.Equal(t, int64(126), CalculateFee(schedule, 100500))
+	require.Equal(t, int64(500), CalculateFee(schedule, 10000000))
+	require.Zero(t, CalculateFee(FeeSchedule{}, 10000000))
+}
+
+func TestTransferTxWithFee(t *testing.T) {
+	store := NewStore(testDB, StoreConfig{})
+	ctx := context.Background()
+
+	// 다른 테스트의 USD checking 이체에 영향을 주지 않도록 CAD 계좌로 시험한다.
+	account1, err := testQueries.CreateAccount(ctx, CreateAccountParams{Owner: createRandomUser(t).Username, Currency: util.CAD, Type: AccountTypeChecking})
+	require.NoError(t, err)
+	account2, err := testQueries.CreateAccount(ctx, CreateAccountParams{Owner: createRandomUser(t).Username, Currency: util.CAD, Type: AccountTypeChecking})
+	require.NoError(t, err)
+	_, err = store.DepositTx(ctx, CashTxParams{AccountID: account1.ID, Amount: 1000})
+	require.NoError(t, err)
+
+	schedule, err := testQueries.UpsertFeeSchedule(ctx, UpsertFeeScheduleParams{
+		Product:       AccountTypeChecking,
+		Currency:      util.CAD,
+		Operation:     FeeOperationTransfer,
+		FlatAmount:    10,
+		PercentageBps: 100,
+		UpdatedBy:     account1.Owner,
+	})
+	require.NoError(t, err)
+	defer testQueries.DeleteFeeSchedule(ctx, schedule.ID)
+
+	fees, err := testQueries.GetLedgerAccount(ctx, GetLedgerAccountParams{Code: LedgerAccountFees, Currency: util.CAD})
+	require.NoError(t, err)
+	fees = postLedgerEntries(t, store, fees.ID)
+
+	result, err := store.TransferTx(ctx, TransferTxParams{FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: 500})
+	require.NoError(t, err)
+	require.Len(t, result.Fees, 1)
+	require.Equal(t, FeeOperationTransfer, result.Fees[0].Operation)
+	require.Equal(t, int64(15), result.Fees[0].Amount)
+	require.Equal(t, int64(-15), result.Fees[0].Entry.Amount)
+	require.Equal(t, int64(1000-500-15), result.FromAccount.Balance)
+	require.Equal(t, int64(500), result.ToAccount.Balance)
+
+	feesAfter := postLedgerEntries(t, store, fees.ID)
+	require.Equal(t, fees.Balance+15, feesAfter.Balance)
+
+	// 수수료까지 낼 수 없는 이체는 거절된다.
+	_, err = store.TransferTx(ctx, TransferTxParams{FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: 480})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	// 수수료는 이체 entries와 다른 journal에 기록되므로 reconciliation에 걸리지 않는다.
+	reconciliation, err := store.ReconcileTx(ctx)
+	require.NoError(t, err)
+	for _, mismatch := range reconciliation.TransferMismatches {
+		require.NotEqual(t, result.Transfer.ID, mismatch.ID)
+	}
+}
+
+func TestChargeMaintenanceFeeTx(t *testing.T) {
+	store := NewStore(testDB, StoreConfig{})
+	ctx := context.Background()
+
+	rich, err := testQueries.CreateAccount(ctx, CreateAccountParams{Owner: createRandomUser(t).Username, Currency: util.CAD, Type: AccountTypeSavings})
+	require.NoError(t, err)
+	poor, err := testQueries.CreateAccount(ctx, CreateAccountParams{Owner: createRandomUser(t).Username, Currency: util.CAD, Type: AccountTypeSavings})
+	require.NoError(t, err)
+	_, err = store.DepositTx(ctx, CashTxParams{AccountID: rich.ID, Amount: 1000})
+	require.NoError(t, err)
+
+	// 설정 전에는 청구할 수수료가 없다.
+	_, err = store.ChargeMaintenanceFeeTx(ctx, ChargeMaintenanceFeeTxParams{AccountID: rich.ID, Period: "2022-03"})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	schedule, err := testQueries.UpsertFeeSchedule(ctx, UpsertFeeScheduleParams{
+		Product:    AccountTypeSavings,
+		Currency:   util.CAD,
+		Operation:  FeeOperationMaintenance,
+		FlatAmount: 300,
+		UpdatedBy:  rich.Owner,
+	})
+	require.NoError(t, err)
+	defer testQueries.DeleteFeeSchedule(ctx, schedule.ID)
+
+	result, err := store.ChargeMaintenanceFeeTx(ctx, ChargeMaintenanceFeeTxParams{AccountID: rich.ID, Period: "2022-03"})
+	require.NoError(t, err)
+	require.True(t, result.Charge.JournalID.Valid)
+	require.Equal(t, int64(300), result.Fee.Amount)
+	require.Equal(t, int64(700), result.Account.Balance)
+
+	_, err = store.ChargeMaintenanceFeeTx(ctx, ChargeMaintenanceFeeTxParams{AccountID: rich.ID, Period: "2022-03"})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// 잔액이 모자라면 면제된다.
+	waived, err := store.ChargeMaintenanceFeeTx(ctx, ChargeMaintenanceFeeTxParams{AccountID: poor.ID, Period: "2022-03"})
+	require.NoError(t, err)
+	require.False(t, waived.Charge.JournalID.Valid)
+	require.Zero(t, waived.Account.Balance)
+}
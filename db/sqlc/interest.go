@@ -0,0 +1,149 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/gyu-young-park/simplebank/util"
+)
+
+// account_products의 code. 계좌의 type이 어떤 상품인지를 가리킨다.
+const (
+	AccountTypeChecking = "checking"
+	AccountTypeSavings  = "savings"
+	AccountTypeBusiness = "business"
+)
+
+type AccrueInterestTxParams struct {
+	AccountID int64 `json:"account_id"`
+	// UTC 기준의 날짜. 그 날이 끝날 때의 잔액에 이자가 붙는다.
+	Date time.Time `json:"date"`
+}
+
+// AccrueInterestTx records one day of interest on an account at its product's current rate.
+// 이미 그 날의 이자가 기록되어 있으면 sql.ErrNoRows를 돌려준다.
+func (store *SQLStore) AccrueInterestTx(ctx context.Context, arg AccrueInterestTxParams) (InterestAccrual, error) {
+	var accrual InterestAccrual
+	day := time.Date(arg.Date.Year(), arg.Date.Month(), arg.Date.Day(), 0, 0, 0, 0, time.UTC)
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		account, err := q.GetAccount(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		product, err := q.GetAccountProduct(ctx, account.Type)
+		if err != nil {
+			return err
+		}
+		balance, err := balanceBefore(ctx, q, account.ID, day.AddDate(0, 0, 1))
+		if err != nil {
+			return err
+		}
+		amount, err := util.DailyInterest(balance, product.AnnualRateBps, product.DayCount, day)
+		if err != nil {
+			return err
+		}
+		accrual, err = q.CreateInterestAccrual(ctx, CreateInterestAccrualParams{
+			AccountID:     account.ID,
+			AccrualDate:   day,
+			Balance:       balance,
+			AnnualRateBps: product.AnnualRateBps,
+			DayCount:      product.DayCount,
+			AmountMicros:  amount,
+		})
+		return err
+	})
+	return accrual, err
+}
+
+type PostInterestTxParams struct {
+	AccountID int64 `json:"account_id"`
+	// YYYY-MM. 계좌마다 한 기간에 한 번만 지급된다.
+	Period string `json:"period"`
+	// 이 날짜 전까지 쌓였지만 아직 지급되지 않은 이자를 모두 지급한다.
+	Before time.Time `json:"before"`
+}
+
+type PostInterestTxResult struct {
+	Posting InterestPosting `json:"posting"`
+	// 지급할 금액이 최소 단위보다 작으면 journal 없이 나머지만 다음 기간으로 넘긴다.
+	Journal JournalTransaction `json:"journal"`
+	Account Account            `json:"account"`
+	Entry   Entry              `json:"entry"`
+}
+
+// PostInterestTx credits the unposted accrued interest of an account from the interest expense ledger account.
+// 최소 단위로 떨어지지 않는 나머지는 다음 지급에 더해진다. 이미 그 기간에 지급했으면 sql.ErrNoRows를 돌려준다.
+func (store *SQLStore) PostInterestTx(ctx context.Context, arg PostInterestTxParams) (PostInterestTxResult, error) {
+	var result PostInterestTxResult
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		// 같은 계좌의 지급이 동시에 실행되지 않도록 계좌를 먼저 잠근다.
+		account, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		result.Account = account
+
+		var carry int64
+		last, err := q.GetLastInterestPosting(ctx, account.ID)
+		switch {
+		case err == nil:
+			carry = last.CarryMicros
+		case err != sql.ErrNoRows:
+			return err
+		}
+		accrued, err := q.SumUnpostedInterestAccruals(ctx, SumUnpostedInterestAccrualsParams{
+			AccountID: account.ID,
+			Before:    arg.Before,
+		})
+		if err != nil {
+			return err
+		}
+		total := accrued + carry
+		amount := total / util.InterestMicrosPerUnit
+
+		result.Posting, err = q.CreateInterestPosting(ctx, CreateInterestPostingParams{
+			AccountID:     account.ID,
+			Period:        arg.Period,
+			AccruedMicros: accrued,
+			Amount:        amount,
+			CarryMicros:   total - amount*util.InterestMicrosPerUnit,
+		})
+		if err != nil {
+			return err
+		}
+		err = q.MarkInterestAccrualsPosted(ctx, MarkInterestAccrualsPostedParams{
+			PostingID: sql.NullInt64{Int64: result.Posting.ID, Valid: true},
+			AccountID: account.ID,
+			Before:    arg.Before,
+		})
+		if err != nil {
+			return err
+		}
+		if amount == 0 {
+			return nil
+		}
+
+		journal, err := postJournal(ctx, q, JournalParams{
+			Kind:        JournalKindInterest,
+			Description: "interest " + arg.Period,
+			Postings: []Posting{
+				{AccountID: account.ID, Currency: account.Currency, Amount: amount},
+				{LedgerCode: LedgerAccountInterest, Currency: account.Currency, Amount: -amount},
+			},
+		})
+		if err != nil {
+			return err
+		}
+		result.Journal = journal.Journal
+		result.Account = journal.Accounts[0]
+		result.Entry = journal.Entries[0]
+
+		result.Posting, err = q.SetInterestPostingJournal(ctx, SetInterestPostingJournalParams{
+			ID:        result.Posting.ID,
+			JournalID: sql.NullInt64{Int64: journal.Journal.ID, Valid: true},
+		})
+		return err
+	})
+	return result, err
+}
@@ -0,0 +1,70 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/gyu-young-park/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterestAccrualAndPosting(t *testing.T) {
+	store := NewStore(testDB, StoreConfig{})
+	ctx := context.Background()
+
+	account, err := testQueries.CreateAccount(ctx, CreateAccountParams{
+		Owner:    createRandomUser(t).Username,
+		Currency: util.USD,
+		Type:     AccountTypeSavings,
+	})
+	require.NoError(t, err)
+	_, err = store.DepositTx(ctx, CashTxParams{AccountID: account.ID, Amount: 1000000})
+	require.NoError(t, err)
+
+	product, err := testQueries.GetAccountProduct(ctx, AccountTypeSavings)
+	require.NoError(t, err)
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	daily, err := util.DailyInterest(1000000, product.AnnualRateBps, product.DayCount, today)
+	require.NoError(t, err)
+
+	for _, day := range []time.Time{today, today.AddDate(0, 0, 1)} {
+		accrual, err := store.AccrueInterestTx(ctx, AccrueInterestTxParams{AccountID: account.ID, Date: day})
+		require.NoError(t, err)
+		require.Equal(t, int64(1000000), accrual.Balance)
+		require.Equal(t, daily, accrual.AmountMicros)
+	}
+	// 같은 날짜는 한 번만 쌓인다.
+	_, err = store.AccrueInterestTx(ctx, AccrueInterestTxParams{AccountID: account.ID, Date: today.Add(time.Hour)})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	interestBefore, err := testQueries.GetLedgerAccount(ctx, GetLedgerAccountParams{Code: LedgerAccountInterest, Currency: util.USD})
+	require.NoError(t, err)
+	interestBefore = postLedgerEntries(t, store, interestBefore.ID)
+
+	before := today.AddDate(0, 0, 2)
+	result, err := store.PostInterestTx(ctx, PostInterestTxParams{AccountID: account.ID, Period: "2022-03", Before: before})
+	require.NoError(t, err)
+	amount := 2 * daily / util.InterestMicrosPerUnit
+	require.Equal(t, 2*daily, result.Posting.AccruedMicros)
+	require.Equal(t, amount, result.Posting.Amount)
+	require.Equal(t, 2*daily-amount*util.InterestMicrosPerUnit, result.Posting.CarryMicros)
+	require.True(t, result.Posting.JournalID.Valid)
+	require.Equal(t, JournalKindInterest, result.Journal.Kind)
+	require.Equal(t, int64(1000000)+amount, result.Account.Balance)
+
+	interestAfter := postLedgerEntries(t, store, interestBefore.ID)
+	require.Equal(t, interestBefore.Balance-amount, interestAfter.Balance)
+
+	// 같은 기간은 한 번만 지급된다.
+	_, err = store.PostInterestTx(ctx, PostInterestTxParams{AccountID: account.ID, Period: "2022-03", Before: before})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// 새로 쌓인 이자가 없으면 나머지만 다음 기간으로 넘어간다.
+	next, err := store.PostInterestTx(ctx, PostInterestTxParams{AccountID: account.ID, Period: "2022-04", Before: before})
+	require.NoError(t, err)
+	require.Zero(t, next.Posting.Amount)
+	require.Equal(t, result.Posting.CarryMicros, next.Posting.CarryMicros)
+	require.False(t, next.Posting.JournalID.Valid)
+}
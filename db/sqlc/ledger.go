@@ -0,0 +1,250 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// 시스템 ledger account의 code. 통화마다 하나씩 있다.
+const (
+	LedgerAccountCash     = "cash"
+	LedgerAccountFees     = "fees"
+	LedgerAccountFX       = "fx"
+	LedgerAccountInterest = "interest"
+)
+
+const (
+	JournalKindTransfer   = "transfer"
+	JournalKindDeposit    = "deposit"
+	JournalKindWithdrawal = "withdrawal"
+	JournalKindInterest   = "interest"
+	JournalKindFee        = "fee"
+)
+
+var ErrUnbalancedJournal = errors.New("journal postings do not balance")
+
+// Posting is one line of a journal transaction.
+// AccountID로 고객 계좌를, LedgerCode와 Currency로 시스템 계정을 지정한다. 둘 중 하나만 지정해야 한다.
+// amount가 양수면 credit, 음수면 debit이므로 고객 계좌의 잔액은 양수, 현금 같은 자산 계정의 잔액은 음수로 쌓인다.
+type Posting struct {
+	AccountID  int64
+	LedgerCode string
+	Currency   string
+	Amount     int64
+}
+
+type JournalParams struct {
+	Kind        string
+	TransferID  sql.NullInt64
+	Description string
+	Reference   string
+	// 비어 있으면 '{}'로 기록한다.
+	Metadata json.RawMessage
+	Postings []Posting
+}
+
+type JournalResult struct {
+	Journal JournalTransaction `json:"journal"`
+	Entries []Entry            `json:"entries"`
+	// 고객 계좌 posting은 갱신된 계좌를, 시스템 계정 posting은 빈 Account를 담는다. Postings와 순서가 같다.
+	Accounts []Account `json:"-"`
+}
+
+// ValidatePostings checks that every posting has exactly one target and that the postings sum to zero per currency.
+// 고객 계좌의 통화는 DB에서 읽기 전에는 알 수 없으므로, Currency가 비어 있는 posting은 합계 검사에서 빠진다.
+func ValidatePostings(postings []Posting) error {
+	if len(postings) < 2 {
+		return fmt.Errorf("%w: need at least two postings", ErrUnbalancedJournal)
+	}
+	sums := make(map[string]int64)
+	for _, posting := range postings {
+		if (posting.AccountID == 0) == (posting.LedgerCode == "") {
+			return errors.New("posting must target either an account or a ledger account")
+		}
+		if posting.LedgerCode != "" && posting.Currency == "" {
+			return errors.New("ledger account posting needs a currency")
+		}
+		if posting.Amount == 0 {
+			return errors.New("posting amount must not be zero")
+		}
+		sums[posting.Currency] += posting.Amount
+	}
+	for currency, sum := range sums {
+		if currency != "" && sum != 0 {
+			return fmt.Errorf("%w: %s postings sum to %d", ErrUnbalancedJournal, currency, sum)
+		}
+	}
+	return nil
+}
+
+// postJournal records a journal transaction and applies its postings to the customer account balances.
+// 고객 계좌는 ID 순서로 갱신해서 동시에 실행되는 journal끼리 deadlock이 나지 않게 한다.
+// 시스템 계정은 모든 deposit, withdrawal, fee, interest가 같이 쓰는 row라서 entry만 기록하고 balance는 ledger poster가 반영한다.
+// posting 합은 DB의 deferred constraint로도 한 번 더 검사된다.
+func postJournal(ctx context.Context, q *Queries, arg JournalParams) (JournalResult, error) {
+	result := JournalResult{
+		Entries:  make([]Entry, len(arg.Postings)),
+		Accounts: make([]Account, len(arg.Postings)),
+	}
+	if err := ValidatePostings(arg.Postings); err != nil {
+		return result, err
+	}
+
+	order := make([]int, len(arg.Postings))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := arg.Postings[order[i]], arg.Postings[order[j]]
+		if (a.AccountID == 0) != (b.AccountID == 0) {
+			return a.AccountID != 0
+		}
+		if a.AccountID != b.AccountID {
+			return a.AccountID < b.AccountID
+		}
+		if a.LedgerCode != b.LedgerCode {
+			return a.LedgerCode < b.LedgerCode
+		}
+		return a.Currency < b.Currency
+	})
+
+	currencies := make([]string, len(arg.Postings))
+	ledgerAccountIDs := make([]int64, len(arg.Postings))
+	sums := make(map[string]int64)
+	for _, i := range order {
+		posting := arg.Postings[i]
+		if posting.AccountID != 0 {
+			account, err := q.AddAccountBalance(ctx, AddAccountBalanceParams{
+				ID:     posting.AccountID,
+				Amount: posting.Amount,
+			})
+			if err != nil {
+				return result, err
+			}
+			if account.Status != AccountStatusActive {
+				return result, fmt.Errorf("%w: account %d is %s", ErrAccountNotActive, account.ID, account.Status)
+			}
+			if posting.Currency != "" && posting.Currency != account.Currency {
+				return result, fmt.Errorf("%w: account %d is in %s, not %s", ErrUnbalancedJournal, account.ID, account.Currency, posting.Currency)
+			}
+			result.Accounts[i] = account
+			currencies[i] = account.Currency
+		} else {
+			// 시스템 계정 row는 여기서 갱신하지 않는다. PostLedgerEntriesTx가 나중에 모아서 balance에 더한다.
+			ledgerAccount, err := q.GetLedgerAccount(ctx, GetLedgerAccountParams{
+				Code:     posting.LedgerCode,
+				Currency: posting.Currency,
+			})
+			if err != nil {
+				return result, err
+			}
+			ledgerAccountIDs[i] = ledgerAccount.ID
+			currencies[i] = posting.Currency
+		}
+		sums[currencies[i]] += posting.Amount
+	}
+	for currency, sum := range sums {
+		if sum != 0 {
+			return result, fmt.Errorf("%w: %s postings sum to %d", ErrUnbalancedJournal, currency, sum)
+		}
+	}
+
+	metadata := arg.Metadata
+	if len(metadata) == 0 {
+		metadata = json.RawMessage("{}")
+	}
+
+	var err error
+	result.Journal, err = q.CreateJournalTransaction(ctx, CreateJournalTransactionParams{
+		Kind:        arg.Kind,
+		TransferID:  arg.TransferID,
+		Description: arg.Description,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	for i, posting := range arg.Postings {
+		result.Entries[i], err = q.CreateEntry(ctx, CreateEntryParams{
+			JournalID:       sql.NullInt64{Int64: result.Journal.ID, Valid: true},
+			AccountID:       sql.NullInt64{Int64: posting.AccountID, Valid: posting.AccountID != 0},
+			LedgerAccountID: sql.NullInt64{Int64: ledgerAccountIDs[i], Valid: ledgerAccountIDs[i] != 0},
+			Currency:        currencies[i],
+			Amount:          posting.Amount,
+			Description:     arg.Description,
+			Reference:       arg.Reference,
+			Metadata:        metadata,
+		})
+		if err != nil {
+			return result, err
+		}
+		// 시스템 계정 entry는 ledger poster가 balance에 반영하면서 chain에 붙인다.
+		if posting.AccountID == 0 {
+			continue
+		}
+		result.Entries[i], err = chainEntry(ctx, q, result.Entries[i])
+		if err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// ledgerPostingBatchSize is how many pending entries PostLedgerEntriesTx applies at once.
+const ledgerPostingBatchSize = 1000
+
+type PostLedgerEntriesTxResult struct {
+	LedgerAccount LedgerAccount `json:"ledger_account"`
+	Entries       []Entry       `json:"entries"`
+}
+
+// PostLedgerEntriesTx applies a ledger account's pending entries to its balance and hash chain in one row update.
+// ledger account row를 lock한 채로 chain을 붙이므로 같은 ledger account의 chain에 동시에 두 entry가 붙지 않는다.
+// 늦게 commit된 journal의 entry는 id가 작아도 뒤에 붙고, 검증은 ledger_seq 순서로 따라간다.
+func (store *SQLStore) PostLedgerEntriesTx(ctx context.Context, ledgerAccountID int64) (PostLedgerEntriesTxResult, error) {
+	var result PostLedgerEntriesTxResult
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		var err error
+		result.LedgerAccount, err = q.GetLedgerAccountForUpdate(ctx, ledgerAccountID)
+		if err != nil {
+			return err
+		}
+		result.Entries, err = q.ListPendingLedgerEntries(ctx, ListPendingLedgerEntriesParams{
+			LedgerAccountID: ledgerAccountID,
+			Limit:           ledgerPostingBatchSize,
+		})
+		if err != nil || len(result.Entries) == 0 {
+			return err
+		}
+
+		prevHash, err := q.GetLastLedgerEntryHash(ctx, ledgerAccountID)
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+		var total int64
+		for i, entry := range result.Entries {
+			result.Entries[i], err = q.SetLedgerEntryHash(ctx, SetLedgerEntryHashParams{
+				ID:       entry.ID,
+				PrevHash: prevHash,
+				Hash:     EntryHash(prevHash, entry),
+			})
+			if err != nil {
+				return err
+			}
+			prevHash = result.Entries[i].Hash
+			total += entry.Amount
+		}
+
+		result.LedgerAccount, err = q.AddLedgerAccountBalance(ctx, AddLedgerAccountBalanceParams{
+			Code:     result.LedgerAccount.Code,
+			Currency: result.LedgerAccount.Currency,
+			Amount:   total,
+		})
+		return err
+	})
+	return result, err
+}
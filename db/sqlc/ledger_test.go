@@ -0,0 +1,229 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"github.com/gyu-young-park/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePostings(t *testing.T) {
+	require.NoError(t, ValidatePostings([]Posting{
+		{AccountID: 1, Amount: -10},
+		{AccountID: 2, Amount: 10},
+	}))
+	require.NoError(t, ValidatePostings([]Posting{
+		{AccountID: 1, Currency: "USD", Amount: 10},
+		{LedgerCode: LedgerAccountCash, Currency: "USD", Amount: -10},
+	}))
+
+	require.ErrorIs(t, ValidatePostings([]Posting{
+		{AccountID: 1, Currency: "USD", Amount: 10},
+	}), ErrUnbalancedJournal)
+	require.ErrorIs(t, ValidatePostings([]Posting{
+		{AccountID: 1, Currency: "USD", Amount: 10},
+		{LedgerCode: LedgerAccountCash, Currency: "USD", Amount: -9},
+	}), ErrUnbalancedJournal)
+	require.ErrorIs(t, ValidatePostings([]Posting{
+		{AccountID: 1, Currency: "USD", Amount: 10},
+		{LedgerCode: LedgerAccountCash, Currency: "EUR", Amount: -10},
+	}), ErrUnbalancedJournal)
+
+	require.Error(t, ValidatePostings([]Posting{
+		{AccountID: 1, LedgerCode: LedgerAccountCash, Currency: "USD", Amount: 10},
+		{LedgerCode: LedgerAccountCash, Currency: "USD", Amount: -10},
+	}))
+	require.Error(t, ValidatePostings([]Posting{
+		{AccountID: 1, Amount: 10},
+		{LedgerCode: LedgerAccountCash, Amount: -10},
+	}))
+	require.Error(t, ValidatePostings([]Posting{
+		{AccountID: 1, Amount: 0},
+		{AccountID: 2, Amount: 0},
+	}))
+}
+
+func TestTransferTxPostsJournal(t *testing.T) {
+	store := NewStore(testDB, StoreConfig{})
+
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccountWithBalance(t, 0)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        30,
+	})
+	require.NoError(t, err)
+	require.Equal(t, JournalKindTransfer, result.Journal.Kind)
+	require.Equal(t, result.Transfer.ID, result.Journal.TransferID.Int64)
+
+	entries, err := testQueries.ListJournalEntries(context.Background(), result.Journal.ID)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	var sum int64
+	for _, entry := range entries {
+		require.Equal(t, account1.Currency, entry.Currency)
+		sum += entry.Amount
+	}
+	require.Zero(t, sum)
+}
+
+func TestTransferTxCurrencyMismatch(t *testing.T) {
+	store := NewStore(testDB, StoreConfig{})
+
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    createRandomUser(t).Username,
+		Currency: util.EUR,
+		Type:     AccountTypeChecking,
+	})
+	require.NoError(t, err)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        30,
+	})
+	require.ErrorIs(t, err, ErrUnbalancedJournal)
+}
+
+func TestUnbalancedJournalRejectedAtCommit(t *testing.T) {
+	account := createRandomAccount(t)
+
+	tx, err := testDB.BeginTx(context.Background(), nil)
+	require.NoError(t, err)
+	q := New(tx)
+
+	journal, err := q.CreateJournalTransaction(context.Background(), CreateJournalTransactionParams{
+		Kind: JournalKindDeposit,
+	})
+	require.NoError(t, err)
+
+	// 상대 posting 없이 한쪽만 기록하면 commit 시점에 deferred constraint가 막는다.
+	_, err = q.CreateEntry(context.Background(), CreateEntryParams{
+		JournalID: sql.NullInt64{Int64: journal.ID, Valid: true},
+		AccountID: sql.NullInt64{Int64: account.ID, Valid: true},
+		Currency:  account.Currency,
+		Amount:    10,
+		Metadata:  json.RawMessage(`{}`),
+	})
+	require.NoError(t, err)
+	require.Error(t, tx.Commit())
+}
+
+func TestDepositAndWithdrawTx(t *testing.T) {
+	store := NewStore(testDB, StoreConfig{})
+	account := createRandomAccountWithBalance(t, 0)
+
+	cash, err := testQueries.GetLedgerAccount(context.Background(), GetLedgerAccountParams{
+		Code:     LedgerAccountCash,
+		Currency: account.Currency,
+	})
+	require.NoError(t, err)
+	cash = postLedgerEntries(t, store, cash.ID)
+
+	deposit, err := store.DepositTx(context.Background(), CashTxParams{
+		AccountID: account.ID,
+		Amount:    100,
+	})
+	require.NoError(t, err)
+	require.Equal(t, JournalKindDeposit, deposit.Journal.Kind)
+	require.Equal(t, int64(100), deposit.Account.Balance)
+	require.Equal(t, int64(100), deposit.Entry.Amount)
+
+	entries, err := testQueries.ListJournalEntries(context.Background(), deposit.Journal.ID)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, cash.ID, entries[1].LedgerAccountID.Int64)
+	require.Equal(t, int64(-100), entries[1].Amount)
+
+	withdrawal, err := store.WithdrawTx(context.Background(), CashTxParams{
+		AccountID: account.ID,
+		Amount:    60,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(40), withdrawal.Account.Balance)
+	require.Equal(t, int64(-60), withdrawal.Entry.Amount)
+
+	_, err = store.WithdrawTx(context.Background(), CashTxParams{
+		AccountID: account.ID,
+		Amount:    41,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	// ledger poster가 반영하기 전까지 cash 계정의 잔액은 그대로이다.
+	pending, err := testQueries.GetLedgerAccountByID(context.Background(), cash.ID)
+	require.NoError(t, err)
+	require.Equal(t, cash.Balance, pending.Balance)
+
+	// 입금 100, 출금 60만큼 cash 계정의 잔액이 움직였다.
+	after := postLedgerEntries(t, store, cash.ID)
+	require.Equal(t, cash.Balance-40, after.Balance)
+}
+
+func TestPostLedgerEntriesTx(t *testing.T) {
+	store := NewStore(testDB, StoreConfig{})
+	account := createRandomAccountWithBalance(t, 0)
+	cash, err := testQueries.GetLedgerAccount(context.Background(), GetLedgerAccountParams{
+		Code:     LedgerAccountCash,
+		Currency: account.Currency,
+	})
+	require.NoError(t, err)
+	cash = postLedgerEntries(t, store, cash.ID)
+
+	var cashEntryIDs []int64
+	for i := 0; i < 3; i++ {
+		deposit, err := store.DepositTx(context.Background(), CashTxParams{
+			AccountID: account.ID,
+			Amount:    10,
+		})
+		require.NoError(t, err)
+		entries, err := testQueries.ListJournalEntries(context.Background(), deposit.Journal.ID)
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		// 고객 계좌 entry는 바로 chain에 붙고, cash entry는 ledger poster를 기다린다.
+		require.NotEmpty(t, entries[0].Hash)
+		require.Empty(t, entries[1].Hash)
+		require.False(t, entries[1].LedgerSeq.Valid)
+		cashEntryIDs = append(cashEntryIDs, entries[1].ID)
+	}
+
+	result, err := store.PostLedgerEntriesTx(context.Background(), cash.ID)
+	require.NoError(t, err)
+	require.Len(t, result.Entries, 3)
+	require.Equal(t, cash.Balance-30, result.LedgerAccount.Balance)
+	for i, entry := range result.Entries {
+		require.Equal(t, cashEntryIDs[i], entry.ID)
+		require.True(t, entry.LedgerSeq.Valid)
+		require.Equal(t, EntryHash(entry.PrevHash, entry), entry.Hash)
+		if i > 0 {
+			require.Equal(t, result.Entries[i-1].Hash, entry.PrevHash)
+			require.Greater(t, entry.LedgerSeq.Int64, result.Entries[i-1].LedgerSeq.Int64)
+		}
+	}
+
+	// 반영할 entry가 없으면 잔액도 그대로이다.
+	result, err = store.PostLedgerEntriesTx(context.Background(), cash.ID)
+	require.NoError(t, err)
+	require.Empty(t, result.Entries)
+	require.Equal(t, cash.Balance-30, result.LedgerAccount.Balance)
+
+	verification, err := store.VerifyEntryChain(context.Background(), VerifyEntryChainParams{LedgerAccountID: cash.ID})
+	require.NoError(t, err)
+	require.True(t, verification.Valid)
+}
+
+// postLedgerEntries applies every pending entry of the ledger account and returns it.
+func postLedgerEntries(t *testing.T, store Store, ledgerAccountID int64) LedgerAccount {
+	for {
+		result, err := store.PostLedgerEntriesTx(context.Background(), ledgerAccountID)
+		require.NoError(t, err)
+		if len(result.Entries) == 0 {
+			return result.LedgerAccount
+		}
+	}
+}
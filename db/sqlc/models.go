@@ -3,23 +3,312 @@
 package db
 
 import (
+	"database/sql"
+	"encoding/json"
 	"time"
 )
 
+type AccountHolder struct {
+	ID        int64  `json:"id"`
+	AccountID int64  `json:"account_id"`
+	Username  string `json:"username"`
+	Role      string `json:"role"`
+	Status    string `json:"status"`
+	// null for the primary holder
+	InvitedBy  sql.NullString `json:"invited_by"`
+	CreatedAt  time.Time      `json:"created_at"`
+	AcceptedAt sql.NullTime   `json:"accepted_at"`
+}
+
+type AccountHold struct {
+	ID          int64 `json:"id"`
+	AccountID   int64 `json:"account_id"`
+	ToAccountID int64 `json:"to_account_id"`
+	Amount      int64 `json:"amount"`
+	// amount settled by capture, the rest of the hold is released
+	CapturedAmount int64     `json:"captured_amount"`
+	Status         string    `json:"status"`
+	ExpiresAt      time.Time `json:"expires_at"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type AccountLimit struct {
+	AccountID      int64     `json:"account_id"`
+	MaxPerTransfer int64     `json:"max_per_transfer"`
+	MaxDailyAmount int64     `json:"max_daily_amount"`
+	MaxDailyCount  int32     `json:"max_daily_count"`
+	UpdatedBy      string    `json:"updated_by"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type AccountProduct struct {
+	Code          string    `json:"code"`
+	Name          string    `json:"name"`
+	AnnualRateBps int32     `json:"annual_rate_bps"`
+	DayCount      string    `json:"day_count"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type AccountStatusHistory struct {
+	ID         int64  `json:"id"`
+	AccountID  int64  `json:"account_id"`
+	FromStatus string `json:"from_status"`
+	ToStatus   string `json:"to_status"`
+	Reason     string `json:"reason"`
+	// username of the user or banker, or the job that changed the status
+	ChangedBy string    `json:"changed_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 type Account struct {
-	ID        int64     `json:"id"`
-	Owner     string    `json:"owner"`
-	Currency  string    `json:"currency"`
+	ID           int64        `json:"id"`
+	Owner        string       `json:"owner"`
+	Currency     string       `json:"currency"`
+	Balance      int64        `json:"balance"`
+	CreatedAt    time.Time    `json:"created_at"`
+	Status       string       `json:"status"`
+	Type         string       `json:"type"`
+	Nickname     string       `json:"nickname"`
+	StatusReason string       `json:"status_reason"`
+	ClosedAt     sql.NullTime `json:"closed_at"`
+}
+
+type BalanceSnapshot struct {
+	ID        int64 `json:"id"`
+	AccountID int64 `json:"account_id"`
+	// sum of the account entries created before taken_at
 	Balance   int64     `json:"balance"`
+	TakenAt   time.Time `json:"taken_at"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
 type Entry struct {
-	ID        int64 `json:"id"`
-	AccountID int64 `json:"account_id"`
+	ID        int64         `json:"id"`
+	AccountID sql.NullInt64 `json:"account_id"`
 	// negative or positive
 	Amount    int64     `json:"amount"`
 	CreatedAt time.Time `json:"created_at"`
+	// copied from the transfer for statement display
+	Description string `json:"description"`
+	Reference   string `json:"reference"`
+	// null for entries posted before the double-entry ledger
+	JournalID       sql.NullInt64 `json:"journal_id"`
+	LedgerAccountID sql.NullInt64 `json:"ledger_account_id"`
+	Currency        string        `json:"currency"`
+	PrevHash        string        `json:"prev_hash"`
+	// empty for entries posted before the hash chain
+	Hash string `json:"hash"`
+	// copied from the transfer like description and reference
+	Metadata json.RawMessage `json:"metadata"`
+	// position in the ledger account hash chain; NULL until the ledger poster applies the entry
+	LedgerSeq sql.NullInt64 `json:"ledger_seq"`
+}
+
+type FeeSchedule struct {
+	ID            int64     `json:"id"`
+	Product       string    `json:"product"`
+	Currency      string    `json:"currency"`
+	Operation     string    `json:"operation"`
+	FlatAmount    int64     `json:"flat_amount"`
+	PercentageBps int32     `json:"percentage_bps"`
+	MinAmount     int64     `json:"min_amount"`
+	MaxAmount     int64     `json:"max_amount"`
+	UpdatedBy     string    `json:"updated_by"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type InterestAccrual struct {
+	ID          int64     `json:"id"`
+	AccountID   int64     `json:"account_id"`
+	AccrualDate time.Time `json:"accrual_date"`
+	// account balance at the end of accrual_date in UTC
+	Balance       int64         `json:"balance"`
+	AnnualRateBps int32         `json:"annual_rate_bps"`
+	DayCount      string        `json:"day_count"`
+	AmountMicros  int64         `json:"amount_micros"`
+	PostingID     sql.NullInt64 `json:"posting_id"`
+	CreatedAt     time.Time     `json:"created_at"`
+}
+
+type InterestPosting struct {
+	ID            int64  `json:"id"`
+	AccountID     int64  `json:"account_id"`
+	Period        string `json:"period"`
+	AccruedMicros int64  `json:"accrued_micros"`
+	Amount        int64  `json:"amount"`
+	CarryMicros   int64  `json:"carry_micros"`
+	// null when the accrued interest was less than one minor unit
+	JournalID sql.NullInt64 `json:"journal_id"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+type JournalTransaction struct {
+	ID          int64         `json:"id"`
+	Kind        string        `json:"kind"`
+	TransferID  sql.NullInt64 `json:"transfer_id"`
+	Description string        `json:"description"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+type LedgerAccount struct {
+	ID        int64     `json:"id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	Type      string    `json:"type"`
+	Currency  string    `json:"currency"`
+	Balance   int64     `json:"balance"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type MaintenanceFeeCharge struct {
+	ID        int64  `json:"id"`
+	AccountID int64  `json:"account_id"`
+	Period    string `json:"period"`
+	Amount    int64  `json:"amount"`
+	// null when the fee was waived because the account could not cover it
+	JournalID sql.NullInt64 `json:"journal_id"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+type Payee struct {
+	ID        int64  `json:"id"`
+	Owner     string `json:"owner"`
+	Nickname  string `json:"nickname"`
+	AccountID int64  `json:"account_id"`
+	// owner of the target account, shown instead of the account id
+	Recipient string    `json:"recipient"`
+	Currency  string    `json:"currency"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type PaymentInitiation struct {
+	ID        int64     `json:"id"`
+	Owner     string    `json:"owner"`
+	MessageID string    `json:"message_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type PaymentInstruction struct {
+	ID            int64  `json:"id"`
+	Owner         string `json:"owner"`
+	InstructionID string `json:"instruction_id"`
+	// MsgId of the pain.001 document the instruction came in
+	MessageID string    `json:"message_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type PaymentRequest struct {
+	ID          int64  `json:"id"`
+	Requester   string `json:"requester"`
+	Payer       string `json:"payer"`
+	ToAccountID int64  `json:"to_account_id"`
+	Amount      int64  `json:"amount"`
+	Currency    string `json:"currency"`
+	Description string `json:"description"`
+	Status      string `json:"status"`
+	// transfer that paid the request
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	ExpiresAt  time.Time     `json:"expires_at"`
+	ResolvedAt sql.NullTime  `json:"resolved_at"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+type ReconciliationRun struct {
+	ID               int64           `json:"id"`
+	AccountsChecked  int64           `json:"accounts_checked"`
+	TransfersChecked int64           `json:"transfers_checked"`
+	DiscrepancyCount int32           `json:"discrepancy_count"`
+	FrozenCount      int32           `json:"frozen_count"`
+	Report           json.RawMessage `json:"report"`
+	StartedAt        time.Time       `json:"started_at"`
+	FinishedAt       time.Time       `json:"finished_at"`
+}
+
+type StandingOrder struct {
+	ID            int64  `json:"id"`
+	Owner         string `json:"owner"`
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	Amount        int64  `json:"amount"`
+	Frequency     string `json:"frequency"`
+	// weekday (0 = sunday) for weekly, day of month for monthly
+	Day            int32         `json:"day"`
+	StartDate      time.Time     `json:"start_date"`
+	EndDate        sql.NullTime  `json:"end_date"`
+	MaxOccurrences sql.NullInt32 `json:"max_occurrences"`
+	Occurrences    int32         `json:"occurrences"`
+	NextRunAt      time.Time     `json:"next_run_at"`
+	FailedAttempts int32         `json:"failed_attempts"`
+	LastAttemptAt  sql.NullTime  `json:"last_attempt_at"`
+	LastError      string        `json:"last_error"`
+	Status         string        `json:"status"`
+	CreatedAt      time.Time     `json:"created_at"`
+}
+
+type Statement struct {
+	ID        int64 `json:"id"`
+	AccountID int64 `json:"account_id"`
+	// calendar month in UTC, formatted as YYYY-MM
+	Period         string          `json:"period"`
+	OpeningBalance int64           `json:"opening_balance"`
+	ClosingBalance int64           `json:"closing_balance"`
+	Data           json.RawMessage `json:"data"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+type StatusHistory struct {
+	ID         int64 `json:"id"`
+	TransferID int64 `json:"transfer_id"`
+	// null for the status a transfer was created with
+	FromStatus sql.NullString `json:"from_status"`
+	ToStatus   string         `json:"to_status"`
+	Reason     string         `json:"reason"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+type TransferApproval struct {
+	ID            int64           `json:"id"`
+	Initiator     string          `json:"initiator"`
+	FromAccountID int64           `json:"from_account_id"`
+	ToAccountID   int64           `json:"to_account_id"`
+	Amount        int64           `json:"amount"`
+	Currency      string          `json:"currency"`
+	Description   string          `json:"description"`
+	Reference     string          `json:"reference"`
+	Metadata      json.RawMessage `json:"metadata"`
+	Status        string          `json:"status"`
+	Reviewer      sql.NullString  `json:"reviewer"`
+	Reason        string          `json:"reason"`
+	// transfer executed after approval
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	ReviewedAt sql.NullTime  `json:"reviewed_at"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+type TransferBatchItem struct {
+	ID      int64 `json:"id"`
+	BatchID int64 `json:"batch_id"`
+	// position of the item in the submitted list, starting at 1
+	LineNo        int32         `json:"line_no"`
+	ToAccountID   int64         `json:"to_account_id"`
+	Amount        int64         `json:"amount"`
+	Status        string        `json:"status"`
+	TransferID    sql.NullInt64 `json:"transfer_id"`
+	FailureReason string        `json:"failure_reason"`
+}
+
+type TransferBatch struct {
+	ID             int64     `json:"id"`
+	Owner          string    `json:"owner"`
+	FromAccountID  int64     `json:"from_account_id"`
+	Currency       string    `json:"currency"`
+	Mode           string    `json:"mode"`
+	Status         string    `json:"status"`
+	TotalCount     int32     `json:"total_count"`
+	TotalAmount    int64     `json:"total_amount"`
+	SucceededCount int32     `json:"succeeded_count"`
+	FailedCount    int32     `json:"failed_count"`
+	CreatedAt      time.Time `json:"created_at"`
 }
 
 type Transfer struct {
@@ -27,8 +316,18 @@ type Transfer struct {
 	FromAccountID int64 `json:"from_account_id"`
 	ToAccountID   int64 `json:"to_account_id"`
 	// negative or positive
-	Amount    int64     `json:"amount"`
-	CreatedAt time.Time `json:"created_at"`
+	Amount          int64         `json:"amount"`
+	CreatedAt       time.Time     `json:"created_at"`
+	StandingOrderID sql.NullInt64 `json:"standing_order_id"`
+	// original transfer when this transfer is a reversal or refund
+	ReversalOf  sql.NullInt64 `json:"reversal_of"`
+	HoldID      sql.NullInt64 `json:"hold_id"`
+	Description string        `json:"description"`
+	// remittance reference such as an invoice number
+	Reference string          `json:"reference"`
+	Metadata  json.RawMessage `json:"metadata"`
+	// pending -> completed | failed | cancelled, completed -> reversed
+	Status string `json:"status"`
 }
 
 type User struct {
@@ -38,4 +337,5 @@ type User struct {
 	Email             string    `json:"email"`
 	PasswordChangedAt time.Time `json:"password_changed_at"`
 	CreatedAt         time.Time `json:"created_at"`
+	Role              string    `json:"role"`
 }
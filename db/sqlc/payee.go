@@ -0,0 +1,47 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gyu-young-park/simplebank/util"
+)
+
+var ErrCoolingOff = errors.New("recipient is in its cooling-off period")
+
+// PayeeCoolingOff limits how much can be sent to a recipient account the sender has only recently started paying.
+type PayeeCoolingOff struct {
+	// 통화별 한도. 없는 통화는 제한하지 않는다.
+	Limits map[string]int64
+	Period time.Duration
+}
+
+// Check checks a payment of amount from fromAccount to the recipient account at the given time against the cooling-off limit.
+// 보내는 사람이 받는 계좌를 payee로 저장했거나 처음 돈을 보낸 때부터 Period 동안은 통화별 한도까지만 보낼 수 있다.
+// 처음 보내는 계좌라면 지금부터 cooling-off가 시작된다. 자기 계좌로 보내는 것은 제한하지 않는다.
+func (coolingOff PayeeCoolingOff) Check(ctx context.Context, q Querier, owner string, fromAccount Account, toAccountID int64, toOwner string, amount int64, at time.Time) error {
+	limit, ok := coolingOff.Limits[fromAccount.Currency]
+	if !ok || amount <= limit || toOwner == owner {
+		return nil
+	}
+	knownSince, err := q.GetRecipientKnownSince(ctx, GetRecipientKnownSinceParams{
+		Owner:         owner,
+		ToAccountID:   toAccountID,
+		FromAccountID: fromAccount.ID,
+	})
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	if knownSince.Valid {
+		start = knownSince.Time
+	}
+	coolingOffEnds := start.Add(coolingOff.Period)
+	if at.Before(coolingOffEnds) {
+		limit := util.NewMoney(limit, fromAccount.Currency)
+		return fmt.Errorf("%w: account [%d] can receive at most %s until %s", ErrCoolingOff, toAccountID, limit, coolingOffEnds.Format(time.RFC3339))
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/gyu-young-park/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomPayee(t *testing.T, owner string) Payee {
+	account := createRandomAccount(t)
+	arg := CreatePayeeParams{
+		Owner:     owner,
+		Nickname:  util.RandomOwner(),
+		AccountID: account.ID,
+		Recipient: account.Owner,
+		Currency:  account.Currency,
+	}
+
+	payee, err := testQueries.CreatePayee(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Owner, payee.Owner)
+	require.Equal(t, arg.Nickname, payee.Nickname)
+	require.Equal(t, arg.AccountID, payee.AccountID)
+	require.Equal(t, arg.Recipient, payee.Recipient)
+	require.Equal(t, arg.Currency, payee.Currency)
+	require.NotZero(t, payee.ID)
+	require.NotZero(t, payee.CreatedAt)
+	return payee
+}
+
+func TestCreatePayee(t *testing.T) {
+	user := createRandomUser(t)
+	createRandomPayee(t, user.Username)
+}
+
+func TestGetPayee(t *testing.T) {
+	user := createRandomUser(t)
+	payee1 := createRandomPayee(t, user.Username)
+
+	payee2, err := testQueries.GetPayee(context.Background(), payee1.ID)
+	require.NoError(t, err)
+	require.Equal(t, payee1.ID, payee2.ID)
+	require.Equal(t, payee1.Nickname, payee2.Nickname)
+	require.Equal(t, payee1.AccountID, payee2.AccountID)
+	require.WithinDuration(t, payee1.CreatedAt, payee2.CreatedAt, time.Second)
+}
+
+func TestUpdatePayee(t *testing.T) {
+	user := createRandomUser(t)
+	payee1 := createRandomPayee(t, user.Username)
+
+	payee2, err := testQueries.UpdatePayee(context.Background(), UpdatePayeeParams{
+		ID:       payee1.ID,
+		Nickname: util.RandomOwner(),
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, payee1.Nickname, payee2.Nickname)
+	require.Equal(t, payee1.AccountID, payee2.AccountID)
+}
+
+func TestDeletePayee(t *testing.T) {
+	user := createRandomUser(t)
+	payee1 := createRandomPayee(t, user.Username)
+
+	err := testQueries.DeletePayee(context.Background(), payee1.ID)
+	require.NoError(t, err)
+
+	_, err = testQueries.GetPayee(context.Background(), payee1.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestListPayees(t *testing.T) {
+	user := createRandomUser(t)
+	for i := 0; i < 5; i++ {
+		createRandomPayee(t, user.Username)
+	}
+
+	payees, err := testQueries.ListPayees(context.Background(), ListPayeesParams{
+		Owner:  user.Username,
+		Limit:  5,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Len(t, payees, 5)
+	for _, payee := range payees {
+		require.Equal(t, user.Username, payee.Owner)
+	}
+}
+
+func TestGetRecipientKnownSince(t *testing.T) {
+	store := NewStore(testDB, StoreConfig{})
+	from := createRandomAccountWithBalance(t, 100)
+	to := createRandomAccount(t)
+	arg := GetRecipientKnownSinceParams{
+		Owner:         from.Owner,
+		ToAccountID:   to.ID,
+		FromAccountID: from.ID,
+	}
+
+	// 처음 보내는 계좌는 알게 된 시각이 없다.
+	knownSince, err := testQueries.GetRecipientKnownSince(context.Background(), arg)
+	require.NoError(t, err)
+	require.False(t, knownSince.Valid)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	knownSince, err = testQueries.GetRecipientKnownSince(context.Background(), arg)
+	require.NoError(t, err)
+	require.True(t, knownSince.Valid)
+	require.WithinDuration(t, result.Transfer.CreatedAt, knownSince.Time, time.Second)
+}
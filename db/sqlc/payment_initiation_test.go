@@ -0,0 +1,56 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gyu-young-park/simplebank/util"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatePaymentInitiation(t *testing.T) {
+	user := createRandomUser(t)
+	messageID := util.RandomString(12)
+
+	initiation, err := testQueries.CreatePaymentInitiation(context.Background(), CreatePaymentInitiationParams{
+		Owner:     user.Username,
+		MessageID: messageID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, messageID, initiation.MessageID)
+
+	// 같은 사용자가 같은 MsgId를 다시 보낼 수 없다.
+	_, err = testQueries.CreatePaymentInitiation(context.Background(), CreatePaymentInitiationParams{
+		Owner:     user.Username,
+		MessageID: messageID,
+	})
+	requireUniqueViolation(t, err)
+}
+
+func TestCreatePaymentInstruction(t *testing.T) {
+	user := createRandomUser(t)
+	arg := CreatePaymentInstructionParams{
+		Owner:         user.Username,
+		InstructionID: util.RandomString(12),
+		MessageID:     util.RandomString(12),
+	}
+
+	instruction, err := testQueries.CreatePaymentInstruction(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.InstructionID, instruction.InstructionID)
+
+	_, err = testQueries.CreatePaymentInstruction(context.Background(), arg)
+	requireUniqueViolation(t, err)
+
+	// 지운 InstrId는 다시 쓸 수 있다.
+	require.NoError(t, testQueries.DeletePaymentInstruction(context.Background(), instruction.ID))
+	_, err = testQueries.CreatePaymentInstruction(context.Background(), arg)
+	require.NoError(t, err)
+}
+
+func requireUniqueViolation(t *testing.T, err error) {
+	pqErr, ok := err.(*pq.Error)
+	require.True(t, ok, err)
+	require.Equal(t, "unique_violation", pqErr.Code.Name())
+}
@@ -4,24 +4,146 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 )
 
 type Querier interface {
+	AcceptAccountInvitation(ctx context.Context, id int64) (AccountHolder, error)
 	AddAccountBalance(ctx context.Context, arg AddAccountBalanceParams) (Account, error)
+	AddLedgerAccountBalance(ctx context.Context, arg AddLedgerAccountBalanceParams) (LedgerAccount, error)
+	ApproveTransferApproval(ctx context.Context, arg ApproveTransferApprovalParams) (TransferApproval, error)
+	CancelAccountStandingOrders(ctx context.Context, fromAccountID int64) (int64, error)
+	CaptureAccountHold(ctx context.Context, arg CaptureAccountHoldParams) (AccountHold, error)
+	CountAccounts(ctx context.Context) (int64, error)
+	CountJournalTransfers(ctx context.Context) (int64, error)
+	CountTransfersWithoutJournal(ctx context.Context) (int64, error)
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
+	CreateAccountHold(ctx context.Context, arg CreateAccountHoldParams) (AccountHold, error)
+	CreateAccountHolder(ctx context.Context, arg CreateAccountHolderParams) (AccountHolder, error)
+	CreateAccountStatusHistory(ctx context.Context, arg CreateAccountStatusHistoryParams) (AccountStatusHistory, error)
+	CreateBalanceSnapshot(ctx context.Context, arg CreateBalanceSnapshotParams) (BalanceSnapshot, error)
 	CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error)
+	CreateInterestAccrual(ctx context.Context, arg CreateInterestAccrualParams) (InterestAccrual, error)
+	CreateInterestPosting(ctx context.Context, arg CreateInterestPostingParams) (InterestPosting, error)
+	CreateJournalTransaction(ctx context.Context, arg CreateJournalTransactionParams) (JournalTransaction, error)
+	CreateMaintenanceFeeCharge(ctx context.Context, arg CreateMaintenanceFeeChargeParams) (MaintenanceFeeCharge, error)
+	CreatePayee(ctx context.Context, arg CreatePayeeParams) (Payee, error)
+	CreatePaymentInitiation(ctx context.Context, arg CreatePaymentInitiationParams) (PaymentInitiation, error)
+	CreatePaymentInstruction(ctx context.Context, arg CreatePaymentInstructionParams) (PaymentInstruction, error)
+	CreatePaymentRequest(ctx context.Context, arg CreatePaymentRequestParams) (PaymentRequest, error)
+	CreateReconciliationRun(ctx context.Context, arg CreateReconciliationRunParams) (ReconciliationRun, error)
+	CreateStandingOrder(ctx context.Context, arg CreateStandingOrderParams) (StandingOrder, error)
+	CreateStatement(ctx context.Context, arg CreateStatementParams) (Statement, error)
+	CreateStatusHistory(ctx context.Context, arg CreateStatusHistoryParams) (StatusHistory, error)
 	CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error)
+	CreateTransferApproval(ctx context.Context, arg CreateTransferApprovalParams) (TransferApproval, error)
+	CreateTransferBatch(ctx context.Context, arg CreateTransferBatchParams) (TransferBatch, error)
+	CreateTransferBatchItem(ctx context.Context, arg CreateTransferBatchItemParams) (TransferBatchItem, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
-	DeleteAccount(ctx context.Context, id int64) error
+	DeleteAccountHolder(ctx context.Context, id int64) error
+	DeleteAccountLimit(ctx context.Context, accountID int64) error
+	DeleteFeeSchedule(ctx context.Context, id int64) (FeeSchedule, error)
+	DeletePayee(ctx context.Context, id int64) error
+	DeletePaymentInstruction(ctx context.Context, id int64) error
+	ExpireAccountHolds(ctx context.Context, expiresAt time.Time) ([]AccountHold, error)
+	ExpirePaymentRequests(ctx context.Context, expiresAt time.Time) ([]PaymentRequest, error)
 	GetAccount(ctx context.Context, id int64) (Account, error)
+	GetAccountByOwnerAndCurrency(ctx context.Context, arg GetAccountByOwnerAndCurrencyParams) (Account, error)
 	GetAccountForUpdate(ctx context.Context, id int64) (Account, error)
+	GetAccountHold(ctx context.Context, id int64) (AccountHold, error)
+	GetAccountHoldForUpdate(ctx context.Context, id int64) (AccountHold, error)
+	GetAccountHolder(ctx context.Context, id int64) (AccountHolder, error)
+	GetAccountHolderByUsername(ctx context.Context, arg GetAccountHolderByUsernameParams) (AccountHolder, error)
+	GetAccountLimit(ctx context.Context, accountID int64) (AccountLimit, error)
+	GetAccountProduct(ctx context.Context, code string) (AccountProduct, error)
 	GetEntry(ctx context.Context, id int64) (Entry, error)
+	GetFeeSchedule(ctx context.Context, arg GetFeeScheduleParams) (FeeSchedule, error)
+	GetHeldAmount(ctx context.Context, accountID int64) (int64, error)
+	GetJournalTransaction(ctx context.Context, id int64) (JournalTransaction, error)
+	GetLastAccountEntryHash(ctx context.Context, accountID int64) (string, error)
+	GetLastInterestAccrualDate(ctx context.Context, accountID int64) (time.Time, error)
+	GetLastInterestPosting(ctx context.Context, accountID int64) (InterestPosting, error)
+	GetLastLedgerEntryHash(ctx context.Context, ledgerAccountID int64) (string, error)
+	GetLatestBalanceSnapshot(ctx context.Context, arg GetLatestBalanceSnapshotParams) (BalanceSnapshot, error)
+	GetLedgerAccount(ctx context.Context, arg GetLedgerAccountParams) (LedgerAccount, error)
+	GetLedgerAccountByID(ctx context.Context, id int64) (LedgerAccount, error)
+	GetLedgerAccountForUpdate(ctx context.Context, id int64) (LedgerAccount, error)
+	GetOutgoingTransferTotal(ctx context.Context, arg GetOutgoingTransferTotalParams) (GetOutgoingTransferTotalRow, error)
+	GetPayee(ctx context.Context, id int64) (Payee, error)
+	GetPaymentRequest(ctx context.Context, id int64) (PaymentRequest, error)
+	GetPaymentRequestForUpdate(ctx context.Context, id int64) (PaymentRequest, error)
+	GetRecipientKnownSince(ctx context.Context, arg GetRecipientKnownSinceParams) (sql.NullTime, error)
+	GetReversedAmount(ctx context.Context, transferID int64) (int64, error)
+	GetStandingOrder(ctx context.Context, id int64) (StandingOrder, error)
+	GetStandingOrderForUpdate(ctx context.Context, id int64) (StandingOrder, error)
+	GetStatement(ctx context.Context, arg GetStatementParams) (Statement, error)
 	GetTransfer(ctx context.Context, id int64) (Transfer, error)
+	GetTransferApproval(ctx context.Context, id int64) (TransferApproval, error)
+	GetTransferApprovalForUpdate(ctx context.Context, id int64) (TransferApproval, error)
+	GetTransferBatch(ctx context.Context, id int64) (TransferBatch, error)
+	GetTransferForUpdate(ctx context.Context, id int64) (Transfer, error)
+	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUsers(ctx context.Context, username string) (User, error)
 	ListAccount(ctx context.Context, arg ListAccountParams) ([]Account, error)
+	ListAccountBalanceMismatches(ctx context.Context) ([]ListAccountBalanceMismatchesRow, error)
+	ListAccountEntryChain(ctx context.Context, arg ListAccountEntryChainParams) ([]Entry, error)
+	ListAccountHolders(ctx context.Context, accountID int64) ([]AccountHolder, error)
+	ListAccountIDs(ctx context.Context) ([]int64, error)
+	ListAccountInvitations(ctx context.Context, username string) ([]AccountHolder, error)
+	ListAccountProducts(ctx context.Context) ([]AccountProduct, error)
+	ListAccountStatusHistory(ctx context.Context, accountID int64) ([]AccountStatusHistory, error)
+	ListAccountsByIDs(ctx context.Context, ids []int64) ([]Account, error)
+	ListAccountsWithUnpostedInterest(ctx context.Context, before time.Time) ([]int64, error)
+	ListDailyEntryTotals(ctx context.Context, arg ListDailyEntryTotalsParams) ([]ListDailyEntryTotalsRow, error)
+	ListDueStandingOrders(ctx context.Context, arg ListDueStandingOrdersParams) ([]StandingOrder, error)
 	ListEntries(ctx context.Context, arg ListEntriesParams) ([]Entry, error)
+	ListFeeSchedules(ctx context.Context) ([]FeeSchedule, error)
+	ListIncomingPaymentRequests(ctx context.Context, arg ListIncomingPaymentRequestsParams) ([]PaymentRequest, error)
+	ListInterestBearingAccounts(ctx context.Context) ([]Account, error)
+	ListInterestPostings(ctx context.Context, arg ListInterestPostingsParams) ([]InterestPosting, error)
+	ListJournalEntries(ctx context.Context, journalID int64) ([]Entry, error)
+	ListLedgerAccountBalanceMismatches(ctx context.Context) ([]ListLedgerAccountBalanceMismatchesRow, error)
+	ListLedgerAccounts(ctx context.Context) ([]LedgerAccount, error)
+	ListLedgerEntryChain(ctx context.Context, arg ListLedgerEntryChainParams) ([]Entry, error)
+	ListMaintenanceFeeAccounts(ctx context.Context, createdBefore time.Time) ([]int64, error)
+	ListOutgoingPaymentRequests(ctx context.Context, arg ListOutgoingPaymentRequestsParams) ([]PaymentRequest, error)
+	ListPayees(ctx context.Context, arg ListPayeesParams) ([]Payee, error)
+	ListPendingLedgerAccountIDs(ctx context.Context) ([]int64, error)
+	ListPendingLedgerEntries(ctx context.Context, arg ListPendingLedgerEntriesParams) ([]Entry, error)
+	ListReconciliationRuns(ctx context.Context, arg ListReconciliationRunsParams) ([]ReconciliationRun, error)
+	ListStandingOrders(ctx context.Context, arg ListStandingOrdersParams) ([]StandingOrder, error)
+	ListStatementEntries(ctx context.Context, arg ListStatementEntriesParams) ([]ListStatementEntriesRow, error)
+	ListStatusHistory(ctx context.Context, transferID int64) ([]StatusHistory, error)
+	ListTransferApprovals(ctx context.Context, arg ListTransferApprovalsParams) ([]TransferApproval, error)
+	ListTransferBatchItems(ctx context.Context, batchID int64) ([]TransferBatchItem, error)
+	ListTransferEntryMismatches(ctx context.Context) ([]ListTransferEntryMismatchesRow, error)
+	ListTransferHistory(ctx context.Context, arg ListTransferHistoryParams) ([]ListTransferHistoryRow, error)
 	ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error)
+	MarkInterestAccrualsPosted(ctx context.Context, arg MarkInterestAccrualsPostedParams) error
+	MarkPaymentRequestPaid(ctx context.Context, arg MarkPaymentRequestPaidParams) (PaymentRequest, error)
+	RecordStandingOrderFailure(ctx context.Context, arg RecordStandingOrderFailureParams) (StandingOrder, error)
+	RejectTransferApproval(ctx context.Context, arg RejectTransferApprovalParams) (TransferApproval, error)
+	ResolvePendingPaymentRequest(ctx context.Context, arg ResolvePendingPaymentRequestParams) (PaymentRequest, error)
+	SetEntryHash(ctx context.Context, arg SetEntryHashParams) (Entry, error)
+	SetInterestPostingJournal(ctx context.Context, arg SetInterestPostingJournalParams) (InterestPosting, error)
+	SetLedgerEntryHash(ctx context.Context, arg SetLedgerEntryHashParams) (Entry, error)
+	SetMaintenanceFeeChargeJournal(ctx context.Context, arg SetMaintenanceFeeChargeJournalParams) (MaintenanceFeeCharge, error)
+	SumAccountEntries(ctx context.Context, arg SumAccountEntriesParams) (int64, error)
+	SumUnpostedInterestAccruals(ctx context.Context, arg SumUnpostedInterestAccrualsParams) (int64, error)
 	UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error)
+	UpdateAccountHoldStatus(ctx context.Context, arg UpdateAccountHoldStatusParams) (AccountHold, error)
+	UpdateAccountNickname(ctx context.Context, arg UpdateAccountNicknameParams) (Account, error)
+	UpdateAccountProduct(ctx context.Context, arg UpdateAccountProductParams) (AccountProduct, error)
+	UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (Account, error)
+	UpdatePayee(ctx context.Context, arg UpdatePayeeParams) (Payee, error)
+	UpdateStandingOrderSchedule(ctx context.Context, arg UpdateStandingOrderScheduleParams) (StandingOrder, error)
+	UpdateStandingOrderStatus(ctx context.Context, arg UpdateStandingOrderStatusParams) (StandingOrder, error)
+	UpdateTransferBatchItem(ctx context.Context, arg UpdateTransferBatchItemParams) (TransferBatchItem, error)
+	UpdateTransferBatchResult(ctx context.Context, arg UpdateTransferBatchResultParams) (TransferBatch, error)
+	UpdateTransferStatus(ctx context.Context, arg UpdateTransferStatusParams) (Transfer, error)
+	UpsertAccountLimit(ctx context.Context, arg UpsertAccountLimitParams) (AccountLimit, error)
+	UpsertFeeSchedule(ctx context.Context, arg UpsertFeeScheduleParams) (FeeSchedule, error)
 }
 
 var _ Querier = (*Queries)(nil)
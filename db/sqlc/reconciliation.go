@@ -0,0 +1,50 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Reconciliation lists everything in the ledger that does not agree with itself.
+type Reconciliation struct {
+	AccountsChecked  int64 `json:"accounts_checked"`
+	TransfersChecked int64 `json:"transfers_checked"`
+	// journal이 생기기 전에 만들어진 transfer는 entries와 연결할 수 없어서 검사하지 않는다.
+	LegacyTransfers         int64                                   `json:"legacy_transfers"`
+	AccountMismatches       []ListAccountBalanceMismatchesRow       `json:"account_mismatches"`
+	LedgerAccountMismatches []ListLedgerAccountBalanceMismatchesRow `json:"ledger_account_mismatches"`
+	TransferMismatches      []ListTransferEntryMismatchesRow        `json:"transfer_mismatches"`
+}
+
+// ReconcileTx compares balances with their entries and transfers with their journal entries.
+// 모든 쿼리가 같은 snapshot을 보도록 repeatable read의 read-only 트랜잭션에서 실행한다.
+func (store *SQLStore) ReconcileTx(ctx context.Context) (Reconciliation, error) {
+	var result Reconciliation
+	opts := &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+	err := store.execTx(ctx, opts, func(q *Queries) error {
+		var err error
+		result.AccountsChecked, err = q.CountAccounts(ctx)
+		if err != nil {
+			return err
+		}
+		result.TransfersChecked, err = q.CountJournalTransfers(ctx)
+		if err != nil {
+			return err
+		}
+		result.LegacyTransfers, err = q.CountTransfersWithoutJournal(ctx)
+		if err != nil {
+			return err
+		}
+		result.AccountMismatches, err = q.ListAccountBalanceMismatches(ctx)
+		if err != nil {
+			return err
+		}
+		result.LedgerAccountMismatches, err = q.ListLedgerAccountBalanceMismatches(ctx)
+		if err != nil {
+			return err
+		}
+		result.TransferMismatches, err = q.ListTransferEntryMismatches(ctx)
+		return err
+	})
+	return result, err
+}
@@ -0,0 +1,70 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcileTx(t *testing.T) {
+	store := NewStore(testDB, StoreConfig{})
+
+	// createRandomAccount는 entries 없이 잔액을 넣으므로 entries와 맞지 않는다.
+	mismatched := createRandomAccount(t)
+
+	// journal로만 움직인 계좌는 잔액이 entries와 맞는다.
+	account1 := createRandomAccountWithBalance(t, 0)
+	account2 := createRandomAccountWithBalance(t, 0)
+	_, err := store.DepositTx(context.Background(), CashTxParams{AccountID: account1.ID, Amount: 100})
+	require.NoError(t, err)
+	transfer, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        40,
+	})
+	require.NoError(t, err)
+
+	result, err := store.ReconcileTx(context.Background())
+	require.NoError(t, err)
+	require.NotZero(t, result.AccountsChecked)
+	require.NotZero(t, result.TransfersChecked)
+
+	mismatchedIDs := make(map[int64]bool)
+	for _, mismatch := range result.AccountMismatches {
+		mismatchedIDs[mismatch.ID] = true
+	}
+	require.True(t, mismatchedIDs[mismatched.ID])
+	require.False(t, mismatchedIDs[account1.ID])
+	require.False(t, mismatchedIDs[account2.ID])
+
+	for _, mismatch := range result.TransferMismatches {
+		require.NotEqual(t, transfer.Transfer.ID, mismatch.ID)
+	}
+}
+
+func TestFrozenAccountRejectsTransfers(t *testing.T) {
+	store := NewStore(testDB, StoreConfig{})
+
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccountWithBalance(t, 0)
+
+	frozen, err := store.ChangeAccountStatusTx(context.Background(), ChangeAccountStatusTxParams{
+		AccountID: account2.ID,
+		ToStatus:  AccountStatusFrozen,
+		ChangedBy: "reconciliation",
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountStatusFrozen, frozen.Account.Status)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.ErrorIs(t, err, ErrAccountNotActive)
+
+	account1, err = testQueries.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), account1.Balance)
+}
@@ -0,0 +1,118 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/gyu-young-park/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomStandingOrder(t *testing.T, account1, account2 Account, nextRunAt time.Time) StandingOrder {
+	arg := CreateStandingOrderParams{
+		Owner:         account1.Owner,
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        util.RandomInt(1, 100),
+		Frequency:     util.Monthly,
+		Day:           int32(nextRunAt.Day()),
+		StartDate:     nextRunAt,
+		NextRunAt:     nextRunAt,
+	}
+	order, err := testQueries.CreateStandingOrder(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, order)
+
+	require.Equal(t, arg.Owner, order.Owner)
+	require.Equal(t, arg.FromAccountID, order.FromAccountID)
+	require.Equal(t, arg.ToAccountID, order.ToAccountID)
+	require.Equal(t, arg.Amount, order.Amount)
+	require.Equal(t, arg.Frequency, order.Frequency)
+	require.Equal(t, arg.Day, order.Day)
+	require.WithinDuration(t, arg.NextRunAt, order.NextRunAt, time.Second)
+	require.Equal(t, StandingOrderStatusActive, order.Status)
+	require.Zero(t, order.Occurrences)
+	require.Zero(t, order.FailedAttempts)
+
+	require.NotZero(t, order.ID)
+	require.NotZero(t, order.CreatedAt)
+	return order
+}
+
+func TestCreateStandingOrder(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+	createRandomStandingOrder(t, account1, account2, time.Now())
+}
+
+func TestRecordStandingOrderFailure(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+	order1 := createRandomStandingOrder(t, account1, account2, time.Now())
+
+	attemptedAt := time.Now()
+	order2, err := testQueries.RecordStandingOrderFailure(context.Background(), RecordStandingOrderFailureParams{
+		ID:            order1.ID,
+		LastAttemptAt: sql.NullTime{Time: attemptedAt, Valid: true},
+		LastError:     ErrInsufficientFunds.Error(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, order1.FailedAttempts+1, order2.FailedAttempts)
+	require.Equal(t, ErrInsufficientFunds.Error(), order2.LastError)
+	require.WithinDuration(t, attemptedAt, order2.LastAttemptAt.Time, time.Second)
+}
+
+func TestExecuteStandingOrderTx(t *testing.T) {
+	store := NewStore(testDB, StoreConfig{})
+
+	account1 := createRandomAccountWithBalance(t, 1000)
+	account2 := createRandomAccount(t)
+	order := createRandomStandingOrder(t, account1, account2, time.Now().Add(-time.Minute))
+
+	result, err := store.ExecuteStandingOrderTx(context.Background(), ExecuteStandingOrderTxParams{
+		StandingOrderID: order.ID,
+		Now:             time.Now(),
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, order.Amount, result.Transfer.Transfer.Amount)
+	require.Equal(t, order.ID, result.Transfer.Transfer.StandingOrderID.Int64)
+	require.Equal(t, account1.Balance-order.Amount, result.Transfer.FromAccount.Balance)
+	require.Equal(t, account2.Balance+order.Amount, result.Transfer.ToAccount.Balance)
+
+	require.Equal(t, int32(1), result.StandingOrder.Occurrences)
+	require.True(t, result.StandingOrder.NextRunAt.After(order.NextRunAt))
+
+	// 다음 회차가 오기 전에는 다시 실행되지 않는다.
+	_, err = store.ExecuteStandingOrderTx(context.Background(), ExecuteStandingOrderTxParams{
+		StandingOrderID: order.ID,
+		Now:             time.Now(),
+	})
+	require.ErrorIs(t, err, ErrStandingOrderNotDue)
+}
+
+func TestNextStandingOrderSchedule(t *testing.T) {
+	nextRunAt := time.Date(2022, time.January, 31, 9, 0, 0, 0, time.UTC)
+	order := StandingOrder{
+		ID:             1,
+		Frequency:      util.Monthly,
+		Day:            31,
+		NextRunAt:      nextRunAt,
+		Occurrences:    1,
+		MaxOccurrences: sql.NullInt32{Int32: 3, Valid: true},
+	}
+
+	arg := NextStandingOrderSchedule(order)
+	require.Equal(t, time.Date(2022, time.February, 28, 9, 0, 0, 0, time.UTC), arg.NextRunAt)
+	require.Equal(t, int32(2), arg.Occurrences)
+	require.Equal(t, StandingOrderStatusActive, arg.Status)
+
+	order.Occurrences = 2
+	require.Equal(t, StandingOrderStatusCompleted, NextStandingOrderSchedule(order).Status)
+
+	order.Occurrences = 0
+	order.EndDate = sql.NullTime{Time: nextRunAt.AddDate(0, 0, 7), Valid: true}
+	require.Equal(t, StandingOrderStatusCompleted, NextStandingOrderSchedule(order).Status)
+}
@@ -0,0 +1,39 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type AccountStatementTxParams struct {
+	AccountID int64     `json:"account_id"`
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+}
+
+// AccountStatementTxResult is the raw material of a statement: the balance at From and every entry in [From, To).
+type AccountStatementTxResult struct {
+	OpeningBalance int64                     `json:"opening_balance"`
+	Entries        []ListStatementEntriesRow `json:"entries"`
+}
+
+// AccountStatementTx reads the opening balance and the entries of a statement period from the same snapshot.
+func (store *SQLStore) AccountStatementTx(ctx context.Context, arg AccountStatementTxParams) (AccountStatementTxResult, error) {
+	var result AccountStatementTxResult
+	opts := &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+	err := store.execTx(ctx, opts, func(q *Queries) error {
+		var err error
+		result.OpeningBalance, err = balanceBefore(ctx, q, arg.AccountID, arg.From)
+		if err != nil {
+			return err
+		}
+		result.Entries, err = q.ListStatementEntries(ctx, ListStatementEntriesParams{
+			AccountID: arg.AccountID,
+			FromTime:  arg.From,
+			ToTime:    arg.To,
+		})
+		return err
+	})
+	return result, err
+}
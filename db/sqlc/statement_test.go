@@ -0,0 +1,41 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountStatementTx(t *testing.T) {
+	store := NewStore(testDB, StoreConfig{})
+	account1 := createRandomAccountWithBalance(t, 0)
+	account2 := createRandomAccountWithBalance(t, 0)
+
+	_, err := store.DepositTx(context.Background(), CashTxParams{AccountID: account1.ID, Amount: 100})
+	require.NoError(t, err)
+	from := time.Now()
+	transfer, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        40,
+	})
+	require.NoError(t, err)
+
+	result, err := store.AccountStatementTx(context.Background(), AccountStatementTxParams{
+		AccountID: account1.ID,
+		From:      from,
+		To:        time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(100), result.OpeningBalance)
+	require.Len(t, result.Entries, 1)
+
+	entry := result.Entries[0]
+	require.Equal(t, int64(-40), entry.Amount)
+	require.Equal(t, JournalKindTransfer, entry.JournalKind.String)
+	require.Equal(t, transfer.Transfer.ID, entry.TransferID.Int64)
+	require.Equal(t, account2.ID, entry.CounterpartyAccountID.Int64)
+	require.NotEmpty(t, entry.CounterpartyName.String)
+}
@@ -3,30 +3,106 @@ package db
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/gyu-young-park/simplebank/util"
 )
 
+// 보내는 계좌의 잔액이 음수가 되는 이체는 거절한다.
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+// 이체 한도를 넘는 이체는 거절한다.
+var ErrTransferLimitExceeded = errors.New("transfer limit exceeded")
+
+// 승인 기준 금액을 넘는 이체는 banker가 승인한 transfer approval로만 실행할 수 있다.
+var ErrApprovalRequired = errors.New("transfer requires banker approval")
+
 type Store interface {
 	Querier
 	TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error)
+	ExecuteStandingOrderTx(ctx context.Context, arg ExecuteStandingOrderTxParams) (ExecuteStandingOrderTxResult, error)
+	ReverseTransferTx(ctx context.Context, arg ReverseTransferTxParams) (ReverseTransferTxResult, error)
+	AuthorizeHoldTx(ctx context.Context, arg AuthorizeHoldTxParams) (AccountHold, error)
+	CaptureHoldTx(ctx context.Context, arg CaptureHoldTxParams) (CaptureHoldTxResult, error)
+	VoidHoldTx(ctx context.Context, holdID int64) (AccountHold, error)
+	BatchTransferTx(ctx context.Context, arg BatchTransferTxParams) (BatchTransferTxResult, error)
+	PayPaymentRequestTx(ctx context.Context, arg PayPaymentRequestTxParams) (PayPaymentRequestTxResult, error)
+	ApproveTransferTx(ctx context.Context, arg ApproveTransferTxParams) (ApproveTransferTxResult, error)
+	DepositTx(ctx context.Context, arg CashTxParams) (CashTxResult, error)
+	WithdrawTx(ctx context.Context, arg CashTxParams) (CashTxResult, error)
+	ReconcileTx(ctx context.Context) (Reconciliation, error)
+	VerifyEntryChain(ctx context.Context, arg VerifyEntryChainParams) (EntryChainVerification, error)
+	PostLedgerEntriesTx(ctx context.Context, ledgerAccountID int64) (PostLedgerEntriesTxResult, error)
+	AccountBalanceAt(ctx context.Context, arg AccountBalanceAtParams) (int64, error)
+	AccountBalanceHistory(ctx context.Context, arg AccountBalanceHistoryParams) ([]BalancePoint, error)
+	SnapshotBalance(ctx context.Context, arg SnapshotBalanceParams) (BalanceSnapshot, error)
+	AccountStatementTx(ctx context.Context, arg AccountStatementTxParams) (AccountStatementTxResult, error)
+	ChargeMaintenanceFeeTx(ctx context.Context, arg ChargeMaintenanceFeeTxParams) (ChargeMaintenanceFeeTxResult, error)
+	AccrueInterestTx(ctx context.Context, arg AccrueInterestTxParams) (InterestAccrual, error)
+	PostInterestTx(ctx context.Context, arg PostInterestTxParams) (PostInterestTxResult, error)
+	CreateAccountTx(ctx context.Context, arg CreateAccountParams) (Account, error)
+	ChangeAccountStatusTx(ctx context.Context, arg ChangeAccountStatusTxParams) (ChangeAccountStatusTxResult, error)
+	GetTransferLimit(ctx context.Context, account Account) (util.TransferLimit, error)
+	TxStats() TxStats
+}
+
+type StoreConfig struct {
+	// 통화별 기본 이체 한도. account_limits에 계좌별 한도가 없으면 이 값을 쓴다.
+	TransferLimits map[string]util.TransferLimit
+	// 통화별로 이 금액을 넘는 이체는 ApproveTransferTx로만 실행된다. 비어 있으면 승인 없이 실행한다.
+	ApprovalThresholds map[string]int64
+	// deadlock이나 serialization failure가 난 트랜잭션을 다시 시도하는 횟수. 0이면 다시 시도하지 않는다.
+	TxMaxRetries int
+	// 첫 재시도 전에 기다리는 시간. 재시도할 때마다 두 배가 되고 jitter가 더해진다.
+	TxRetryBackoff time.Duration
 }
 
 // store는 쿼리와 트랜잭션 실행에 필요한 모든 함수를 제공한다.
 type SQLStore struct {
 	*Queries
-	db *sql.DB
+	db     *sql.DB
+	config StoreConfig
+	// atomic으로 갱신한다.
+	txRetries   uint64
+	txExhausted uint64
 }
 
-func NewStore(db *sql.DB) Store {
+func NewStore(db *sql.DB, config StoreConfig) Store {
 	return &SQLStore{
 		db:      db,
 		Queries: New(db),
+		config:  config,
 	}
 }
 
 // callback 함수에서 실행하도록 한다.
-func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) error {
-	tx, err := store.db.BeginTx(ctx, nil)
+// deadlock이나 serialization failure로 중단된 트랜잭션은 fn을 처음부터 다시 실행하므로, fn은 여러 번 불려도 안전해야 한다.
+func (store *SQLStore) execTx(ctx context.Context, opts *sql.TxOptions, fn func(*Queries) error) error {
+	for attempt := 0; ; attempt++ {
+		err := store.runTx(ctx, opts, fn)
+		if err == nil || !isRetryable(err) {
+			return err
+		}
+		if attempt >= store.config.TxMaxRetries {
+			atomic.AddUint64(&store.txExhausted, 1)
+			return fmt.Errorf("%w after %d attempts: %v", ErrTxConflict, attempt+1, err)
+		}
+
+		atomic.AddUint64(&store.txRetries, 1)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(retryDelay(store.config.TxRetryBackoff, attempt)):
+		}
+	}
+}
+
+func (store *SQLStore) runTx(ctx context.Context, opts *sql.TxOptions, fn func(*Queries) error) error {
+	tx, err := store.db.BeginTx(ctx, opts)
 	if err != nil {
 		return err
 	}
@@ -35,7 +111,7 @@ func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) erro
 	err = fn(q)
 	if err != nil {
 		if rbErr := tx.Rollback(); rbErr != nil {
-			return fmt.Errorf("tx err: %v, rb error : %v", err, rbErr)
+			return fmt.Errorf("tx err: %w, rb error : %v", err, rbErr)
 		}
 		return err
 	}
@@ -43,93 +119,190 @@ func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) erro
 }
 
 type TransferTxParams struct {
-	FromAccountID int64 `json:"from_account_id"`
-	ToAccountID   int64 `json:"to_account_id"`
-	Amount        int64 `json:"amount"`
+	FromAccountID   int64         `json:"from_account_id"`
+	ToAccountID     int64         `json:"to_account_id"`
+	Amount          int64         `json:"amount"`
+	StandingOrderID sql.NullInt64 `json:"standing_order_id"`
+	ReversalOf      sql.NullInt64 `json:"reversal_of"`
+	HoldID          sql.NullInt64 `json:"hold_id"`
+	// 받는 사람에게 보여줄 송금 정보. entries에도 복사되어 거래내역에 표시된다.
+	Description string          `json:"description"`
+	Reference   string          `json:"reference"`
+	Metadata    json.RawMessage `json:"metadata"`
+	// banker가 승인한 이체인지. ApproveTransferTx만 설정할 수 있다.
+	approved bool
 }
 
 type TransferTxResult struct {
-	Transfer    Transfer `json:"transfer"`
-	FromAccount Account  `json:"from_account"`
-	ToAccount   Account  `json:"to_account"`
-	FromEntry   Entry    `json:"from_entry"`
-	ToEntry     Entry    `json:"to_entry"`
+	Transfer    Transfer           `json:"transfer"`
+	Journal     JournalTransaction `json:"journal"`
+	FromAccount Account            `json:"from_account"`
+	ToAccount   Account            `json:"to_account"`
+	FromEntry   Entry              `json:"from_entry"`
+	ToEntry     Entry              `json:"to_entry"`
+	// 보내는 계좌에서 따로 빠져나간 수수료. FromAccount는 수수료까지 빠진 잔액이다.
+	Fees []FeeLine `json:"fees"`
 }
 
 // 돈을 보낼 때에는 transfer을 하고, from ,to에게 돈을 보낸 entry 기록, 그리고 계정을 업데이트해줘야 한다.
 func (store *SQLStore) TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error) {
 	var result TransferTxResult
-	err := store.execTx(ctx, func(q *Queries) error {
-		//query
+	err := store.execTx(ctx, nil, func(q *Queries) error {
 		var err error
+		result, err = store.transfer(ctx, q, arg)
+		return err
+	})
+	return result, err
+}
 
-		result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
-			FromAccountID: arg.FromAccountID,
-			ToAccountID:   arg.ToAccountID,
-			Amount:        arg.Amount,
-		})
+// transfer는 TransferTx의 본문이다. standing order처럼 다른 트랜잭션 안에서 이체를 해야 하는 경우에도 사용한다.
+func (store *SQLStore) transfer(ctx context.Context, q *Queries, arg TransferTxParams) (TransferTxResult, error) {
+	result, err := moveMoney(ctx, q, arg)
+	if err != nil {
+		return result, err
+	}
 
-		if err != nil {
-			return err
+	// batch, payment request, standing order, hold capture 등 어느 경로로 들어온 이체든 여기서 승인 기준 금액을 검사한다.
+	// 되돌리는 이체는 이미 실행된 이체의 돈을 돌려보내는 것이므로 승인이 필요 없다.
+	if !arg.approved && !arg.ReversalOf.Valid {
+		if err := store.checkApprovalThreshold(result.FromAccount.Currency, arg.Amount); err != nil {
+			return result, err
 		}
+	}
 
-		result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.FromAccountID,
-			Amount:    -arg.Amount,
-		})
-
+	// 되돌리는 이체에는 수수료를 받지 않는다.
+	if !arg.ReversalOf.Valid {
+		fee, err := accountFee(ctx, q, result.FromAccount, FeeOperationTransfer, arg.Amount)
 		if err != nil {
-			return err
+			return result, err
+		}
+		if fee > 0 {
+			transferID := sql.NullInt64{Int64: result.Transfer.ID, Valid: true}
+			line, account, err := postFee(ctx, q, result.FromAccount, FeeOperationTransfer, fee, transferID)
+			if err != nil {
+				return result, err
+			}
+			result.Fees = append(result.Fees, line)
+			result.FromAccount = account
 		}
+	}
 
-		result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.ToAccountID,
-			Amount:    arg.Amount,
-		})
+	// 잔액 갱신 시 계좌에 lock이 걸리므로, 갱신된 잔액으로 검사해야 동시에 들어온 이체도 막을 수 있다.
+	// hold로 잡혀있는 금액은 쓸 수 없다.
+	held, err := q.GetHeldAmount(ctx, arg.FromAccountID)
+	if err != nil {
+		return result, err
+	}
+	if result.FromAccount.Balance-held < 0 {
+		return result, ErrInsufficientFunds
+	}
 
-		if err != nil {
-			return err
-		}
+	err = store.checkTransferLimit(ctx, q, result.FromAccount, arg.Amount)
+	return result, err
+}
 
-		if arg.FromAccountID < arg.ToAccountID {
-			result.FromAccount, result.ToAccount, err = addMoney(ctx, q, arg.FromAccountID, arg.ToAccountID, -arg.Amount, arg.Amount)
-		} else {
-			result.ToAccount, result.FromAccount, err = addMoney(ctx, q, arg.ToAccountID, arg.FromAccountID, arg.Amount, -arg.Amount)
-		}
+// GetTransferLimit returns the limits that apply to transfers out of the account.
+func (store *SQLStore) GetTransferLimit(ctx context.Context, account Account) (util.TransferLimit, error) {
+	return store.transferLimit(ctx, store.Queries, account)
+}
 
-		if err != nil {
-			return err
-		}
+func (store *SQLStore) transferLimit(ctx context.Context, q *Queries, account Account) (util.TransferLimit, error) {
+	limit, err := q.GetAccountLimit(ctx, account.ID)
+	if err == sql.ErrNoRows {
+		return store.config.TransferLimits[account.Currency], nil
+	}
+	if err != nil {
+		return util.TransferLimit{}, err
+	}
+	return util.TransferLimit{
+		MaxPerTransfer: limit.MaxPerTransfer,
+		MaxDailyAmount: limit.MaxDailyAmount,
+		MaxDailyCount:  limit.MaxDailyCount,
+	}, nil
+}
 
+// 보내는 계좌에 lock이 걸린 뒤에 오늘의 합계를 구하므로, 동시에 들어온 이체들이 일일 한도를 함께 넘을 수 없다.
+// 합계에는 방금 만든 transfer도 포함되어 있다.
+func (store *SQLStore) checkTransferLimit(ctx context.Context, q *Queries, account Account, amount int64) error {
+	limit, err := store.transferLimit(ctx, q, account)
+	if err != nil {
 		return err
+	}
+	if limit.MaxPerTransfer > 0 && amount > limit.MaxPerTransfer {
+		return fmt.Errorf("%w: amount %d is over the per-transfer limit %d", ErrTransferLimitExceeded, amount, limit.MaxPerTransfer)
+	}
+	if limit.MaxDailyAmount == 0 && limit.MaxDailyCount == 0 {
+		return nil
+	}
+
+	today, err := q.GetOutgoingTransferTotal(ctx, GetOutgoingTransferTotalParams{
+		FromAccountID: account.ID,
+		CreatedAt:     time.Now().UTC().Truncate(24 * time.Hour),
 	})
-	return result, err
+	if err != nil {
+		return err
+	}
+	if limit.MaxDailyAmount > 0 && today.TotalAmount > limit.MaxDailyAmount {
+		return fmt.Errorf("%w: daily total %d is over the limit %d", ErrTransferLimitExceeded, today.TotalAmount, limit.MaxDailyAmount)
+	}
+	if limit.MaxDailyCount > 0 && today.TransferCount > int64(limit.MaxDailyCount) {
+		return fmt.Errorf("%w: more than %d transfers today", ErrTransferLimitExceeded, limit.MaxDailyCount)
+	}
+	return nil
 }
 
-func addMoney(
-	ctx context.Context,
-	q *Queries,
-	accountID1 int64,
-	accountID2 int64,
-	amount1 int64,
-	amount2 int64,
-) (account1 Account, account2 Account, err error) {
-	account1, err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
-		Amount: amount1,
-		ID:     accountID1,
+// moveMoney records the transfer and posts it as a journal transaction without checking funds.
+func moveMoney(ctx context.Context, q *Queries, arg TransferTxParams) (TransferTxResult, error) {
+	result := TransferTxResult{Fees: []FeeLine{}}
+	var err error
+
+	metadata := arg.Metadata
+	if len(metadata) == 0 {
+		metadata = json.RawMessage("{}")
+	}
+
+	result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
+		FromAccountID:   arg.FromAccountID,
+		ToAccountID:     arg.ToAccountID,
+		Amount:          arg.Amount,
+		StandingOrderID: arg.StandingOrderID,
+		ReversalOf:      arg.ReversalOf,
+		HoldID:          arg.HoldID,
+		Description:     arg.Description,
+		Reference:       arg.Reference,
+		Metadata:        metadata,
 	})
 
 	if err != nil {
-		return
+		return result, err
 	}
 
-	account2, err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
-		ID:     accountID2,
-		Amount: amount2,
+	_, err = q.CreateStatusHistory(ctx, CreateStatusHistoryParams{
+		TransferID: result.Transfer.ID,
+		ToStatus:   result.Transfer.Status,
 	})
 
 	if err != nil {
-		return
+		return result, err
 	}
-	return
+
+	journal, err := postJournal(ctx, q, JournalParams{
+		Kind:        JournalKindTransfer,
+		TransferID:  sql.NullInt64{Int64: result.Transfer.ID, Valid: true},
+		Description: arg.Description,
+		Reference:   arg.Reference,
+		Metadata:    metadata,
+		Postings: []Posting{
+			{AccountID: arg.FromAccountID, Amount: -arg.Amount},
+			{AccountID: arg.ToAccountID, Amount: arg.Amount},
+		},
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.Journal = journal.Journal
+	result.FromEntry, result.ToEntry = journal.Entries[0], journal.Entries[1]
+	result.FromAccount, result.ToAccount = journal.Accounts[0], journal.Accounts[1]
+	return result, nil
 }
@@ -2,22 +2,25 @@ package db
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 
+	"github.com/gyu-young-park/simplebank/util"
 	"github.com/stretchr/testify/require"
 )
 
 func TestTransferTx(t *testing.T) {
-	store := NewStore(testDB)
-
-	account1 := createRandomAccount(t)
-	account2 := createRandomAccount(t)
-	fmt.Println(">> before:", account1.Balance, account2.Balance)
+	store := NewStore(testDB, StoreConfig{})
 
 	n := 5
 	amount := int64(10)
 
+	// 잔액이 부족하면 이체가 거절되므로 보내는 계좌에 충분한 돈을 넣어둔다.
+	account1 := createRandomAccountWithBalance(t, int64(n)*amount+util.RandomMoney())
+	account2 := createRandomAccount(t)
+	fmt.Println(">> before:", account1.Balance, account2.Balance)
+
 	errs := make(chan error)
 	results := make(chan TransferTxResult)
 
@@ -55,7 +58,7 @@ func TestTransferTx(t *testing.T) {
 
 		fromEntry := result.FromEntry
 		require.NotEmpty(t, fromEntry)
-		require.Equal(t, account1.ID, fromEntry.AccountID)
+		require.Equal(t, account1.ID, fromEntry.AccountID.Int64)
 		require.Equal(t, -amount, fromEntry.Amount)
 		require.NotZero(t, fromEntry.ID)
 		require.NotZero(t, fromEntry.CreatedAt)
@@ -65,7 +68,7 @@ func TestTransferTx(t *testing.T) {
 
 		toEntry := result.ToEntry
 		require.NotEmpty(t, toEntry)
-		require.Equal(t, account2.ID, toEntry.AccountID)
+		require.Equal(t, account2.ID, toEntry.AccountID.Int64)
 		require.Equal(t, amount, toEntry.Amount)
 		require.NotZero(t, toEntry.ID)
 		require.NotZero(t, toEntry.CreatedAt)
@@ -110,3 +113,63 @@ func TestTransferTx(t *testing.T) {
 	require.Equal(t, account1.Balance-int64(n)*amount, updatedAccount1.Balance)
 	require.Equal(t, account2.Balance+int64(n)*amount, updatedAccount2.Balance)
 }
+
+func TestTransferTxInsufficientFunds(t *testing.T) {
+	store := NewStore(testDB, StoreConfig{})
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	_, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        account1.Balance + 1,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	// 트랜잭션이 롤백되었으므로 잔액은 그대로여야 한다.
+	updatedAccount1, err := store.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, updatedAccount1.Balance)
+
+	updatedAccount2, err := store.GetAccount(context.Background(), account2.ID)
+	require.NoError(t, err)
+	require.Equal(t, account2.Balance, updatedAccount2.Balance)
+}
+
+func TestTransferTxRemittanceInfo(t *testing.T) {
+	store := NewStore(testDB, StoreConfig{})
+
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccountWithBalance(t, 0)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+		Description:   "March rent",
+		Reference:     "INV-2022-03",
+		Metadata:      json.RawMessage(`{"unit": "301"}`),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "March rent", result.Transfer.Description)
+	require.Equal(t, "INV-2022-03", result.Transfer.Reference)
+	require.JSONEq(t, `{"unit": "301"}`, string(result.Transfer.Metadata))
+
+	// entries에도 복사되어야 거래내역에서 볼 수 있다.
+	for _, entry := range []Entry{result.FromEntry, result.ToEntry} {
+		require.Equal(t, "March rent", entry.Description)
+		require.Equal(t, "INV-2022-03", entry.Reference)
+		require.JSONEq(t, `{"unit": "301"}`, string(entry.Metadata))
+	}
+
+	// metadata를 주지 않으면 빈 object가 저장된다.
+	result, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+	require.JSONEq(t, `{}`, string(result.Transfer.Metadata))
+	require.JSONEq(t, `{}`, string(result.FromEntry.Metadata))
+}
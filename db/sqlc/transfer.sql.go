@@ -5,26 +5,88 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
 )
 
+const createStatusHistory = `-- name: CreateStatusHistory :one
+INSERT INTO status_history (
+  transfer_id,
+  from_status,
+  to_status,
+  reason
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING id, transfer_id, from_status, to_status, reason, created_at
+`
+
+type CreateStatusHistoryParams struct {
+	TransferID int64          `json:"transfer_id"`
+	FromStatus sql.NullString `json:"from_status"`
+	ToStatus   string         `json:"to_status"`
+	Reason     string         `json:"reason"`
+}
+
+func (q *Queries) CreateStatusHistory(ctx context.Context, arg CreateStatusHistoryParams) (StatusHistory, error) {
+	row := q.db.QueryRowContext(ctx, createStatusHistory,
+		arg.TransferID,
+		arg.FromStatus,
+		arg.ToStatus,
+		arg.Reason,
+	)
+	var i StatusHistory
+	err := row.Scan(
+		&i.ID,
+		&i.TransferID,
+		&i.FromStatus,
+		&i.ToStatus,
+		&i.Reason,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const createTransfer = `-- name: CreateTransfer :one
 INSERT INTO transfers (
   from_account_id,
   to_account_id,
-  amount
+  amount,
+  standing_order_id,
+  reversal_of,
+  hold_id,
+  description,
+  reference,
+  metadata
 ) VALUES (
-  $1, $2, $3
-) RETURNING id, from_account_id, to_account_id, amount, created_at
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING id, from_account_id, to_account_id, amount, created_at, standing_order_id, reversal_of, hold_id, description, reference, metadata, status
 `
 
 type CreateTransferParams struct {
-	FromAccountID int64 `json:"from_account_id"`
-	ToAccountID   int64 `json:"to_account_id"`
-	Amount        int64 `json:"amount"`
+	FromAccountID   int64           `json:"from_account_id"`
+	ToAccountID     int64           `json:"to_account_id"`
+	Amount          int64           `json:"amount"`
+	StandingOrderID sql.NullInt64   `json:"standing_order_id"`
+	ReversalOf      sql.NullInt64   `json:"reversal_of"`
+	HoldID          sql.NullInt64   `json:"hold_id"`
+	Description     string          `json:"description"`
+	Reference       string          `json:"reference"`
+	Metadata        json.RawMessage `json:"metadata"`
 }
 
 func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error) {
-	row := q.db.QueryRowContext(ctx, createTransfer, arg.FromAccountID, arg.ToAccountID, arg.Amount)
+	row := q.db.QueryRowContext(ctx, createTransfer,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.StandingOrderID,
+		arg.ReversalOf,
+		arg.HoldID,
+		arg.Description,
+		arg.Reference,
+		arg.Metadata,
+	)
 	var i Transfer
 	err := row.Scan(
 		&i.ID,
@@ -32,12 +94,56 @@ func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams)
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.StandingOrderID,
+		&i.ReversalOf,
+		&i.HoldID,
+		&i.Description,
+		&i.Reference,
+		&i.Metadata,
+		&i.Status,
 	)
 	return i, err
 }
 
+const getOutgoingTransferTotal = `-- name: GetOutgoingTransferTotal :one
+SELECT COALESCE(SUM(amount), 0)::bigint AS total_amount, COUNT(*) AS transfer_count FROM transfers
+WHERE from_account_id = $1 AND created_at >= $2 AND status NOT IN ('failed', 'cancelled')
+`
+
+type GetOutgoingTransferTotalParams struct {
+	FromAccountID int64     `json:"from_account_id"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type GetOutgoingTransferTotalRow struct {
+	TotalAmount   int64 `json:"total_amount"`
+	TransferCount int64 `json:"transfer_count"`
+}
+
+func (q *Queries) GetOutgoingTransferTotal(ctx context.Context, arg GetOutgoingTransferTotalParams) (GetOutgoingTransferTotalRow, error) {
+	row := q.db.QueryRowContext(ctx, getOutgoingTransferTotal, arg.FromAccountID, arg.CreatedAt)
+	var i GetOutgoingTransferTotalRow
+	err := row.Scan(
+		&i.TotalAmount,
+		&i.TransferCount,
+	)
+	return i, err
+}
+
+const getReversedAmount = `-- name: GetReversedAmount :one
+SELECT COALESCE(SUM(amount), 0)::bigint AS reversed_amount FROM transfers
+WHERE reversal_of = $1::bigint
+`
+
+func (q *Queries) GetReversedAmount(ctx context.Context, transferID int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getReversedAmount, transferID)
+	var reversedAmount int64
+	err := row.Scan(&reversedAmount)
+	return reversedAmount, err
+}
+
 const getTransfer = `-- name: GetTransfer :one
-SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
+SELECT id, from_account_id, to_account_id, amount, created_at, standing_order_id, reversal_of, hold_id, description, reference, metadata, status FROM transfers
 WHERE id = $1 LIMIT 1
 `
 
@@ -50,12 +156,171 @@ func (q *Queries) GetTransfer(ctx context.Context, id int64) (Transfer, error) {
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.StandingOrderID,
+		&i.ReversalOf,
+		&i.HoldID,
+		&i.Description,
+		&i.Reference,
+		&i.Metadata,
+		&i.Status,
+	)
+	return i, err
+}
+
+const getTransferForUpdate = `-- name: GetTransferForUpdate :one
+SELECT id, from_account_id, to_account_id, amount, created_at, standing_order_id, reversal_of, hold_id, description, reference, metadata, status FROM transfers
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetTransferForUpdate(ctx context.Context, id int64) (Transfer, error) {
+	row := q.db.QueryRowContext(ctx, getTransferForUpdate, id)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.StandingOrderID,
+		&i.ReversalOf,
+		&i.HoldID,
+		&i.Description,
+		&i.Reference,
+		&i.Metadata,
+		&i.Status,
 	)
 	return i, err
 }
 
+const listStatusHistory = `-- name: ListStatusHistory :many
+SELECT id, transfer_id, from_status, to_status, reason, created_at FROM status_history
+WHERE transfer_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListStatusHistory(ctx context.Context, transferID int64) ([]StatusHistory, error) {
+	rows, err := q.db.QueryContext(ctx, listStatusHistory, transferID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []StatusHistory
+	for rows.Next() {
+		var i StatusHistory
+		if err := rows.Scan(
+			&i.ID,
+			&i.TransferID,
+			&i.FromStatus,
+			&i.ToStatus,
+			&i.Reason,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransferHistory = `-- name: ListTransferHistory :many
+SELECT transfers.id, transfers.from_account_id, transfers.to_account_id, transfers.amount, transfers.created_at, transfers.standing_order_id, transfers.reversal_of, transfers.hold_id, transfers.description, transfers.reference, transfers.metadata, transfers.status, from_accounts.owner AS from_owner, to_accounts.owner AS to_owner
+FROM transfers
+JOIN accounts AS from_accounts ON from_accounts.id = transfers.from_account_id
+JOIN accounts AS to_accounts ON to_accounts.id = transfers.to_account_id
+WHERE
+    (transfers.from_account_id = $1 OR transfers.to_account_id = $1) AND
+    ($2::varchar = '' OR transfers.reference = $2) AND
+    ($3::varchar = '' OR transfers.description ILIKE '%' || $3 || '%') AND
+    transfers.metadata @> $4::jsonb
+ORDER BY transfers.id DESC
+LIMIT $5
+OFFSET $6
+`
+
+type ListTransferHistoryParams struct {
+	AccountID   int64           `json:"account_id"`
+	Reference   string          `json:"reference"`
+	Description string          `json:"description"`
+	Metadata    json.RawMessage `json:"metadata"`
+	Limit       int32           `json:"limit"`
+	Offset      int32           `json:"offset"`
+}
+
+type ListTransferHistoryRow struct {
+	ID            int64 `json:"id"`
+	FromAccountID int64 `json:"from_account_id"`
+	ToAccountID   int64 `json:"to_account_id"`
+	// negative or positive
+	Amount          int64         `json:"amount"`
+	CreatedAt       time.Time     `json:"created_at"`
+	StandingOrderID sql.NullInt64 `json:"standing_order_id"`
+	// original transfer when this transfer is a reversal or refund
+	ReversalOf  sql.NullInt64 `json:"reversal_of"`
+	HoldID      sql.NullInt64 `json:"hold_id"`
+	Description string        `json:"description"`
+	// remittance reference such as an invoice number
+	Reference string          `json:"reference"`
+	Metadata  json.RawMessage `json:"metadata"`
+	// pending -> completed | failed | cancelled, completed -> reversed
+	Status    string `json:"status"`
+	FromOwner string `json:"from_owner"`
+	ToOwner   string `json:"to_owner"`
+}
+
+func (q *Queries) ListTransferHistory(ctx context.Context, arg ListTransferHistoryParams) ([]ListTransferHistoryRow, error) {
+	rows, err := q.db.QueryContext(ctx, listTransferHistory,
+		arg.AccountID,
+		arg.Reference,
+		arg.Description,
+		arg.Metadata,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTransferHistoryRow
+	for rows.Next() {
+		var i ListTransferHistoryRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.StandingOrderID,
+			&i.ReversalOf,
+			&i.HoldID,
+			&i.Description,
+			&i.Reference,
+			&i.Metadata,
+			&i.Status,
+			&i.FromOwner,
+			&i.ToOwner,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listTransfers = `-- name: ListTransfers :many
-SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
+SELECT id, from_account_id, to_account_id, amount, created_at, standing_order_id, reversal_of, hold_id, description, reference, metadata, status FROM transfers
 WHERE 
     from_account_id = $1 OR
     to_account_id = $2
@@ -91,6 +356,13 @@ func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([
 			&i.ToAccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.StandingOrderID,
+			&i.ReversalOf,
+			&i.HoldID,
+			&i.Description,
+			&i.Reference,
+			&i.Metadata,
+			&i.Status,
 		); err != nil {
 			return nil, err
 		}
@@ -104,3 +376,36 @@ func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([
 	}
 	return items, nil
 }
+
+const updateTransferStatus = `-- name: UpdateTransferStatus :one
+UPDATE transfers
+SET status = $1
+WHERE id = $2 AND status = $3
+RETURNING id, from_account_id, to_account_id, amount, created_at, standing_order_id, reversal_of, hold_id, description, reference, metadata, status
+`
+
+type UpdateTransferStatusParams struct {
+	ToStatus   string `json:"to_status"`
+	ID         int64  `json:"id"`
+	FromStatus string `json:"from_status"`
+}
+
+func (q *Queries) UpdateTransferStatus(ctx context.Context, arg UpdateTransferStatusParams) (Transfer, error) {
+	row := q.db.QueryRowContext(ctx, updateTransferStatus, arg.ToStatus, arg.ID, arg.FromStatus)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.StandingOrderID,
+		&i.ReversalOf,
+		&i.HoldID,
+		&i.Description,
+		&i.Reference,
+		&i.Metadata,
+		&i.Status,
+	)
+	return i, err
+}
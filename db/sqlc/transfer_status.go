@@ -0,0 +1,61 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+const (
+	TransferStatusPending   = "pending"
+	TransferStatusCompleted = "completed"
+	TransferStatusFailed    = "failed"
+	TransferStatusReversed  = "reversed"
+	TransferStatusCancelled = "cancelled"
+)
+
+var ErrInvalidTransferTransition = errors.New("invalid transfer status transition")
+
+// transferTransitions는 각 상태에서 갈 수 있는 다음 상태이다. failed, reversed, cancelled는 끝 상태이다.
+var transferTransitions = map[string][]string{
+	TransferStatusPending:   {TransferStatusCompleted, TransferStatusFailed, TransferStatusCancelled},
+	TransferStatusCompleted: {TransferStatusReversed},
+}
+
+// CanTransitionTransfer reports whether a transfer may move from one status to another.
+func CanTransitionTransfer(from string, to string) bool {
+	for _, next := range transferTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// transitionTransfer moves a transfer to a new status and records it in the status history.
+// 현재 상태를 조건으로 갱신하므로 그 사이에 상태가 바뀌었다면 실패한다.
+func transitionTransfer(ctx context.Context, q *Queries, transfer Transfer, to string, reason string) (Transfer, error) {
+	if !CanTransitionTransfer(transfer.Status, to) {
+		return transfer, fmt.Errorf("%w: %s to %s", ErrInvalidTransferTransition, transfer.Status, to)
+	}
+	updated, err := q.UpdateTransferStatus(ctx, UpdateTransferStatusParams{
+		ID:         transfer.ID,
+		FromStatus: transfer.Status,
+		ToStatus:   to,
+	})
+	if err == sql.ErrNoRows {
+		return transfer, fmt.Errorf("%w: transfer %d is no longer %s", ErrInvalidTransferTransition, transfer.ID, transfer.Status)
+	}
+	if err != nil {
+		return transfer, err
+	}
+
+	_, err = q.CreateStatusHistory(ctx, CreateStatusHistoryParams{
+		TransferID: transfer.ID,
+		FromStatus: sql.NullString{String: transfer.Status, Valid: true},
+		ToStatus:   to,
+		Reason:     reason,
+	})
+	return updated, err
+}
@@ -0,0 +1,44 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanTransitionTransfer(t *testing.T) {
+	require.True(t, CanTransitionTransfer(TransferStatusPending, TransferStatusCompleted))
+	require.True(t, CanTransitionTransfer(TransferStatusPending, TransferStatusFailed))
+	require.True(t, CanTransitionTransfer(TransferStatusPending, TransferStatusCancelled))
+	require.True(t, CanTransitionTransfer(TransferStatusCompleted, TransferStatusReversed))
+
+	require.False(t, CanTransitionTransfer(TransferStatusCompleted, TransferStatusPending))
+	require.False(t, CanTransitionTransfer(TransferStatusCompleted, TransferStatusCancelled))
+	require.False(t, CanTransitionTransfer(TransferStatusFailed, TransferStatusCompleted))
+	require.False(t, CanTransitionTransfer(TransferStatusReversed, TransferStatusCompleted))
+	require.False(t, CanTransitionTransfer(TransferStatusCancelled, TransferStatusPending))
+}
+
+func TestTransitionTransfer(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+	transfer := createRandomTransfer(t, account1, account2)
+	require.Equal(t, TransferStatusCompleted, transfer.Status)
+
+	_, err := transitionTransfer(context.Background(), testQueries, transfer, TransferStatusCancelled, "")
+	require.ErrorIs(t, err, ErrInvalidTransferTransition)
+
+	reversed, err := transitionTransfer(context.Background(), testQueries, transfer, TransferStatusReversed, "mistake")
+	require.NoError(t, err)
+	require.Equal(t, TransferStatusReversed, reversed.Status)
+
+	// 오래된 상태를 기준으로 다시 바꾸려 하면 실패한다.
+	_, err = transitionTransfer(context.Background(), testQueries, transfer, TransferStatusReversed, "mistake")
+	require.ErrorIs(t, err, ErrInvalidTransferTransition)
+
+	history, err := testQueries.ListStatusHistory(context.Background(), transfer.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	require.Equal(t, "mistake", history[0].Reason)
+}
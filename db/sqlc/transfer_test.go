@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -14,6 +15,7 @@ func createRandomTransfer(t *testing.T, account1, account2 Account) Transfer {
 		FromAccountID: account1.ID,
 		ToAccountID:   account2.ID,
 		Amount:        util.RandomMoney(),
+		Metadata:      json.RawMessage("{}"),
 	}
 
 	transfer, err := testQueries.CreateTransfer(context.Background(), arg)
@@ -78,3 +80,55 @@ func TestListTransfer(t *testing.T) {
 		require.True(t, transfer.FromAccountID == account1.ID || transfer.ToAccountID == account1.ID)
 	}
 }
+
+func TestListTransferHistory(t *testing.T) {
+	store := NewStore(testDB, StoreConfig{})
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccountWithBalance(t, 0)
+
+	reference := util.RandomString(10)
+	for i := 0; i < 3; i++ {
+		_, err := store.TransferTx(context.Background(), TransferTxParams{
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        10,
+			Description:   "Invoice payment",
+			Reference:     reference,
+			Metadata:      json.RawMessage(`{"invoice": "A-1"}`),
+		})
+		require.NoError(t, err)
+	}
+	_, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	arg := ListTransferHistoryParams{
+		AccountID: account2.ID,
+		Metadata:  json.RawMessage("{}"),
+		Limit:     10,
+	}
+	transfers, err := testQueries.ListTransferHistory(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, transfers, 4)
+
+	arg.Reference = reference
+	transfers, err = testQueries.ListTransferHistory(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, transfers, 3)
+
+	arg.Reference = ""
+	arg.Description = "invoice"
+	arg.Metadata = json.RawMessage(`{"invoice": "A-1"}`)
+	transfers, err = testQueries.ListTransferHistory(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, transfers, 3)
+	for _, transfer := range transfers {
+		require.Equal(t, "Invoice payment", transfer.Description)
+		require.Equal(t, reference, transfer.Reference)
+		require.Equal(t, account1.Owner, transfer.FromOwner)
+		require.Equal(t, account2.Owner, transfer.ToOwner)
+	}
+}
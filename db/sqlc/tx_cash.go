@@ -0,0 +1,65 @@
+package db
+
+import (
+	"context"
+)
+
+type CashTxParams struct {
+	AccountID   int64  `json:"account_id"`
+	Amount      int64  `json:"amount"`
+	Description string `json:"description"`
+}
+
+type CashTxResult struct {
+	Journal JournalTransaction `json:"journal"`
+	Account Account            `json:"account"`
+	Entry   Entry              `json:"entry"`
+}
+
+// DepositTx credits cash paid in at the bank to an account. 상대 posting은 해당 통화의 cash 계정에 기록된다.
+func (store *SQLStore) DepositTx(ctx context.Context, arg CashTxParams) (CashTxResult, error) {
+	return store.cashTx(ctx, JournalKindDeposit, arg.AccountID, arg.Amount, arg.Description)
+}
+
+// WithdrawTx pays cash out of an account. hold로 잡힌 금액은 찾을 수 없다.
+func (store *SQLStore) WithdrawTx(ctx context.Context, arg CashTxParams) (CashTxResult, error) {
+	return store.cashTx(ctx, JournalKindWithdrawal, arg.AccountID, -arg.Amount, arg.Description)
+}
+
+func (store *SQLStore) cashTx(ctx context.Context, kind string, accountID int64, amount int64, description string) (CashTxResult, error) {
+	var result CashTxResult
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		account, err := q.GetAccountForUpdate(ctx, accountID)
+		if err != nil {
+			return err
+		}
+
+		journal, err := postJournal(ctx, q, JournalParams{
+			Kind:        kind,
+			Description: description,
+			Postings: []Posting{
+				{AccountID: account.ID, Currency: account.Currency, Amount: amount},
+				{LedgerCode: LedgerAccountCash, Currency: account.Currency, Amount: -amount},
+			},
+		})
+		if err != nil {
+			return err
+		}
+		result.Journal = journal.Journal
+		result.Account = journal.Accounts[0]
+		result.Entry = journal.Entries[0]
+
+		if amount > 0 {
+			return nil
+		}
+		held, err := q.GetHeldAmount(ctx, account.ID)
+		if err != nil {
+			return err
+		}
+		if result.Account.Balance-held < 0 {
+			return ErrInsufficientFunds
+		}
+		return nil
+	})
+	return result, err
+}
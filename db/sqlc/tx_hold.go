@@ -0,0 +1,151 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	HoldStatusActive   = "active"
+	HoldStatusCaptured = "captured"
+	HoldStatusVoided   = "voided"
+	HoldStatusExpired  = "expired"
+)
+
+var (
+	ErrHoldNotActive      = errors.New("hold is no longer active")
+	ErrCaptureExceedsHold = errors.New("capture amount exceeds the held amount")
+)
+
+type AuthorizeHoldTxParams struct {
+	AccountID   int64     `json:"account_id"`
+	ToAccountID int64     `json:"to_account_id"`
+	Amount      int64     `json:"amount"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// AuthorizeHoldTx reserves funds on an account so that they can be captured later.
+// 계좌 row에 lock을 걸고 사용 가능한 잔액을 검사하므로, 동시에 들어온 이체나 다른 hold와 같은 돈을 쓸 수 없다.
+func (store *SQLStore) AuthorizeHoldTx(ctx context.Context, arg AuthorizeHoldTxParams) (AccountHold, error) {
+	var hold AccountHold
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		account, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		// frozen이나 closed 계좌의 돈은 묶어 둘 수 없고, 받는 계좌도 capture할 때 active여야 한다.
+		if account.Status != AccountStatusActive {
+			return fmt.Errorf("%w: account %d is %s", ErrAccountNotActive, account.ID, account.Status)
+		}
+		toAccount, err := q.GetAccount(ctx, arg.ToAccountID)
+		if err != nil {
+			return err
+		}
+		if toAccount.Status != AccountStatusActive {
+			return fmt.Errorf("%w: account %d is %s", ErrAccountNotActive, toAccount.ID, toAccount.Status)
+		}
+		// capture할 때 승인이 필요해서 실패할 hold는 처음부터 잡지 않는다.
+		if err := store.checkApprovalThreshold(account.Currency, arg.Amount); err != nil {
+			return err
+		}
+
+		held, err := q.GetHeldAmount(ctx, account.ID)
+		if err != nil {
+			return err
+		}
+		if account.Balance-held < arg.Amount {
+			return ErrInsufficientFunds
+		}
+
+		hold, err = q.CreateAccountHold(ctx, CreateAccountHoldParams{
+			AccountID:   arg.AccountID,
+			ToAccountID: arg.ToAccountID,
+			Amount:      arg.Amount,
+			ExpiresAt:   arg.ExpiresAt,
+		})
+		return err
+	})
+	return hold, err
+}
+
+type CaptureHoldTxParams struct {
+	HoldID int64 `json:"hold_id"`
+	// 0이면 hold 금액 전부를 capture한다.
+	Amount int64 `json:"amount"`
+}
+
+type CaptureHoldTxResult struct {
+	Hold AccountHold `json:"hold"`
+	TransferTxResult
+}
+
+// CaptureHoldTx settles all or part of a hold as a transfer to the hold's recipient and releases the rest.
+func (store *SQLStore) CaptureHoldTx(ctx context.Context, arg CaptureHoldTxParams) (CaptureHoldTxResult, error) {
+	var result CaptureHoldTxResult
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		hold, err := lockActiveHold(ctx, q, arg.HoldID)
+		if err != nil {
+			return err
+		}
+
+		amount := arg.Amount
+		if amount == 0 {
+			amount = hold.Amount
+		}
+		if amount <= 0 || amount > hold.Amount {
+			return ErrCaptureExceedsHold
+		}
+
+		// hold를 먼저 닫아야 아래 이체의 잔액 검사에서 이 hold 금액이 빠진다.
+		result.Hold, err = q.CaptureAccountHold(ctx, CaptureAccountHoldParams{
+			ID:             hold.ID,
+			CapturedAmount: amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.TransferTxResult, err = store.transfer(ctx, q, TransferTxParams{
+			FromAccountID: hold.AccountID,
+			ToAccountID:   hold.ToAccountID,
+			Amount:        amount,
+			HoldID:        sql.NullInt64{Int64: hold.ID, Valid: true},
+		})
+		return err
+	})
+	return result, err
+}
+
+// VoidHoldTx releases a hold without moving any money.
+func (store *SQLStore) VoidHoldTx(ctx context.Context, holdID int64) (AccountHold, error) {
+	var hold AccountHold
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		var err error
+		hold, err = lockActiveHold(ctx, q, holdID)
+		if err != nil {
+			return err
+		}
+
+		hold, err = q.UpdateAccountHoldStatus(ctx, UpdateAccountHoldStatusParams{
+			ID:     hold.ID,
+			Status: HoldStatusVoided,
+		})
+		return err
+	})
+	return hold, err
+}
+
+// 만료 시간이 지났지만 아직 expire job이 처리하지 않은 hold도 더 이상 쓸 수 없다.
+func lockActiveHold(ctx context.Context, q *Queries, holdID int64) (AccountHold, error) {
+	hold, err := q.GetAccountHoldForUpdate(ctx, holdID)
+	if err != nil {
+		return hold, err
+	}
+	if hold.Status != HoldStatusActive || !hold.ExpiresAt.After(time.Now()) {
+		return hold, ErrHoldNotActive
+	}
+	return hold, nil
+}
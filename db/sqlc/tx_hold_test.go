@@ -0,0 +1,184 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthorizeHoldTx(t *testing.T) {
+	store := NewStore(testDB, StoreConfig{})
+
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccountWithBalance(t, 0)
+
+	hold, err := store.AuthorizeHoldTx(context.Background(), AuthorizeHoldTxParams{
+		AccountID:   account1.ID,
+		ToAccountID: account2.ID,
+		Amount:      70,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.Equal(t, HoldStatusActive, hold.Status)
+	require.Equal(t, int64(70), hold.Amount)
+
+	held, err := testQueries.GetHeldAmount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(70), held)
+
+	// 잔액은 그대로지만 사용 가능한 잔액은 30뿐이다.
+	_, err = store.AuthorizeHoldTx(context.Background(), AuthorizeHoldTxParams{
+		AccountID:   account1.ID,
+		ToAccountID: account2.ID,
+		Amount:      31,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        31,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        30,
+	})
+	require.NoError(t, err)
+}
+
+func TestAuthorizeHoldTxInactiveAccount(t *testing.T) {
+	store := NewStore(testDB, StoreConfig{})
+
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccountWithBalance(t, 0)
+	arg := AuthorizeHoldTxParams{
+		AccountID:   account1.ID,
+		ToAccountID: account2.ID,
+		Amount:      10,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+
+	// 받는 계좌가 frozen이면 hold를 잡을 수 없다.
+	_, err := store.ChangeAccountStatusTx(context.Background(), ChangeAccountStatusTxParams{
+		AccountID: account2.ID,
+		ToStatus:  AccountStatusFrozen,
+		Reason:    "suspected fraud",
+		ChangedBy: "banker",
+	})
+	require.NoError(t, err)
+	_, err = store.AuthorizeHoldTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrAccountNotActive)
+
+	// 보내는 계좌가 frozen이어도 마찬가지이다.
+	_, err = store.ChangeAccountStatusTx(context.Background(), ChangeAccountStatusTxParams{
+		AccountID: account1.ID,
+		ToStatus:  AccountStatusFrozen,
+		Reason:    "suspected fraud",
+		ChangedBy: "banker",
+	})
+	require.NoError(t, err)
+	arg.ToAccountID = createRandomAccount(t).ID
+	_, err = store.AuthorizeHoldTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrAccountNotActive)
+
+	held, err := testQueries.GetHeldAmount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Zero(t, held)
+}
+
+func TestCaptureHoldTx(t *testing.T) {
+	store := NewStore(testDB, StoreConfig{})
+
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccountWithBalance(t, 0)
+
+	hold, err := store.AuthorizeHoldTx(context.Background(), AuthorizeHoldTxParams{
+		AccountID:   account1.ID,
+		ToAccountID: account2.ID,
+		Amount:      50,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	_, err = store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{HoldID: hold.ID, Amount: 51})
+	require.ErrorIs(t, err, ErrCaptureExceedsHold)
+
+	result, err := store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{HoldID: hold.ID, Amount: 40})
+	require.NoError(t, err)
+	require.Equal(t, HoldStatusCaptured, result.Hold.Status)
+	require.Equal(t, int64(40), result.Hold.CapturedAmount)
+	require.Equal(t, hold.ID, result.Transfer.HoldID.Int64)
+	require.Equal(t, int64(40), result.Transfer.Amount)
+	require.Equal(t, int64(60), result.FromAccount.Balance)
+	require.Equal(t, int64(40), result.ToAccount.Balance)
+
+	// 나머지 10은 풀려난다.
+	held, err := testQueries.GetHeldAmount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Zero(t, held)
+
+	_, err = store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{HoldID: hold.ID})
+	require.ErrorIs(t, err, ErrHoldNotActive)
+}
+
+func TestVoidHoldTx(t *testing.T) {
+	store := NewStore(testDB, StoreConfig{})
+
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccountWithBalance(t, 0)
+
+	hold, err := store.AuthorizeHoldTx(context.Background(), AuthorizeHoldTxParams{
+		AccountID:   account1.ID,
+		ToAccountID: account2.ID,
+		Amount:      50,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	voided, err := store.VoidHoldTx(context.Background(), hold.ID)
+	require.NoError(t, err)
+	require.Equal(t, HoldStatusVoided, voided.Status)
+
+	held, err := testQueries.GetHeldAmount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Zero(t, held)
+
+	_, err = store.VoidHoldTx(context.Background(), hold.ID)
+	require.ErrorIs(t, err, ErrHoldNotActive)
+}
+
+func TestExpireAccountHolds(t *testing.T) {
+	store := NewStore(testDB, StoreConfig{})
+
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccountWithBalance(t, 0)
+
+	hold, err := store.AuthorizeHoldTx(context.Background(), AuthorizeHoldTxParams{
+		AccountID:   account1.ID,
+		ToAccountID: account2.ID,
+		Amount:      50,
+		ExpiresAt:   time.Now().Add(time.Second),
+	})
+	require.NoError(t, err)
+
+	expired, err := testQueries.ExpireAccountHolds(context.Background(), time.Now().Add(time.Minute))
+	require.NoError(t, err)
+
+	var found bool
+	for _, h := range expired {
+		require.Equal(t, HoldStatusExpired, h.Status)
+		if h.ID == hold.ID {
+			found = true
+		}
+	}
+	require.True(t, found)
+
+	_, err = store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{HoldID: hold.ID})
+	require.ErrorIs(t, err, ErrHoldNotActive)
+}
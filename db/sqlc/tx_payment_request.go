@@ -0,0 +1,61 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+const (
+	PaymentRequestStatusPending   = "pending"
+	PaymentRequestStatusPaid      = "paid"
+	PaymentRequestStatusDeclined  = "declined"
+	PaymentRequestStatusCancelled = "cancelled"
+	PaymentRequestStatusExpired   = "expired"
+)
+
+var ErrPaymentRequestNotPending = errors.New("payment request is no longer pending")
+
+type PayPaymentRequestTxParams struct {
+	PaymentRequestID int64 `json:"payment_request_id"`
+	FromAccountID    int64 `json:"from_account_id"`
+}
+
+type PayPaymentRequestTxResult struct {
+	PaymentRequest PaymentRequest `json:"payment_request"`
+	TransferTxResult
+}
+
+// PayPaymentRequestTx pays a pending payment request from the payer's account.
+// request row에 lock을 걸고 상태를 확인하므로 같은 request가 두 번 지불되지 않는다.
+func (store *SQLStore) PayPaymentRequestTx(ctx context.Context, arg PayPaymentRequestTxParams) (PayPaymentRequestTxResult, error) {
+	var result PayPaymentRequestTxResult
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		request, err := q.GetPaymentRequestForUpdate(ctx, arg.PaymentRequestID)
+		if err != nil {
+			return err
+		}
+		// 만료 시간이 지났지만 아직 expire job이 처리하지 않은 request도 지불할 수 없다.
+		if request.Status != PaymentRequestStatusPending || !request.ExpiresAt.After(time.Now()) {
+			return ErrPaymentRequestNotPending
+		}
+
+		result.TransferTxResult, err = store.transfer(ctx, q, TransferTxParams{
+			FromAccountID: arg.FromAccountID,
+			ToAccountID:   request.ToAccountID,
+			Amount:        request.Amount,
+			Description:   request.Description,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.PaymentRequest, err = q.MarkPaymentRequestPaid(ctx, MarkPaymentRequestPaidParams{
+			ID:         request.ID,
+			TransferID: sql.NullInt64{Int64: result.Transfer.ID, Valid: true},
+		})
+		return err
+	})
+	return result, err
+}
@@ -0,0 +1,134 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomPaymentRequest(t *testing.T, requester Account, payer Account, amount int64, expiresAt time.Time) PaymentRequest {
+	arg := CreatePaymentRequestParams{
+		Requester:   requester.Owner,
+		Payer:       payer.Owner,
+		ToAccountID: requester.ID,
+		Amount:      amount,
+		Currency:    requester.Currency,
+		Description: "dinner",
+		ExpiresAt:   expiresAt,
+	}
+	request, err := testQueries.CreatePaymentRequest(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, PaymentRequestStatusPending, request.Status)
+	require.Equal(t, arg.Amount, request.Amount)
+	require.False(t, request.TransferID.Valid)
+	return request
+}
+
+func TestPayPaymentRequestTx(t *testing.T) {
+	store := NewStore(testDB, StoreConfig{})
+
+	requester := createRandomAccountWithBalance(t, 0)
+	payer := createRandomAccountWithBalance(t, 100)
+	request := createRandomPaymentRequest(t, requester, payer, 40, time.Now().Add(time.Hour))
+
+	result, err := store.PayPaymentRequestTx(context.Background(), PayPaymentRequestTxParams{
+		PaymentRequestID: request.ID,
+		FromAccountID:    payer.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, PaymentRequestStatusPaid, result.PaymentRequest.Status)
+	require.Equal(t, result.Transfer.ID, result.PaymentRequest.TransferID.Int64)
+	require.True(t, result.PaymentRequest.ResolvedAt.Valid)
+	require.Equal(t, "dinner", result.Transfer.Description)
+	require.Equal(t, int64(60), result.FromAccount.Balance)
+	require.Equal(t, int64(40), result.ToAccount.Balance)
+
+	// 한 번만 지불할 수 있다.
+	_, err = store.PayPaymentRequestTx(context.Background(), PayPaymentRequestTxParams{
+		PaymentRequestID: request.ID,
+		FromAccountID:    payer.ID,
+	})
+	require.ErrorIs(t, err, ErrPaymentRequestNotPending)
+}
+
+func TestPayPaymentRequestTxConcurrent(t *testing.T) {
+	store := NewStore(testDB, StoreConfig{})
+
+	requester := createRandomAccountWithBalance(t, 0)
+	payer := createRandomAccountWithBalance(t, 100)
+	request := createRandomPaymentRequest(t, requester, payer, 10, time.Now().Add(time.Hour))
+
+	n := 5
+	errs := make(chan error)
+	for i := 0; i < n; i++ {
+		go func() {
+			_, err := store.PayPaymentRequestTx(context.Background(), PayPaymentRequestTxParams{
+				PaymentRequestID: request.ID,
+				FromAccountID:    payer.ID,
+			})
+			errs <- err
+		}()
+	}
+
+	var paid int
+	for i := 0; i < n; i++ {
+		if err := <-errs; err == nil {
+			paid++
+		} else {
+			require.ErrorIs(t, err, ErrPaymentRequestNotPending)
+		}
+	}
+	require.Equal(t, 1, paid)
+
+	account, err := testQueries.GetAccount(context.Background(), payer.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(90), account.Balance)
+}
+
+func TestResolvePendingPaymentRequest(t *testing.T) {
+	requester := createRandomAccountWithBalance(t, 0)
+	payer := createRandomAccountWithBalance(t, 100)
+	request := createRandomPaymentRequest(t, requester, payer, 10, time.Now().Add(time.Hour))
+
+	declined, err := testQueries.ResolvePendingPaymentRequest(context.Background(), ResolvePendingPaymentRequestParams{
+		ID:     request.ID,
+		Status: PaymentRequestStatusDeclined,
+	})
+	require.NoError(t, err)
+	require.Equal(t, PaymentRequestStatusDeclined, declined.Status)
+
+	_, err = testQueries.ResolvePendingPaymentRequest(context.Background(), ResolvePendingPaymentRequestParams{
+		ID:     request.ID,
+		Status: PaymentRequestStatusCancelled,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestExpirePaymentRequests(t *testing.T) {
+	store := NewStore(testDB, StoreConfig{})
+
+	requester := createRandomAccountWithBalance(t, 0)
+	payer := createRandomAccountWithBalance(t, 100)
+	request := createRandomPaymentRequest(t, requester, payer, 10, time.Now().Add(time.Second))
+
+	expired, err := testQueries.ExpirePaymentRequests(context.Background(), time.Now().Add(time.Minute))
+	require.NoError(t, err)
+
+	var found bool
+	for _, r := range expired {
+		require.Equal(t, PaymentRequestStatusExpired, r.Status)
+		if r.ID == request.ID {
+			found = true
+		}
+	}
+	require.True(t, found)
+
+	_, err = store.PayPaymentRequestTx(context.Background(), PayPaymentRequestTxParams{
+		PaymentRequestID: request.ID,
+		FromAccountID:    payer.ID,
+	})
+	require.ErrorIs(t, err, ErrPaymentRequestNotPending)
+}
@@ -0,0 +1,51 @@
+package db
+
+import (
+	"errors"
+	"math/rand"
+	"sync/atomic"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// 재시도를 다 써도 충돌이 계속되면 이 에러를 감싸서 돌려준다.
+var ErrTxConflict = errors.New("transaction kept conflicting with concurrent transactions")
+
+// TxStats counts transaction retries since the store was created.
+type TxStats struct {
+	// 다시 시도한 횟수
+	Retries uint64 `json:"retries"`
+	// 재시도를 다 쓰고 ErrTxConflict로 끝난 트랜잭션 수
+	Exhausted uint64 `json:"exhausted"`
+}
+
+func (store *SQLStore) TxStats() TxStats {
+	return TxStats{
+		Retries:   atomic.LoadUint64(&store.txRetries),
+		Exhausted: atomic.LoadUint64(&store.txExhausted),
+	}
+}
+
+// Postgres가 트랜잭션을 중단시킨 경우로, 처음부터 다시 실행하면 성공할 수 있다.
+func isRetryable(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	switch pqErr.Code.Name() {
+	case "serialization_failure", "deadlock_detected":
+		return true
+	}
+	return false
+}
+
+// retryDelay는 backoff를 attempt마다 두 배로 늘리고, 그 절반에서 전체 사이의 값을 고른다.
+// 같이 충돌한 트랜잭션들이 같은 시점에 다시 부딪히지 않게 하기 위해서이다.
+func retryDelay(backoff time.Duration, attempt int) time.Duration {
+	backoff <<= attempt
+	if backoff <= 0 {
+		return 0
+	}
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
@@ -0,0 +1,86 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRetryable(t *testing.T) {
+	require.True(t, isRetryable(&pq.Error{Code: "40001"}))
+	require.True(t, isRetryable(&pq.Error{Code: "40P01"}))
+	require.True(t, isRetryable(fmt.Errorf("tx err: %w, rb error : closed", &pq.Error{Code: "40P01"})))
+
+	require.False(t, isRetryable(&pq.Error{Code: "23505"}))
+	require.False(t, isRetryable(ErrInsufficientFunds))
+	require.False(t, isRetryable(sql.ErrNoRows))
+}
+
+func TestRetryDelay(t *testing.T) {
+	backoff := 10 * time.Millisecond
+	for attempt := 0; attempt < 4; attempt++ {
+		max := backoff << attempt
+		for i := 0; i < 100; i++ {
+			delay := retryDelay(backoff, attempt)
+			require.GreaterOrEqual(t, delay, max/2)
+			require.LessOrEqual(t, delay, max)
+		}
+	}
+	require.Zero(t, retryDelay(0, 3))
+}
+
+func TestExecTxRetry(t *testing.T) {
+	store := NewStore(testDB, StoreConfig{TxMaxRetries: 2, TxRetryBackoff: time.Millisecond}).(*SQLStore)
+	opts := &sql.TxOptions{Isolation: sql.LevelSerializable}
+
+	// 처음 한 번은 deadlock으로 실패하고 다시 시도하면 성공한다.
+	calls := 0
+	err := store.execTx(context.Background(), opts, func(q *Queries) error {
+		calls++
+		if calls == 1 {
+			return &pq.Error{Code: "40P01"}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, calls)
+	require.Equal(t, TxStats{Retries: 1}, store.TxStats())
+
+	// 재시도를 다 쓰면 ErrTxConflict를 돌려준다.
+	calls = 0
+	err = store.execTx(context.Background(), opts, func(q *Queries) error {
+		calls++
+		return &pq.Error{Code: "40001"}
+	})
+	require.ErrorIs(t, err, ErrTxConflict)
+	require.Equal(t, 3, calls)
+	require.Equal(t, TxStats{Retries: 3, Exhausted: 1}, store.TxStats())
+
+	// 다른 에러는 다시 시도하지 않는다.
+	calls = 0
+	err = store.execTx(context.Background(), nil, func(q *Queries) error {
+		calls++
+		return ErrInsufficientFunds
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+	require.Equal(t, 1, calls)
+}
+
+func TestExecTxReadOnly(t *testing.T) {
+	store := NewStore(testDB, StoreConfig{}).(*SQLStore)
+	account := createRandomAccount(t)
+
+	err := store.execTx(context.Background(), &sql.TxOptions{ReadOnly: true}, func(q *Queries) error {
+		_, err := q.AddAccountBalance(context.Background(), AddAccountBalanceParams{
+			ID:     account.ID,
+			Amount: 10,
+		})
+		return err
+	})
+	require.Error(t, err)
+}
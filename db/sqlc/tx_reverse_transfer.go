@@ -0,0 +1,82 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrReversalExceedsTransfer = errors.New("reversal amount exceeds the amount left to reverse")
+	ErrTransferIsReversal      = errors.New("a reversal cannot be reversed")
+	ErrTransferNotCompleted    = errors.New("only a completed transfer can be reversed")
+)
+
+type ReverseTransferTxParams struct {
+	TransferID int64 `json:"transfer_id"`
+	// 0이면 아직 되돌려지지 않은 금액 전부를 되돌린다.
+	Amount int64 `json:"amount"`
+	// banker가 하는 reversal은 수취인의 잔액이 부족해도 진행한다. refund는 수취인의 돈으로 하므로 잔액을 검사한다.
+	AllowOverdraft bool `json:"allow_overdraft"`
+}
+
+type ReverseTransferTxResult struct {
+	OriginalTransfer Transfer `json:"original_transfer"`
+	TransferTxResult
+}
+
+// ReverseTransferTx sends all or part of a transfer back to its sender as a new transfer linked to the original.
+// 원래 transfer row에 lock을 걸어서 동시에 들어온 refund가 원래 금액을 넘지 못하게 한다.
+func (store *SQLStore) ReverseTransferTx(ctx context.Context, arg ReverseTransferTxParams) (ReverseTransferTxResult, error) {
+	var result ReverseTransferTxResult
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		var err error
+		result.OriginalTransfer, err = q.GetTransferForUpdate(ctx, arg.TransferID)
+		if err != nil {
+			return err
+		}
+		original := result.OriginalTransfer
+		if original.ReversalOf.Valid {
+			return ErrTransferIsReversal
+		}
+		// 전액 되돌려진 transfer는 reversed 상태이고, 아래에서 남은 금액이 없어 막힌다.
+		if original.Status != TransferStatusCompleted && original.Status != TransferStatusReversed {
+			return ErrTransferNotCompleted
+		}
+
+		reversed, err := q.GetReversedAmount(ctx, original.ID)
+		if err != nil {
+			return err
+		}
+		remaining := original.Amount - reversed
+
+		amount := arg.Amount
+		if amount == 0 {
+			amount = remaining
+		}
+		if amount <= 0 || amount > remaining {
+			return ErrReversalExceedsTransfer
+		}
+
+		params := TransferTxParams{
+			FromAccountID: original.ToAccountID,
+			ToAccountID:   original.FromAccountID,
+			Amount:        amount,
+			ReversalOf:    sql.NullInt64{Int64: original.ID, Valid: true},
+		}
+		if arg.AllowOverdraft {
+			result.TransferTxResult, err = moveMoney(ctx, q, params)
+		} else {
+			result.TransferTxResult, err = store.transfer(ctx, q, params)
+		}
+		if err != nil || amount < remaining {
+			return err
+		}
+
+		reason := fmt.Sprintf("reversed by transfer %d", result.Transfer.ID)
+		result.OriginalTransfer, err = transitionTransfer(ctx, q, original, TransferStatusReversed, reason)
+		return err
+	})
+	return result, err
+}
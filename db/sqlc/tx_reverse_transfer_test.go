@@ -0,0 +1,101 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReverseTransferTx(t *testing.T) {
+	store := NewStore(testDB, StoreConfig{})
+
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccountWithBalance(t, 0)
+
+	original, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        50,
+	})
+	require.NoError(t, err)
+
+	// partial refund
+	result, err := store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: original.Transfer.ID,
+		Amount:     20,
+	})
+	require.NoError(t, err)
+	require.Equal(t, original.Transfer.ID, result.OriginalTransfer.ID)
+	require.Equal(t, original.Transfer.ID, result.Transfer.ReversalOf.Int64)
+	require.Equal(t, account2.ID, result.Transfer.FromAccountID)
+	require.Equal(t, account1.ID, result.Transfer.ToAccountID)
+	require.Equal(t, int64(20), result.Transfer.Amount)
+	require.Equal(t, int64(30), result.FromAccount.Balance)
+	require.Equal(t, int64(70), result.ToAccount.Balance)
+	require.Equal(t, TransferStatusCompleted, result.OriginalTransfer.Status)
+
+	// 남은 금액보다 많이 돌려줄 수 없다.
+	_, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: original.Transfer.ID,
+		Amount:     31,
+	})
+	require.ErrorIs(t, err, ErrReversalExceedsTransfer)
+
+	// amount를 생략하면 남은 금액 전부를 되돌린다.
+	result, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: original.Transfer.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(30), result.Transfer.Amount)
+	require.Equal(t, account1.Balance, result.ToAccount.Balance)
+	require.Equal(t, TransferStatusReversed, result.OriginalTransfer.Status)
+
+	history, err := testQueries.ListStatusHistory(context.Background(), original.Transfer.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	require.False(t, history[0].FromStatus.Valid)
+	require.Equal(t, TransferStatusCompleted, history[0].ToStatus)
+	require.Equal(t, TransferStatusCompleted, history[1].FromStatus.String)
+	require.Equal(t, TransferStatusReversed, history[1].ToStatus)
+
+	_, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: original.Transfer.ID,
+	})
+	require.ErrorIs(t, err, ErrReversalExceedsTransfer)
+
+	_, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: result.Transfer.ID,
+	})
+	require.ErrorIs(t, err, ErrTransferIsReversal)
+}
+
+func TestReverseTransferTxOverdraft(t *testing.T) {
+	store := NewStore(testDB, StoreConfig{})
+
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccountWithBalance(t, 0)
+
+	original, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        50,
+	})
+	require.NoError(t, err)
+
+	// 수취인이 돈을 이미 써버린 경우
+	_, err = testQueries.UpdateAccount(context.Background(), UpdateAccountParams{ID: account2.ID, Balance: 10})
+	require.NoError(t, err)
+
+	_, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: original.Transfer.ID,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	result, err := store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID:     original.Transfer.ID,
+		AllowOverdraft: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(-40), result.FromAccount.Balance)
+}
@@ -0,0 +1,77 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/gyu-young-park/simplebank/util"
+)
+
+const (
+	StandingOrderStatusActive    = "active"
+	StandingOrderStatusCompleted = "completed"
+	StandingOrderStatusCancelled = "cancelled"
+)
+
+// 다른 worker가 먼저 실행했거나 취소된 standing order이다.
+var ErrStandingOrderNotDue = errors.New("standing order is not due")
+
+type ExecuteStandingOrderTxParams struct {
+	StandingOrderID int64     `json:"standing_order_id"`
+	Now             time.Time `json:"now"`
+}
+
+type ExecuteStandingOrderTxResult struct {
+	StandingOrder StandingOrder    `json:"standing_order"`
+	Transfer      TransferTxResult `json:"transfer"`
+}
+
+// ExecuteStandingOrderTx runs one occurrence of a standing order and moves it to the next occurrence.
+// standing order row에 lock을 걸기 때문에 같은 회차가 두 번 실행되지 않는다.
+func (store *SQLStore) ExecuteStandingOrderTx(ctx context.Context, arg ExecuteStandingOrderTxParams) (ExecuteStandingOrderTxResult, error) {
+	var result ExecuteStandingOrderTxResult
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		order, err := q.GetStandingOrderForUpdate(ctx, arg.StandingOrderID)
+		if err != nil {
+			return err
+		}
+		if order.Status != StandingOrderStatusActive || order.NextRunAt.After(arg.Now) {
+			return ErrStandingOrderNotDue
+		}
+
+		result.Transfer, err = store.transfer(ctx, q, TransferTxParams{
+			FromAccountID:   order.FromAccountID,
+			ToAccountID:     order.ToAccountID,
+			Amount:          order.Amount,
+			StandingOrderID: sql.NullInt64{Int64: order.ID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.StandingOrder, err = q.UpdateStandingOrderSchedule(ctx, NextStandingOrderSchedule(order))
+		return err
+	})
+	return result, err
+}
+
+// NextStandingOrderSchedule returns the schedule after the current occurrence is consumed,
+// either because it was executed or because its retries were exhausted.
+func NextStandingOrderSchedule(order StandingOrder) UpdateStandingOrderScheduleParams {
+	recurrence := util.Recurrence{Frequency: order.Frequency, Day: int(order.Day)}
+	arg := UpdateStandingOrderScheduleParams{
+		ID:          order.ID,
+		NextRunAt:   recurrence.Next(order.NextRunAt),
+		Occurrences: order.Occurrences + 1,
+		Status:      StandingOrderStatusActive,
+	}
+	if order.MaxOccurrences.Valid && arg.Occurrences >= order.MaxOccurrences.Int32 {
+		arg.Status = StandingOrderStatusCompleted
+	}
+	if order.EndDate.Valid && arg.NextRunAt.After(order.EndDate.Time) {
+		arg.Status = StandingOrderStatusCompleted
+	}
+	return arg
+}
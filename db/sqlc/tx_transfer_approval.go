@@ -0,0 +1,80 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/gyu-young-park/simplebank/util"
+)
+
+const (
+	TransferApprovalStatusPending  = "pending_approval"
+	TransferApprovalStatusApproved = "approved"
+	TransferApprovalStatusRejected = "rejected"
+)
+
+var (
+	ErrApprovalNotPending = errors.New("transfer approval is no longer pending")
+	ErrSelfApproval       = errors.New("transfer must be approved by someone other than its initiator")
+)
+
+type ApproveTransferTxParams struct {
+	ApprovalID int64  `json:"approval_id"`
+	Reviewer   string `json:"reviewer"`
+}
+
+type ApproveTransferTxResult struct {
+	Approval TransferApproval `json:"approval"`
+	TransferTxResult
+}
+
+// ApproveTransferTx executes a transfer waiting for approval and records the reviewer.
+// 이체가 실패하면 approval도 pending_approval 상태로 남는다.
+func (store *SQLStore) ApproveTransferTx(ctx context.Context, arg ApproveTransferTxParams) (ApproveTransferTxResult, error) {
+	var result ApproveTransferTxResult
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		approval, err := q.GetTransferApprovalForUpdate(ctx, arg.ApprovalID)
+		if err != nil {
+			return err
+		}
+		if approval.Status != TransferApprovalStatusPending {
+			return ErrApprovalNotPending
+		}
+		if approval.Initiator == arg.Reviewer {
+			return ErrSelfApproval
+		}
+
+		result.TransferTxResult, err = store.transfer(ctx, q, TransferTxParams{
+			FromAccountID: approval.FromAccountID,
+			ToAccountID:   approval.ToAccountID,
+			Amount:        approval.Amount,
+			Description:   approval.Description,
+			Reference:     approval.Reference,
+			Metadata:      approval.Metadata,
+			approved:      true,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Approval, err = q.ApproveTransferApproval(ctx, ApproveTransferApprovalParams{
+			ID:         approval.ID,
+			Reviewer:   sql.NullString{String: arg.Reviewer, Valid: true},
+			TransferID: sql.NullInt64{Int64: result.Transfer.ID, Valid: true},
+		})
+		return err
+	})
+	return result, err
+}
+
+// checkApprovalThreshold returns ErrApprovalRequired if the amount is over the approval threshold of its currency.
+func (store *SQLStore) checkApprovalThreshold(currency string, amount int64) error {
+	threshold, ok := store.config.ApprovalThresholds[currency]
+	if ok && amount > threshold {
+		return fmt.Errorf("%w: %s is over the approval threshold %s",
+			ErrApprovalRequired, util.NewMoney(amount, currency), util.NewMoney(threshold, currency))
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gyu-young-park/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomTransferApproval(t *testing.T, from Account, to Account, amount int64) TransferApproval {
+	arg := CreateTransferApprovalParams{
+		Initiator:     from.Owner,
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        amount,
+		Currency:      from.Currency,
+		Description:   "house deposit",
+		Metadata:      json.RawMessage(`{}`),
+	}
+	approval, err := testQueries.CreateTransferApproval(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, TransferApprovalStatusPending, approval.Status)
+	require.Equal(t, arg.Amount, approval.Amount)
+	require.False(t, approval.Reviewer.Valid)
+	require.False(t, approval.TransferID.Valid)
+	return approval
+}
+
+func TestApproveTransferTx(t *testing.T) {
+	store := NewStore(testDB, StoreConfig{})
+
+	from := createRandomAccountWithBalance(t, 100)
+	to := createRandomAccountWithBalance(t, 0)
+	reviewer := createRandomUser(t)
+	approval := createRandomTransferApproval(t, from, to, 70)
+
+	// 요청한 사람은 스스로 승인할 수 없다.
+	_, err := store.ApproveTransferTx(context.Background(), ApproveTransferTxParams{
+		ApprovalID: approval.ID,
+		Reviewer:   from.Owner,
+	})
+	require.ErrorIs(t, err, ErrSelfApproval)
+
+	result, err := store.ApproveTransferTx(context.Background(), ApproveTransferTxParams{
+		ApprovalID: approval.ID,
+		Reviewer:   reviewer.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferApprovalStatusApproved, result.Approval.Status)
+	require.Equal(t, reviewer.Username, result.Approval.Reviewer.String)
+	require.Equal(t, result.Transfer.ID, result.Approval.TransferID.Int64)
+	require.True(t, result.Approval.ReviewedAt.Valid)
+	require.Equal(t, "house deposit", result.Transfer.Description)
+	require.Equal(t, int64(30), result.FromAccount.Balance)
+	require.Equal(t, int64(70), result.ToAccount.Balance)
+
+	_, err = store.ApproveTransferTx(context.Background(), ApproveTransferTxParams{
+		ApprovalID: approval.ID,
+		Reviewer:   reviewer.Username,
+	})
+	require.ErrorIs(t, err, ErrApprovalNotPending)
+}
+
+func TestApproveTransferTxInsufficientFunds(t *testing.T) {
+	store := NewStore(testDB, StoreConfig{})
+
+	from := createRandomAccountWithBalance(t, 10)
+	to := createRandomAccountWithBalance(t, 0)
+	reviewer := createRandomUser(t)
+	approval := createRandomTransferApproval(t, from, to, 70)
+
+	_, err := store.ApproveTransferTx(context.Background(), ApproveTransferTxParams{
+		ApprovalID: approval.ID,
+		Reviewer:   reviewer.Username,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	// 실패한 승인은 기록되지 않는다.
+	approval, err = testQueries.GetTransferApproval(context.Background(), approval.ID)
+	require.NoError(t, err)
+	require.Equal(t, TransferApprovalStatusPending, approval.Status)
+}
+
+func TestRejectTransferApproval(t *testing.T) {
+	from := createRandomAccountWithBalance(t, 100)
+	to := createRandomAccountWithBalance(t, 0)
+	reviewer := createRandomUser(t)
+	approval := createRandomTransferApproval(t, from, to, 70)
+
+	arg := RejectTransferApprovalParams{
+		ID:       approval.ID,
+		Reviewer: sql.NullString{String: reviewer.Username, Valid: true},
+		Reason:   "unusual recipient",
+	}
+	rejected, err := testQueries.RejectTransferApproval(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, TransferApprovalStatusRejected, rejected.Status)
+	require.Equal(t, arg.Reason, rejected.Reason)
+	require.Equal(t, reviewer.Username, rejected.Reviewer.String)
+
+	_, err = testQueries.RejectTransferApproval(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	approvals, err := testQueries.ListTransferApprovals(context.Background(), ListTransferApprovalsParams{
+		Status: TransferApprovalStatusRejected,
+		Limit:  1000,
+	})
+	require.NoError(t, err)
+	require.Contains(t, approvals, rejected)
+}
+
+// TestApprovalThreshold checks that every way of moving money is held to the approval threshold, not only TransferTx.
+func TestApprovalThreshold(t *testing.T) {
+	thresholds := make(map[string]int64)
+	for _, currency := range []string{util.USD, util.EUR, util.CAD, util.WON} {
+		thresholds[currency] = 50
+	}
+	store := NewStore(testDB, StoreConfig{ApprovalThresholds: thresholds})
+	ctx := context.Background()
+
+	from := createRandomAccountWithBalance(t, 1000)
+	to := createRandomAccountWithBalance(t, 0)
+
+	_, err := store.TransferTx(ctx, TransferTxParams{FromAccountID: from.ID, ToAccountID: to.ID, Amount: 51})
+	require.ErrorIs(t, err, ErrApprovalRequired)
+
+	// 기준 금액 이하는 그대로 실행된다.
+	_, err = store.TransferTx(ctx, TransferTxParams{FromAccountID: from.ID, ToAccountID: to.ID, Amount: 50})
+	require.NoError(t, err)
+
+	batch, err := store.BatchTransferTx(ctx, BatchTransferTxParams{
+		Owner:         from.Owner,
+		FromAccountID: from.ID,
+		Currency:      from.Currency,
+		Mode:          BatchModeBestEffort,
+		Items:         []BatchTransferItem{{ToAccountID: to.ID, Amount: 51}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, BatchItemStatusFailed, batch.Items[0].Status)
+	require.Contains(t, batch.Items[0].FailureReason, ErrApprovalRequired.Error())
+
+	request := createRandomPaymentRequest(t, to, from, 51, time.Now().Add(time.Hour))
+	_, err = store.PayPaymentRequestTx(ctx, PayPaymentRequestTxParams{
+		PaymentRequestID: request.ID,
+		FromAccountID:    from.ID,
+	})
+	require.ErrorIs(t, err, ErrApprovalRequired)
+
+	order, err := testQueries.CreateStandingOrder(ctx, CreateStandingOrderParams{
+		Owner:         from.Owner,
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        51,
+		Frequency:     util.Daily,
+		StartDate:     time.Now().Add(-time.Minute),
+		NextRunAt:     time.Now().Add(-time.Minute),
+	})
+	require.NoError(t, err)
+	_, err = store.ExecuteStandingOrderTx(ctx, ExecuteStandingOrderTxParams{StandingOrderID: order.ID, Now: time.Now()})
+	require.ErrorIs(t, err, ErrApprovalRequired)
+
+	// capture할 때 실패할 hold는 잡을 수 없다.
+	_, err = store.AuthorizeHoldTx(ctx, AuthorizeHoldTxParams{
+		AccountID:   from.ID,
+		ToAccountID: to.ID,
+		Amount:      51,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.ErrorIs(t, err, ErrApprovalRequired)
+
+	// banker가 승인한 이체만 기준 금액을 넘을 수 있다.
+	reviewer := createRandomUser(t)
+	approval := createRandomTransferApproval(t, from, to, 51)
+	_, err = store.ApproveTransferTx(ctx, ApproveTransferTxParams{ApprovalID: approval.ID, Reviewer: reviewer.Username})
+	require.NoError(t, err)
+}
@@ -0,0 +1,220 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+const (
+	BatchModeAtomic     = "atomic"
+	BatchModeBestEffort = "best_effort"
+)
+
+const (
+	BatchStatusProcessing         = "processing"
+	BatchStatusCompleted          = "completed"
+	BatchStatusPartiallyCompleted = "partially_completed"
+	BatchStatusFailed             = "failed"
+)
+
+const (
+	BatchItemStatusPending   = "pending"
+	BatchItemStatusSucceeded = "succeeded"
+	BatchItemStatusFailed    = "failed"
+)
+
+// atomic batch에서 다른 item이 실패해서 함께 취소된 item에 기록한다.
+var ErrBatchRolledBack = errors.New("rolled back because another item of the batch failed")
+
+type BatchTransferItem struct {
+	ToAccountID int64 `json:"to_account_id"`
+	Amount      int64 `json:"amount"`
+}
+
+type BatchTransferTxParams struct {
+	Owner         string              `json:"owner"`
+	FromAccountID int64               `json:"from_account_id"`
+	Currency      string              `json:"currency"`
+	Mode          string              `json:"mode"`
+	Items         []BatchTransferItem `json:"items"`
+}
+
+type BatchTransferTxResult struct {
+	Batch TransferBatch       `json:"batch"`
+	Items []TransferBatchItem `json:"items"`
+}
+
+// BatchTransferTx records a batch of transfers from one account and executes it.
+// atomic batch는 모든 이체를 하나의 트랜잭션에서 실행하고, best effort batch는 item마다 트랜잭션을 따로 쓴다.
+// item의 실패는 error가 아니라 batch와 item의 status로 알려준다.
+func (store *SQLStore) BatchTransferTx(ctx context.Context, arg BatchTransferTxParams) (BatchTransferTxResult, error) {
+	result, err := store.createTransferBatch(ctx, arg)
+	if err != nil {
+		return result, err
+	}
+
+	if arg.Mode == BatchModeAtomic {
+		err = store.executeAtomicBatch(ctx, &result)
+	} else {
+		err = store.executeBestEffortBatch(ctx, &result)
+	}
+	if err != nil {
+		return result, err
+	}
+
+	var succeeded, failed int32
+	for _, item := range result.Items {
+		if item.Status == BatchItemStatusSucceeded {
+			succeeded++
+		} else {
+			failed++
+		}
+	}
+	status := BatchStatusPartiallyCompleted
+	switch {
+	case failed == 0:
+		status = BatchStatusCompleted
+	case succeeded == 0:
+		status = BatchStatusFailed
+	}
+
+	result.Batch, err = store.UpdateTransferBatchResult(ctx, UpdateTransferBatchResultParams{
+		ID:             result.Batch.ID,
+		Status:         status,
+		SucceededCount: succeeded,
+		FailedCount:    failed,
+	})
+	return result, err
+}
+
+// 실행 전에 batch와 item을 먼저 저장해두어야 실행이 실패해도 status endpoint에서 결과를 볼 수 있다.
+func (store *SQLStore) createTransferBatch(ctx context.Context, arg BatchTransferTxParams) (BatchTransferTxResult, error) {
+	var result BatchTransferTxResult
+	var totalAmount int64
+	for _, item := range arg.Items {
+		totalAmount += item.Amount
+	}
+
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		var err error
+		result.Batch, err = q.CreateTransferBatch(ctx, CreateTransferBatchParams{
+			Owner:         arg.Owner,
+			FromAccountID: arg.FromAccountID,
+			Currency:      arg.Currency,
+			Mode:          arg.Mode,
+			TotalCount:    int32(len(arg.Items)),
+			TotalAmount:   totalAmount,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Items = make([]TransferBatchItem, len(arg.Items))
+		for i, item := range arg.Items {
+			result.Items[i], err = q.CreateTransferBatchItem(ctx, CreateTransferBatchItemParams{
+				BatchID:     result.Batch.ID,
+				LineNo:      int32(i + 1),
+				ToAccountID: item.ToAccountID,
+				Amount:      item.Amount,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return result, err
+}
+
+func (store *SQLStore) executeAtomicBatch(ctx context.Context, result *BatchTransferTxResult) error {
+	executed := make([]TransferBatchItem, len(result.Items))
+	failedAt := -1
+	var failure error
+
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		// 재시도될 때 이전 시도의 실패 정보가 남지 않게 한다.
+		failedAt, failure = -1, nil
+		for i, item := range result.Items {
+			transferResult, err := store.transfer(ctx, q, TransferTxParams{
+				FromAccountID: result.Batch.FromAccountID,
+				ToAccountID:   item.ToAccountID,
+				Amount:        item.Amount,
+			})
+			if err != nil {
+				failedAt, failure = i, err
+				return err
+			}
+
+			executed[i], err = q.UpdateTransferBatchItem(ctx, UpdateTransferBatchItemParams{
+				ID:         item.ID,
+				Status:     BatchItemStatusSucceeded,
+				TransferID: sql.NullInt64{Int64: transferResult.Transfer.ID, Valid: true},
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err == nil {
+		result.Items = executed
+		return nil
+	}
+	// item 때문이 아닌 에러는 batch를 processing 상태로 두고 그대로 돌려준다.
+	if failedAt < 0 {
+		return err
+	}
+
+	// 트랜잭션이 rollback되었으므로 모든 item을 실패로 기록한다.
+	for i, item := range result.Items {
+		reason := ErrBatchRolledBack.Error()
+		if i == failedAt {
+			reason = failure.Error()
+		}
+		result.Items[i], err = store.UpdateTransferBatchItem(ctx, UpdateTransferBatchItemParams{
+			ID:            item.ID,
+			Status:        BatchItemStatusFailed,
+			FailureReason: reason,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (store *SQLStore) executeBestEffortBatch(ctx context.Context, result *BatchTransferTxResult) error {
+	for i, item := range result.Items {
+		var executed TransferBatchItem
+		err := store.execTx(ctx, nil, func(q *Queries) error {
+			transferResult, err := store.transfer(ctx, q, TransferTxParams{
+				FromAccountID: result.Batch.FromAccountID,
+				ToAccountID:   item.ToAccountID,
+				Amount:        item.Amount,
+			})
+			if err != nil {
+				return err
+			}
+
+			executed, err = q.UpdateTransferBatchItem(ctx, UpdateTransferBatchItemParams{
+				ID:         item.ID,
+				Status:     BatchItemStatusSucceeded,
+				TransferID: sql.NullInt64{Int64: transferResult.Transfer.ID, Valid: true},
+			})
+			return err
+		})
+		if err != nil {
+			executed, err = store.UpdateTransferBatchItem(ctx, UpdateTransferBatchItemParams{
+				ID:            item.ID,
+				Status:        BatchItemStatusFailed,
+				FailureReason: err.Error(),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		result.Items[i] = executed
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchTransferTxAtomic(t *testing.T) {
+	store := NewStore(testDB, StoreConfig{})
+
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccountWithBalance(t, 0)
+	account3 := createRandomAccountWithBalance(t, 0)
+
+	arg := BatchTransferTxParams{
+		Owner:         account1.Owner,
+		FromAccountID: account1.ID,
+		Currency:      account1.Currency,
+		Mode:          BatchModeAtomic,
+		Items: []BatchTransferItem{
+			{ToAccountID: account2.ID, Amount: 30},
+			{ToAccountID: account3.ID, Amount: 40},
+		},
+	}
+	result, err := store.BatchTransferTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, BatchStatusCompleted, result.Batch.Status)
+	require.Equal(t, int32(2), result.Batch.SucceededCount)
+	require.Equal(t, int64(70), result.Batch.TotalAmount)
+	for _, item := range result.Items {
+		require.Equal(t, BatchItemStatusSucceeded, item.Status)
+		require.True(t, item.TransferID.Valid)
+	}
+
+	// 두 번째 item에서 잔액이 부족하면 첫 번째 item도 취소된다.
+	result, err = store.BatchTransferTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, BatchStatusFailed, result.Batch.Status)
+	require.Equal(t, int32(2), result.Batch.FailedCount)
+	require.Equal(t, ErrBatchRolledBack.Error(), result.Items[0].FailureReason)
+	require.Equal(t, ErrInsufficientFunds.Error(), result.Items[1].FailureReason)
+
+	account1, err = testQueries.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(30), account1.Balance)
+}
+
+func TestBatchTransferTxBestEffort(t *testing.T) {
+	store := NewStore(testDB, StoreConfig{})
+
+	account1 := createRandomAccountWithBalance(t, 100)
+	account2 := createRandomAccountWithBalance(t, 0)
+	account3 := createRandomAccountWithBalance(t, 0)
+
+	result, err := store.BatchTransferTx(context.Background(), BatchTransferTxParams{
+		Owner:         account1.Owner,
+		FromAccountID: account1.ID,
+		Currency:      account1.Currency,
+		Mode:          BatchModeBestEffort,
+		Items: []BatchTransferItem{
+			{ToAccountID: account2.ID, Amount: 80},
+			{ToAccountID: account3.ID, Amount: 40},
+			{ToAccountID: account3.ID, Amount: 20},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, BatchStatusPartiallyCompleted, result.Batch.Status)
+	require.Equal(t, int32(2), result.Batch.SucceededCount)
+	require.Equal(t, int32(1), result.Batch.FailedCount)
+	require.Equal(t, BatchItemStatusSucceeded, result.Items[0].Status)
+	require.Equal(t, BatchItemStatusFailed, result.Items[1].Status)
+	require.Equal(t, BatchItemStatusSucceeded, result.Items[2].Status)
+
+	items, err := testQueries.ListTransferBatchItems(context.Background(), result.Batch.ID)
+	require.NoError(t, err)
+	require.Equal(t, result.Items, items)
+}
@@ -1,12 +1,20 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
+	"flag"
 	"log"
+	"os"
+	"time"
 
 	"github.com/gyu-young-park/simplebank/api"
 	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/gyu-young-park/simplebank/statement"
+	"github.com/gyu-young-park/simplebank/statement/export"
 	"github.com/gyu-young-park/simplebank/util"
+	"github.com/gyu-young-park/simplebank/worker"
 	_ "github.com/lib/pq"
 )
 
@@ -20,7 +28,37 @@ func main() {
 	if err != nil {
 		log.Fatal("cannot connect to db:", err)
 	}
-	store := db.NewStore(conn)
+	transferLimits, err := util.ParseTransferLimits(config.TransferLimits)
+	if err != nil {
+		log.Fatal("cannot parse transfer limits:", err)
+	}
+	approvalThresholds, err := util.ParseApprovalThresholds(config.ApprovalThresholds)
+	if err != nil {
+		log.Fatal("cannot parse approval thresholds:", err)
+	}
+	store := db.NewStore(conn, db.StoreConfig{
+		TransferLimits:     transferLimits,
+		ApprovalThresholds: approvalThresholds,
+		TxMaxRetries:       config.TxMaxRetries,
+		TxRetryBackoff:     config.TxRetryBackoff,
+	})
+	// "reconcile" 명령은 서버를 띄우지 않고 reconciliation을 한 번 실행한다.
+	if len(os.Args) > 1 && os.Args[1] == "reconcile" {
+		runReconcile(store, os.Args[2:])
+		return
+	}
+	// "export-statement" 명령은 계좌의 statement를 파일 형식으로 출력한다.
+	if len(os.Args) > 1 && os.Args[1] == "export-statement" {
+		runExportStatement(store, os.Args[2:])
+		return
+	}
+	// "verify-chain" 명령은 entries의 hash chain을 검증한다.
+	if len(os.Args) > 1 && os.Args[1] == "verify-chain" {
+		runVerifyChain(store, os.Args[2:])
+		return
+	}
+	runScheduler(config, store)
+
 	server, err := api.NewServer(config, store)
 	if err != nil {
 		log.Fatal("cannot create server:", err)
@@ -31,3 +69,174 @@ func main() {
 		log.Fatal("cannot start server:", err)
 	}
 }
+
+func runScheduler(config util.Config, store db.Store) {
+	scheduler := worker.NewScheduler()
+	standingOrders := worker.NewStandingOrderProcessor(config, store, worker.LogNotifier{})
+	scheduler.Add(worker.Job{
+		Name:     "standing_orders",
+		Interval: config.SchedulerInterval,
+		Run:      standingOrders.Run,
+	})
+	scheduler.Add(worker.Job{
+		Name:     "expire_holds",
+		Interval: config.SchedulerInterval,
+		Run:      worker.NewHoldExpirer(store).Run,
+	})
+	scheduler.Add(worker.Job{
+		Name:     "expire_payment_requests",
+		Interval: config.SchedulerInterval,
+		Run:      worker.NewPaymentRequestExpirer(store, worker.LogNotifier{}).Run,
+	})
+	scheduler.Add(worker.Job{
+		Name:     "ledger_posting",
+		Interval: config.SchedulerInterval,
+		Run:      worker.NewLedgerPoster(store).Run,
+	})
+	scheduler.Add(worker.Job{
+		Name:     "reconciliation",
+		Interval: config.ReconciliationInterval,
+		Run:      worker.NewReconciler(store, config.ReconciliationFreeze).Run,
+	})
+	scheduler.Add(worker.Job{
+		Name:     "balance_snapshots",
+		Interval: config.BalanceSnapshotInterval,
+		Run:      worker.NewBalanceSnapshotter(store).Run,
+	})
+	scheduler.Add(worker.Job{
+		Name:     "statements",
+		Interval: config.StatementInterval,
+		Run:      worker.NewStatementGenerator(store).Run,
+	})
+	scheduler.Add(worker.Job{
+		Name:     "interest_accrual",
+		Interval: config.InterestAccrualInterval,
+		Run:      worker.NewInterestAccruer(store).Run,
+	})
+	scheduler.Add(worker.Job{
+		Name:     "interest_posting",
+		Interval: config.InterestPostingInterval,
+		Run:      worker.NewInterestPoster(store).Run,
+	})
+	scheduler.Add(worker.Job{
+		Name:     "maintenance_fees",
+		Interval: config.MaintenanceFeeInterval,
+		Run:      worker.NewMaintenanceFeeCharger(store).Run,
+	})
+	scheduler.Start(context.Background())
+}
+
+// runReconcile prints the reconciliation report as JSON and exits with status 1 if anything is off.
+func runReconcile(store db.Store, args []string) {
+	flags := flag.NewFlagSet("reconcile", flag.ExitOnError)
+	freeze := flags.Bool("freeze", false, "freeze accounts whose balance does not match their entries")
+	flags.Parse(args)
+
+	report, err := worker.NewReconciler(store, *freeze).Reconcile(context.Background())
+	if err != nil {
+		log.Fatal("cannot reconcile:", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		log.Fatal("cannot write report:", err)
+	}
+	if len(report.Discrepancies) > 0 {
+		os.Exit(1)
+	}
+}
+
+// runVerifyChain prints the hash chain verification of one account, or of every account when none is given,
+// and exits with status 1 if any chain is broken.
+func runVerifyChain(store db.Store, args []string) {
+	flags := flag.NewFlagSet("verify-chain", flag.ExitOnError)
+	accountID := flags.Int64("account", 0, "verify only this account")
+	ledgerAccountID := flags.Int64("ledger_account", 0, "verify only this ledger account")
+	flags.Parse(args)
+
+	ctx := context.Background()
+	var targets []db.VerifyEntryChainParams
+	switch {
+	case *accountID != 0:
+		targets = append(targets, db.VerifyEntryChainParams{AccountID: *accountID})
+	case *ledgerAccountID != 0:
+		targets = append(targets, db.VerifyEntryChainParams{LedgerAccountID: *ledgerAccountID})
+	default:
+		accountIDs, err := store.ListAccountIDs(ctx)
+		if err != nil {
+			log.Fatal("cannot list accounts:", err)
+		}
+		for _, id := range accountIDs {
+			targets = append(targets, db.VerifyEntryChainParams{AccountID: id})
+		}
+		ledgerAccounts, err := store.ListLedgerAccounts(ctx)
+		if err != nil {
+			log.Fatal("cannot list ledger accounts:", err)
+		}
+		for _, ledgerAccount := range ledgerAccounts {
+			targets = append(targets, db.VerifyEntryChainParams{LedgerAccountID: ledgerAccount.ID})
+		}
+	}
+
+	// 깨진 chain만 출력한다.
+	broken := []db.EntryChainVerification{}
+	for _, target := range targets {
+		result, err := store.VerifyEntryChain(ctx, target)
+		if err != nil {
+			log.Fatal("cannot verify entry chain:", err)
+		}
+		if !result.Valid {
+			broken = append(broken, result)
+		}
+	}
+	log.Printf("verified %d entry chains, %d broken", len(targets), len(broken))
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(broken); err != nil {
+		log.Fatal("cannot write report:", err)
+	}
+	if len(broken) > 0 {
+		os.Exit(1)
+	}
+}
+
+// runExportStatement writes an account's statement for a date range to stdout.
+func runExportStatement(store db.Store, args []string) {
+	flags := flag.NewFlagSet("export-statement", flag.ExitOnError)
+	accountID := flags.Int64("account", 0, "account to export")
+	fromDate := flags.String("from", "", "first day of the statement, YYYY-MM-DD")
+	toDate := flags.String("to", "", "last day of the statement, YYYY-MM-DD")
+	format := flags.String("format", export.FormatCAMT053, "camt053, mt940, json, csv or pdf")
+	flags.Parse(args)
+
+	from, err := time.Parse("2006-01-02", *fromDate)
+	if err != nil {
+		log.Fatal("cannot parse -from:", err)
+	}
+	to, err := time.Parse("2006-01-02", *toDate)
+	if err != nil {
+		log.Fatal("cannot parse -to:", err)
+	}
+
+	ctx := context.Background()
+	account, err := store.GetAccount(ctx, *accountID)
+	if err != nil {
+		log.Fatal("cannot get account:", err)
+	}
+	// -to로 받은 날짜도 statement에 포함된다.
+	result, err := statement.GenerateRange(ctx, store, account, from, to.AddDate(0, 0, 1), time.Now())
+	if err != nil {
+		log.Fatal("cannot generate statement:", err)
+	}
+
+	if export.Supported(*format) {
+		err = export.Render(os.Stdout, result, *format)
+	} else {
+		err = statement.Render(os.Stdout, result, *format)
+	}
+	if err != nil {
+		log.Fatal("cannot write statement:", err)
+	}
+}
@@ -0,0 +1,290 @@
+package pain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/gyu-young-park/simplebank/util"
+	"github.com/lib/pq"
+)
+
+// transfer의 reference와 description 길이 제한. API로 만드는 transfer와 같다.
+const (
+	maxReferenceLength   = 35
+	maxDescriptionLength = 140
+)
+
+// Importer executes the credit transfers of a pain.001 document.
+type Importer struct {
+	store db.Store
+	// 이 금액을 넘는 transfer는 바로 실행하지 않고 banker의 승인을 기다린다.
+	approvalThresholds map[string]int64
+	// API로 보내는 transfer와 같은 payee cooling-off 한도를 받는 계좌마다 적용한다.
+	coolingOff db.PayeeCoolingOff
+	now        func() time.Time
+}
+
+func NewImporter(store db.Store, approvalThresholds map[string]int64, coolingOff db.PayeeCoolingOff) *Importer {
+	return &Importer{
+		store:              store,
+		approvalThresholds: approvalThresholds,
+		coolingOff:         coolingOff,
+		now:                time.Now,
+	}
+}
+
+// Import runs every credit transfer on behalf of owner and reports the outcome of each.
+// 각 거래는 따로 실행되므로 앞의 거래가 실패해도 뒤의 거래는 실행된다.
+// group header의 거래 수나 합계가 맞지 않거나 이미 받은 MsgId이면 아무것도 실행하지 않고 파일 전체를 거절한다.
+func (importer *Importer) Import(ctx context.Context, owner string, initiation Initiation) StatusReport {
+	report := StatusReport{
+		MessageID:         truncate("RPT-"+initiation.MessageID, maxReferenceLength),
+		CreatedAt:         importer.now(),
+		OriginalMessageID: initiation.MessageID,
+		OriginalNbOfTxs:   initiation.NumberOfTxs,
+	}
+	if reason, ok := initiation.CheckTotals(); !ok {
+		report.GroupStatus = StatusRejected
+		report.GroupReason = reason
+		return report
+	}
+	_, err := importer.store.CreatePaymentInitiation(ctx, db.CreatePaymentInitiationParams{
+		Owner:     owner,
+		MessageID: initiation.MessageID,
+	})
+	if err != nil {
+		report.GroupStatus = StatusRejected
+		report.GroupReason, report.GroupInfo = ReasonNarrative, err.Error()
+		if isUniqueViolation(err) {
+			report.GroupReason, report.GroupInfo = ReasonDuplicate, fmt.Sprintf("message %q was already received", initiation.MessageID)
+		}
+		return report
+	}
+
+	accounts, err := importer.loadAccounts(ctx, initiation)
+	// 같은 파일 안에서 같은 계좌로 가는 거래는 합한 금액으로 cooling-off를 검사한다.
+	paid := make(map[int64]int64)
+	for _, payment := range initiation.Payments {
+		var debtor db.Account
+		var reason, info string
+		if err != nil {
+			reason, info = ReasonNarrative, err.Error()
+		} else {
+			debtor, reason, info = importer.checkDebtor(owner, payment, accounts)
+		}
+		for _, tx := range payment.Transactions {
+			status := TransactionStatus{
+				PaymentInfoID: payment.ID,
+				InstructionID: tx.InstructionID,
+				EndToEndID:    tx.EndToEndID,
+				Status:        StatusRejected,
+				Reason:        reason,
+				Info:          info,
+			}
+			if reason == "" {
+				status = importer.transfer(ctx, owner, initiation.MessageID, payment.ID, debtor, tx, accounts, paid, status)
+			}
+			report.Transactions = append(report.Transactions, status)
+		}
+	}
+	report.GroupStatus = GroupStatus(report.Transactions)
+	return report
+}
+
+// loadAccounts reads every debtor and creditor account of the document in one query.
+func (importer *Importer) loadAccounts(ctx context.Context, initiation Initiation) (map[int64]db.Account, error) {
+	var ids []int64
+	for _, payment := range initiation.Payments {
+		if id, err := strconv.ParseInt(payment.DebtorAccount, 10, 64); err == nil {
+			ids = append(ids, id)
+		}
+		for _, tx := range payment.Transactions {
+			if id, err := strconv.ParseInt(tx.CreditorAccount, 10, 64); err == nil {
+				ids = append(ids, id)
+			}
+		}
+	}
+	accounts, err := importer.store.ListAccountsByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load accounts: %w", err)
+	}
+	accountByID := make(map[int64]db.Account, len(accounts))
+	for _, account := range accounts {
+		accountByID[account.ID] = account
+	}
+	return accountByID, nil
+}
+
+// checkDebtor returns a reason code when none of the payment's transfers can be made from its debtor account.
+func (importer *Importer) checkDebtor(owner string, payment PaymentInfo, accounts map[int64]db.Account) (db.Account, string, string) {
+	id, err := strconv.ParseInt(payment.DebtorAccount, 10, 64)
+	debtor, ok := accounts[id]
+	switch {
+	case err != nil || !ok:
+		return debtor, ReasonInvalidDebtorAccount, fmt.Sprintf("debtor account %q not found", payment.DebtorAccount)
+	case debtor.Owner != owner:
+		return debtor, ReasonTransactionForbidden, "debtor account doesn't belong to the authenticated user"
+	case payment.Method != "" && payment.Method != "TRF":
+		return debtor, ReasonNarrative, fmt.Sprintf("payment method %s is not supported", payment.Method)
+	}
+	if payment.DebtorCurrency != "" {
+		currency, ok := fileCurrency(payment.DebtorCurrency)
+		if !ok || currency != debtor.Currency {
+			return debtor, ReasonCurrencyNotAllowed, fmt.Sprintf("debtor account is in %s, not %s", debtor.Currency, payment.DebtorCurrency)
+		}
+	}
+	return debtor, "", ""
+}
+
+// transfer validates one credit transfer and executes it, or holds it for approval.
+// InstrId가 있으면 먼저 기록해서 이미 받은 거래는 DUPL로 거절하고, 거절된 거래의 InstrId는 다시 보낼 수 있게 지운다.
+func (importer *Importer) transfer(
+	ctx context.Context,
+	owner string,
+	messageID string,
+	paymentID string,
+	debtor db.Account,
+	tx CreditTransfer,
+	accounts map[int64]db.Account,
+	paid map[int64]int64,
+	status TransactionStatus,
+) TransactionStatus {
+	var instruction db.PaymentInstruction
+	reject := func(reason string, format string, args ...interface{}) TransactionStatus {
+		status.Reason, status.Info = reason, fmt.Sprintf(format, args...)
+		if instruction.ID != 0 {
+			if err := importer.store.DeletePaymentInstruction(ctx, instruction.ID); err != nil {
+				status.Info = fmt.Sprintf("%s (instruction id is still recorded: %v)", status.Info, err)
+			}
+		}
+		return status
+	}
+
+	if tx.InstructionID != "" {
+		var err error
+		instruction, err = importer.store.CreatePaymentInstruction(ctx, db.CreatePaymentInstructionParams{
+			Owner:         owner,
+			InstructionID: tx.InstructionID,
+			MessageID:     messageID,
+		})
+		if err != nil {
+			if isUniqueViolation(err) {
+				return reject(ReasonDuplicate, "instruction %q was already received", tx.InstructionID)
+			}
+			return reject(ReasonNarrative, "%v", err)
+		}
+	}
+
+	currency, ok := fileCurrency(tx.Currency)
+	if !ok {
+		return reject(ReasonCurrencyNotAllowed, "currency %s is not supported", tx.Currency)
+	}
+	if currency != debtor.Currency {
+		return reject(ReasonCurrencyNotAllowed, "debtor account is in %s, not %s", debtor.Currency, currency)
+	}
+	amount, err := ParseAmount(tx.Amount, util.CurrencyExponent(currency))
+	if err != nil {
+		return reject(ReasonInvalidAmount, "invalid amount %q for %s", tx.Amount, tx.Currency)
+	}
+	creditorID, err := strconv.ParseInt(tx.CreditorAccount, 10, 64)
+	creditor, ok := accounts[creditorID]
+	switch {
+	case err != nil || !ok:
+		return reject(ReasonInvalidCreditorAccount, "creditor account %q not found", tx.CreditorAccount)
+	case creditor.ID == debtor.ID:
+		return reject(ReasonInvalidCreditorAccount, "cannot transfer to the same account")
+	case creditor.Currency != currency:
+		return reject(ReasonInvalidCreditorAccount, "creditor account is in %s, not %s", creditor.Currency, currency)
+	}
+	err = importer.coolingOff.Check(ctx, importer.store, owner, debtor, creditor.ID, creditor.Owner, paid[creditor.ID]+amount, importer.now())
+	if err != nil {
+		if errors.Is(err, db.ErrCoolingOff) {
+			return reject(ReasonAmountNotAllowed, "%v", err)
+		}
+		return reject(ReasonNarrative, "%v", err)
+	}
+
+	reference := tx.EndToEndID
+	if reference == "NOTPROVIDED" {
+		reference = ""
+	}
+	metadata, err := json.Marshal(map[string]string{
+		"pain001_msg_id":     messageID,
+		"pain001_pmt_inf_id": paymentID,
+		"pain001_instr_id":   tx.InstructionID,
+	})
+	if err != nil {
+		return reject(ReasonNarrative, "%v", err)
+	}
+	arg := db.TransferTxParams{
+		FromAccountID: debtor.ID,
+		ToAccountID:   creditor.ID,
+		Amount:        amount,
+		Description:   truncate(tx.Remittance, maxDescriptionLength),
+		Reference:     truncate(reference, maxReferenceLength),
+		Metadata:      metadata,
+	}
+
+	if threshold, ok := importer.approvalThresholds[currency]; ok && amount > threshold {
+		approval, err := importer.store.CreateTransferApproval(ctx, db.CreateTransferApprovalParams{
+			Initiator:     owner,
+			FromAccountID: arg.FromAccountID,
+			ToAccountID:   arg.ToAccountID,
+			Amount:        arg.Amount,
+			Currency:      currency,
+			Description:   arg.Description,
+			Reference:     arg.Reference,
+			Metadata:      arg.Metadata,
+		})
+		if err != nil {
+			return reject(ReasonNarrative, "%v", err)
+		}
+		paid[creditor.ID] += amount
+		status.Status = StatusPending
+		status.ServicerReference = fmt.Sprintf("approval-%d", approval.ID)
+		return status
+	}
+
+	result, err := importer.store.TransferTx(ctx, arg)
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrInsufficientFunds):
+			return reject(ReasonInsufficientFunds, "%v", err)
+		case errors.Is(err, db.ErrTransferLimitExceeded), errors.Is(err, db.ErrApprovalRequired):
+			return reject(ReasonAmountNotAllowed, "%v", err)
+		case errors.Is(err, db.ErrAccountNotActive):
+			return reject(ReasonBlockedAccount, "%v", err)
+		}
+		return reject(ReasonNarrative, "%v", err)
+	}
+	paid[creditor.ID] += amount
+	status.Status = StatusAcceptedSettlementCompleted
+	status.ServicerReference = fmt.Sprintf("transfer-%d", result.Transfer.ID)
+	return status
+}
+
+func isUniqueViolation(err error) bool {
+	pqErr, ok := err.(*pq.Error)
+	return ok && pqErr.Code.Name() == "unique_violation"
+}
+
+// fileCurrency reads the Ccy attribute. ISO 4217 코드가 원칙이지만 우리 통화 코드도 받아 준다.
+func fileCurrency(code string) (string, bool) {
+	if currency, ok := util.CurrencyFromISOCode(code); ok {
+		return currency, true
+	}
+	return code, util.IsSupportedCurrency(code)
+}
+
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
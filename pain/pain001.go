@@ -0,0 +1,179 @@
+// Package pain reads ISO 20022 pain.001 payment initiations and writes pain.002 status reports.
+package pain
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// 태그에 namespace를 적지 않아서 pain.001.001.03과 그 이후 버전을 모두 읽는다.
+type initiationDocument struct {
+	XMLName    xml.Name       `xml:"Document"`
+	Initiation initiationBody `xml:"CstmrCdtTrfInitn"`
+}
+
+type initiationBody struct {
+	GroupHeader struct {
+		MessageID       string `xml:"MsgId"`
+		NumberOfTxs     string `xml:"NbOfTxs"`
+		ControlSum      string `xml:"CtrlSum"`
+		InitiatingParty string `xml:"InitgPty>Nm"`
+	} `xml:"GrpHdr"`
+	Payments []struct {
+		ID             string `xml:"PmtInfId"`
+		Method         string `xml:"PmtMtd"`
+		DebtorName     string `xml:"Dbtr>Nm"`
+		DebtorAccount  string `xml:"DbtrAcct>Id>Othr>Id"`
+		DebtorCurrency string `xml:"DbtrAcct>Ccy"`
+		Transactions   []struct {
+			InstructionID string `xml:"PmtId>InstrId"`
+			EndToEndID    string `xml:"PmtId>EndToEndId"`
+			Amount        struct {
+				Currency string `xml:"Ccy,attr"`
+				Value    string `xml:",chardata"`
+			} `xml:"Amt>InstdAmt"`
+			CreditorName    string `xml:"Cdtr>Nm"`
+			CreditorAccount string `xml:"CdtrAcct>Id>Othr>Id"`
+			Remittance      string `xml:"RmtInf>Ustrd"`
+		} `xml:"CdtTrfTxInf"`
+	} `xml:"PmtInf"`
+}
+
+// Initiation is a parsed pain.001 customer credit transfer initiation.
+type Initiation struct {
+	MessageID       string
+	NumberOfTxs     string
+	ControlSum      string
+	InitiatingParty string
+	Payments        []PaymentInfo
+}
+
+// PaymentInfo is one PmtInf block: a debtor account and the credit transfers paid from it.
+type PaymentInfo struct {
+	ID             string
+	Method         string
+	DebtorName     string
+	DebtorAccount  string
+	DebtorCurrency string
+	Transactions   []CreditTransfer
+}
+
+// CreditTransfer is one CdtTrfTxInf block. 금액은 파일에 적힌 10진수 문자열 그대로 둔다.
+type CreditTransfer struct {
+	InstructionID   string
+	EndToEndID      string
+	Amount          string
+	Currency        string
+	CreditorName    string
+	CreditorAccount string
+	Remittance      string
+}
+
+var ErrInvalidInitiation = errors.New("invalid pain.001 document")
+
+// ParseInitiation reads a pain.001 document.
+func ParseInitiation(r io.Reader) (Initiation, error) {
+	var doc initiationDocument
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return Initiation{}, fmt.Errorf("%w: %v", ErrInvalidInitiation, err)
+	}
+	body := doc.Initiation
+	if body.GroupHeader.MessageID == "" {
+		return Initiation{}, fmt.Errorf("%w: missing GrpHdr/MsgId", ErrInvalidInitiation)
+	}
+	if len(body.Payments) == 0 {
+		return Initiation{}, fmt.Errorf("%w: missing PmtInf", ErrInvalidInitiation)
+	}
+
+	initiation := Initiation{
+		MessageID:       strings.TrimSpace(body.GroupHeader.MessageID),
+		NumberOfTxs:     strings.TrimSpace(body.GroupHeader.NumberOfTxs),
+		ControlSum:      strings.TrimSpace(body.GroupHeader.ControlSum),
+		InitiatingParty: strings.TrimSpace(body.GroupHeader.InitiatingParty),
+	}
+	for _, payment := range body.Payments {
+		info := PaymentInfo{
+			ID:             strings.TrimSpace(payment.ID),
+			Method:         strings.TrimSpace(payment.Method),
+			DebtorName:     strings.TrimSpace(payment.DebtorName),
+			DebtorAccount:  strings.TrimSpace(payment.DebtorAccount),
+			DebtorCurrency: strings.TrimSpace(payment.DebtorCurrency),
+		}
+		for _, tx := range payment.Transactions {
+			info.Transactions = append(info.Transactions, CreditTransfer{
+				InstructionID:   strings.TrimSpace(tx.InstructionID),
+				EndToEndID:      strings.TrimSpace(tx.EndToEndID),
+				Amount:          strings.TrimSpace(tx.Amount.Value),
+				Currency:        strings.TrimSpace(tx.Amount.Currency),
+				CreditorName:    strings.TrimSpace(tx.CreditorName),
+				CreditorAccount: strings.TrimSpace(tx.CreditorAccount),
+				Remittance:      strings.TrimSpace(tx.Remittance),
+			})
+		}
+		initiation.Payments = append(initiation.Payments, info)
+	}
+	return initiation, nil
+}
+
+// TransactionCount is the number of credit transfers in the document.
+func (initiation Initiation) TransactionCount() int {
+	count := 0
+	for _, payment := range initiation.Payments {
+		count += len(payment.Transactions)
+	}
+	return count
+}
+
+var ErrInvalidAmount = errors.New("invalid amount")
+
+// ParseAmount converts a decimal amount such as "12.30" into minor units with the given exponent.
+func ParseAmount(value string, exponent int) (int64, error) {
+	whole, fraction := value, ""
+	if i := strings.IndexByte(value, '.'); i >= 0 {
+		whole, fraction = value[:i], value[i+1:]
+	}
+	if whole == "" || len(fraction) > exponent || strings.Trim(whole+fraction, "0123456789") != "" {
+		return 0, ErrInvalidAmount
+	}
+	fraction += strings.Repeat("0", exponent-len(fraction))
+
+	amount, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil || amount <= 0 {
+		return 0, ErrInvalidAmount
+	}
+	return amount, nil
+}
+
+// CheckTotals compares NbOfTxs and CtrlSum in the group header with the transactions in the document.
+// CtrlSum은 통화와 상관없이 모든 금액을 더한 값이다.
+func (initiation Initiation) CheckTotals() (reason string, ok bool) {
+	if initiation.NumberOfTxs != strconv.Itoa(initiation.TransactionCount()) {
+		return ReasonInvalidNumberOfTxs, false
+	}
+	if initiation.ControlSum == "" {
+		return "", true
+	}
+	want, valid := new(big.Rat).SetString(initiation.ControlSum)
+	if !valid {
+		return ReasonInvalidControlSum, false
+	}
+	sum := new(big.Rat)
+	for _, payment := range initiation.Payments {
+		for _, tx := range payment.Transactions {
+			amount, valid := new(big.Rat).SetString(tx.Amount)
+			if !valid {
+				return ReasonInvalidControlSum, false
+			}
+			sum.Add(sum, amount)
+		}
+	}
+	if sum.Cmp(want) != 0 {
+		return ReasonInvalidControlSum, false
+	}
+	return "", true
+}
@@ -0,0 +1,160 @@
+package pain
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+)
+
+const pain002Namespace = "urn:iso:std:iso:20022:tech:xsd:pain.002.001.03"
+
+// 거래 상태 코드 (ExternalPaymentTransactionStatus1Code)
+const (
+	StatusAcceptedSettlementCompleted = "ACSC"
+	StatusPending                     = "PDNG"
+	StatusRejected                    = "RJCT"
+	StatusPartiallyAccepted           = "PART"
+)
+
+// 거절 사유 코드 (ExternalStatusReason1Code)
+const (
+	ReasonInvalidDebtorAccount   = "AC02"
+	ReasonInvalidCreditorAccount = "AC03"
+	ReasonBlockedAccount         = "AC06"
+	ReasonAmountNotAllowed       = "AM02"
+	ReasonCurrencyNotAllowed     = "AM03"
+	ReasonInsufficientFunds      = "AM04"
+	ReasonInvalidControlSum      = "AM10"
+	ReasonInvalidAmount          = "AM12"
+	ReasonInvalidNumberOfTxs     = "AM18"
+	ReasonTransactionForbidden   = "AG01"
+	ReasonDuplicate              = "DUPL"
+	ReasonNarrative              = "NARR"
+)
+
+// TransactionStatus is the outcome of one credit transfer.
+type TransactionStatus struct {
+	PaymentInfoID string
+	InstructionID string
+	EndToEndID    string
+	Status        string
+	Reason        string
+	Info          string
+	// 실행된 transfer나 만들어진 approval을 가리킨다.
+	ServicerReference string
+}
+
+// StatusReport is the pain.002 answer to one pain.001 document.
+type StatusReport struct {
+	MessageID         string
+	CreatedAt         time.Time
+	OriginalMessageID string
+	OriginalNbOfTxs   string
+	GroupStatus       string
+	GroupReason       string
+	GroupInfo         string
+	Transactions      []TransactionStatus
+}
+
+type reportDocument struct {
+	XMLName xml.Name   `xml:"Document"`
+	Xmlns   string     `xml:"xmlns,attr"`
+	Report  reportBody `xml:"CstmrPmtStsRpt"`
+}
+
+type reportBody struct {
+	GroupHeader struct {
+		MessageID string `xml:"MsgId"`
+		CreatedAt string `xml:"CreDtTm"`
+	} `xml:"GrpHdr"`
+	OriginalGroup struct {
+		MessageID   string      `xml:"OrgnlMsgId"`
+		MessageName string      `xml:"OrgnlMsgNmId"`
+		NumberOfTxs string      `xml:"OrgnlNbOfTxs,omitempty"`
+		Status      string      `xml:"GrpSts"`
+		Reason      *reasonInfo `xml:"StsRsnInf,omitempty"`
+	} `xml:"OrgnlGrpInfAndSts"`
+	Payments []reportPayment `xml:"OrgnlPmtInfAndSts"`
+}
+
+type reportPayment struct {
+	ID           string              `xml:"OrgnlPmtInfId"`
+	Transactions []reportTransaction `xml:"TxInfAndSts"`
+}
+
+type reportTransaction struct {
+	InstructionID     string      `xml:"OrgnlInstrId,omitempty"`
+	EndToEndID        string      `xml:"OrgnlEndToEndId,omitempty"`
+	Status            string      `xml:"TxSts"`
+	Reason            *reasonInfo `xml:"StsRsnInf,omitempty"`
+	ServicerReference string      `xml:"AcctSvcrRef,omitempty"`
+}
+
+type reasonInfo struct {
+	Code string `xml:"Rsn>Cd"`
+	Info string `xml:"AddtlInf,omitempty"`
+}
+
+func newReasonInfo(code string, info string) *reasonInfo {
+	if code == "" {
+		return nil
+	}
+	// AddtlInf는 최대 105자이다.
+	if runes := []rune(info); len(runes) > 105 {
+		info = string(runes[:105])
+	}
+	return &reasonInfo{Code: code, Info: info}
+}
+
+// GroupStatus summarizes the transaction statuses: one status if they all agree, PART otherwise.
+func GroupStatus(transactions []TransactionStatus) string {
+	if len(transactions) == 0 {
+		return StatusRejected
+	}
+	status := transactions[0].Status
+	for _, tx := range transactions[1:] {
+		if tx.Status != status {
+			return StatusPartiallyAccepted
+		}
+	}
+	return status
+}
+
+// WriteStatusReport writes the report as a pain.002.001.03 document.
+func WriteStatusReport(w io.Writer, report StatusReport) error {
+	doc := reportDocument{Xmlns: pain002Namespace}
+	body := &doc.Report
+	body.GroupHeader.MessageID = report.MessageID
+	body.GroupHeader.CreatedAt = report.CreatedAt.UTC().Format(time.RFC3339)
+	body.OriginalGroup.MessageID = report.OriginalMessageID
+	body.OriginalGroup.MessageName = "pain.001.001.03"
+	body.OriginalGroup.NumberOfTxs = report.OriginalNbOfTxs
+	body.OriginalGroup.Status = report.GroupStatus
+	body.OriginalGroup.Reason = newReasonInfo(report.GroupReason, report.GroupInfo)
+
+	// 같은 PmtInf에 속한 거래는 하나의 OrgnlPmtInfAndSts로 묶는다.
+	for _, tx := range report.Transactions {
+		if n := len(body.Payments); n == 0 || body.Payments[n-1].ID != tx.PaymentInfoID {
+			body.Payments = append(body.Payments, reportPayment{ID: tx.PaymentInfoID})
+		}
+		payment := &body.Payments[len(body.Payments)-1]
+		payment.Transactions = append(payment.Transactions, reportTransaction{
+			InstructionID:     tx.InstructionID,
+			EndToEndID:        tx.EndToEndID,
+			Status:            tx.Status,
+			Reason:            newReasonInfo(tx.Reason, tx.Info),
+			ServicerReference: tx.ServicerReference,
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,319 @@
+package pain
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/xml"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/gyu-young-park/simplebank/db/mock"
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/gyu-young-park/simplebank/util"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+// go test ./pain -update 로 golden file을 다시 만든다.
+var update = flag.Bool("update", false, "update golden files")
+
+func readInitiation(t *testing.T) Initiation {
+	f, err := os.Open(filepath.Join("testdata", "pain001.xml"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	initiation, err := ParseInitiation(f)
+	require.NoError(t, err)
+	return initiation
+}
+
+func TestParseInitiation(t *testing.T) {
+	initiation := readInitiation(t)
+	require.Equal(t, "PAYROLL-2022-03", initiation.MessageID)
+	require.Len(t, initiation.Payments, 2)
+	require.Equal(t, 5, initiation.TransactionCount())
+
+	payment := initiation.Payments[0]
+	require.Equal(t, "1", payment.DebtorAccount)
+	require.Equal(t, "USD", payment.DebtorCurrency)
+	require.Equal(t, CreditTransfer{
+		InstructionID:   "I-1",
+		EndToEndID:      "SALARY-BOB",
+		Amount:          "100.25",
+		Currency:        "USD",
+		CreditorName:    "Bob",
+		CreditorAccount: "2",
+		Remittance:      "March salary",
+	}, payment.Transactions[0])
+
+	_, ok := initiation.CheckTotals()
+	require.True(t, ok)
+
+	_, err := ParseInitiation(strings.NewReader("<Document><CstmrCdtTrfInitn>"))
+	require.ErrorIs(t, err, ErrInvalidInitiation)
+	_, err = ParseInitiation(strings.NewReader("<Document><CstmrCdtTrfInitn><GrpHdr><MsgId>1</MsgId></GrpHdr></CstmrCdtTrfInitn></Document>"))
+	require.ErrorIs(t, err, ErrInvalidInitiation)
+}
+
+func TestCheckTotals(t *testing.T) {
+	initiation := readInitiation(t)
+
+	wrongCount := initiation
+	wrongCount.NumberOfTxs = "4"
+	reason, ok := wrongCount.CheckTotals()
+	require.False(t, ok)
+	require.Equal(t, ReasonInvalidNumberOfTxs, reason)
+
+	wrongSum := initiation
+	wrongSum.ControlSum = "6100.7"
+	reason, ok = wrongSum.CheckTotals()
+	require.False(t, ok)
+	require.Equal(t, ReasonInvalidControlSum, reason)
+}
+
+func TestParseAmount(t *testing.T) {
+	testCases := []struct {
+		value    string
+		exponent int
+		amount   int64
+		valid    bool
+	}{
+		{"12.30", 2, 1230, true},
+		{"12.3", 2, 1230, true},
+		{"12", 2, 1200, true},
+		{"1000", 0, 1000, true},
+		{"12.345", 2, 0, false},
+		{"10.5", 0, 0, false},
+		{"-1.00", 2, 0, false},
+		{"0.00", 2, 0, false},
+		{".50", 2, 0, false},
+		{"1e3", 2, 0, false},
+		{"99999999999999999999", 2, 0, false},
+	}
+	for _, tc := range testCases {
+		amount, err := ParseAmount(tc.value, tc.exponent)
+		if !tc.valid {
+			require.ErrorIs(t, err, ErrInvalidAmount, tc.value)
+			continue
+		}
+		require.NoError(t, err, tc.value)
+		require.Equal(t, tc.amount, amount, tc.value)
+	}
+}
+
+func TestImport(t *testing.T) {
+	owner := "alice"
+	accounts := []db.Account{
+		{ID: 1, Owner: owner, Currency: util.USD},
+		{ID: 2, Owner: "bob", Currency: util.USD},
+		{ID: 3, Owner: "carol", Currency: util.USD},
+		{ID: 4, Owner: "dave", Currency: util.USD},
+	}
+
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	store := mockdb.NewMockStore(mockController)
+	store.EXPECT().
+		CreatePaymentInitiation(gomock.Any(), gomock.Eq(db.CreatePaymentInitiationParams{Owner: owner, MessageID: "PAYROLL-2022-03"})).
+		Times(1).
+		Return(db.PaymentInitiation{ID: 1}, nil)
+	// InstrId가 있는 거래 4개가 기록되고, 그 중 거절된 I-2와 I-4는 지워진다.
+	var instructionID int64
+	store.EXPECT().
+		CreatePaymentInstruction(gomock.Any(), gomock.Any()).
+		Times(4).
+		DoAndReturn(func(_ context.Context, arg db.CreatePaymentInstructionParams) (db.PaymentInstruction, error) {
+			require.Equal(t, owner, arg.Owner)
+			require.Equal(t, "PAYROLL-2022-03", arg.MessageID)
+			instructionID++
+			return db.PaymentInstruction{ID: instructionID, InstructionID: arg.InstructionID}, nil
+		})
+	store.EXPECT().DeletePaymentInstruction(gomock.Any(), gomock.Eq(int64(2))).Times(1).Return(nil)
+	store.EXPECT().DeletePaymentInstruction(gomock.Any(), gomock.Eq(int64(4))).Times(1).Return(nil)
+	store.EXPECT().ListAccountsByIDs(gomock.Any(), gomock.Any()).Times(1).Return(accounts, nil)
+	store.EXPECT().
+		TransferTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.TransferTxParams) (db.TransferTxResult, error) {
+			require.Equal(t, int64(1), arg.FromAccountID)
+			require.Equal(t, int64(2), arg.ToAccountID)
+			require.Equal(t, int64(10025), arg.Amount)
+			require.Equal(t, "SALARY-BOB", arg.Reference)
+			require.Equal(t, "March salary", arg.Description)
+			require.Contains(t, string(arg.Metadata), "PAYROLL-2022-03")
+			return db.TransferTxResult{Transfer: db.Transfer{ID: 77}}, nil
+		})
+	// 6000.00 USD는 승인 한도 5000.00을 넘는다.
+	store.EXPECT().
+		CreateTransferApproval(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.CreateTransferApprovalParams) (db.TransferApproval, error) {
+			require.Equal(t, owner, arg.Initiator)
+			require.Equal(t, int64(600000), arg.Amount)
+			return db.TransferApproval{ID: 5}, nil
+		})
+
+	importer := NewImporter(store, map[string]int64{util.USD: 500000}, db.PayeeCoolingOff{})
+	importer.now = func() time.Time { return time.Date(2022, 3, 25, 9, 1, 0, 0, time.UTC) }
+	report := importer.Import(context.Background(), owner, readInitiation(t))
+
+	require.Equal(t, StatusPartiallyAccepted, report.GroupStatus)
+	require.Len(t, report.Transactions, 5)
+
+	want := []struct{ status, reason string }{
+		{StatusAcceptedSettlementCompleted, ""},
+		{StatusRejected, ReasonInvalidCreditorAccount},
+		{StatusPending, ""},
+		{StatusRejected, ReasonCurrencyNotAllowed},
+		{StatusRejected, ReasonTransactionForbidden},
+	}
+	for i, tx := range report.Transactions {
+		require.Equal(t, want[i].status, tx.Status, tx.EndToEndID)
+		require.Equal(t, want[i].reason, tx.Reason, tx.EndToEndID)
+	}
+	require.Equal(t, "transfer-77", report.Transactions[0].ServicerReference)
+	require.Equal(t, "approval-5", report.Transactions[2].ServicerReference)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteStatusReport(&buf, report))
+	checkGolden(t, "pain002.golden", buf.Bytes())
+}
+
+func TestImportRejectsWrongTotals(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	store := mockdb.NewMockStore(mockController)
+	store.EXPECT().CreatePaymentInitiation(gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().ListAccountsByIDs(gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+
+	initiation := readInitiation(t)
+	initiation.ControlSum = "1.00"
+	report := NewImporter(store, nil, db.PayeeCoolingOff{}).Import(context.Background(), "alice", initiation)
+	require.Equal(t, StatusRejected, report.GroupStatus)
+	require.Equal(t, ReasonInvalidControlSum, report.GroupReason)
+	require.Empty(t, report.Transactions)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteStatusReport(&buf, report))
+	var doc reportDocument
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+	require.Equal(t, ReasonInvalidControlSum, doc.Report.OriginalGroup.Reason.Code)
+}
+
+func TestImportFailedTransfer(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	store := mockdb.NewMockStore(mockController)
+	store.EXPECT().CreatePaymentInitiation(gomock.Any(), gomock.Any()).Times(1).Return(db.PaymentInitiation{ID: 1}, nil)
+	store.EXPECT().CreatePaymentInstruction(gomock.Any(), gomock.Any()).Times(1).Return(db.PaymentInstruction{ID: 7}, nil)
+	store.EXPECT().ListAccountsByIDs(gomock.Any(), gomock.Any()).Times(1).Return([]db.Account{
+		{ID: 1, Owner: "alice", Currency: util.USD},
+		{ID: 2, Owner: "bob", Currency: util.USD},
+	}, nil)
+	store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).Return(db.TransferTxResult{}, db.ErrInsufficientFunds)
+	// 실행되지 않은 거래의 InstrId는 다시 보낼 수 있어야 한다.
+	store.EXPECT().DeletePaymentInstruction(gomock.Any(), gomock.Eq(int64(7))).Times(1).Return(nil)
+
+	report := NewImporter(store, nil, db.PayeeCoolingOff{}).Import(context.Background(), "alice", singleTransferInitiation(t))
+	require.Equal(t, StatusRejected, report.GroupStatus)
+	require.Equal(t, ReasonInsufficientFunds, report.Transactions[0].Reason)
+}
+
+func TestImportDuplicateMessage(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	store := mockdb.NewMockStore(mockController)
+	store.EXPECT().CreatePaymentInitiation(gomock.Any(), gomock.Any()).Times(1).Return(db.PaymentInitiation{}, &pq.Error{Code: "23505"})
+	store.EXPECT().ListAccountsByIDs(gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+
+	report := NewImporter(store, nil, db.PayeeCoolingOff{}).Import(context.Background(), "alice", readInitiation(t))
+	require.Equal(t, StatusRejected, report.GroupStatus)
+	require.Equal(t, ReasonDuplicate, report.GroupReason)
+	require.Empty(t, report.Transactions)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteStatusReport(&buf, report))
+	var doc reportDocument
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+	require.Equal(t, ReasonDuplicate, doc.Report.OriginalGroup.Reason.Code)
+}
+
+func TestImportDuplicateInstruction(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	store := mockdb.NewMockStore(mockController)
+	store.EXPECT().CreatePaymentInitiation(gomock.Any(), gomock.Any()).Times(1).Return(db.PaymentInitiation{ID: 2}, nil)
+	store.EXPECT().CreatePaymentInstruction(gomock.Any(), gomock.Any()).Times(1).Return(db.PaymentInstruction{}, &pq.Error{Code: "23505"})
+	store.EXPECT().ListAccountsByIDs(gomock.Any(), gomock.Any()).Times(1).Return([]db.Account{
+		{ID: 1, Owner: "alice", Currency: util.USD},
+		{ID: 2, Owner: "bob", Currency: util.USD},
+	}, nil)
+	store.EXPECT().DeletePaymentInstruction(gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+
+	report := NewImporter(store, nil, db.PayeeCoolingOff{}).Import(context.Background(), "alice", singleTransferInitiation(t))
+	require.Equal(t, StatusRejected, report.GroupStatus)
+	require.Equal(t, ReasonDuplicate, report.Transactions[0].Reason)
+}
+
+func TestImportCoolingOff(t *testing.T) {
+	now := time.Date(2022, 3, 25, 9, 1, 0, 0, time.UTC)
+
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	store := mockdb.NewMockStore(mockController)
+	store.EXPECT().CreatePaymentInitiation(gomock.Any(), gomock.Any()).Times(1).Return(db.PaymentInitiation{ID: 3}, nil)
+	store.EXPECT().CreatePaymentInstruction(gomock.Any(), gomock.Any()).Times(1).Return(db.PaymentInstruction{ID: 8}, nil)
+	store.EXPECT().ListAccountsByIDs(gomock.Any(), gomock.Any()).Times(1).Return([]db.Account{
+		{ID: 1, Owner: "alice", Currency: util.USD},
+		{ID: 2, Owner: "bob", Currency: util.USD},
+	}, nil)
+	// bob의 계좌는 한 시간 전에 payee로 저장됐다.
+	store.EXPECT().
+		GetRecipientKnownSince(gomock.Any(), gomock.Eq(db.GetRecipientKnownSinceParams{Owner: "alice", ToAccountID: 2, FromAccountID: 1})).
+		Times(1).
+		Return(sql.NullTime{Time: now.Add(-time.Hour), Valid: true}, nil)
+	store.EXPECT().DeletePaymentInstruction(gomock.Any(), gomock.Eq(int64(8))).Times(1).Return(nil)
+	store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+
+	coolingOff := db.PayeeCoolingOff{Limits: map[string]int64{util.USD: 10000}, Period: 24 * time.Hour}
+	importer := NewImporter(store, nil, coolingOff)
+	importer.now = func() time.Time { return now }
+	report := importer.Import(context.Background(), "alice", singleTransferInitiation(t))
+	require.Equal(t, StatusRejected, report.GroupStatus)
+	require.Equal(t, ReasonAmountNotAllowed, report.Transactions[0].Reason)
+	require.Contains(t, report.Transactions[0].Info, db.ErrCoolingOff.Error())
+}
+
+// singleTransferInitiation keeps only the first credit transfer of the test document, 100.25 USD from account 1 to 2.
+func singleTransferInitiation(t *testing.T) Initiation {
+	initiation := readInitiation(t)
+	initiation.Payments = initiation.Payments[:1]
+	initiation.Payments[0].Transactions = initiation.Payments[0].Transactions[:1]
+	initiation.NumberOfTxs, initiation.ControlSum = "1", ""
+	return initiation
+}
+
+func checkGolden(t *testing.T, name string, got []byte) {
+	path := filepath.Join("testdata", name)
+	if *update {
+		require.NoError(t, os.WriteFile(path, got, 0644))
+	}
+	want, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, string(want), string(got))
+}
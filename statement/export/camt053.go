@@ -0,0 +1,255 @@
+package export
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gyu-young-park/simplebank/statement"
+)
+
+const camt053Namespace = "urn:iso:std:iso:20022:tech:xsd:camt.053.001.02"
+
+// camt.053.001.02의 element들. XSD의 순서를 그대로 따라야 검증을 통과한다.
+type camtDocument struct {
+	XMLName xml.Name     `xml:"Document"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	Report  camtBkToCstm `xml:"BkToCstmrStmt"`
+}
+
+type camtBkToCstm struct {
+	GroupHeader camtGroupHeader `xml:"GrpHdr"`
+	Statement   camtStatement   `xml:"Stmt"`
+}
+
+type camtGroupHeader struct {
+	MessageID string `xml:"MsgId"`
+	CreatedAt string `xml:"CreDtTm"`
+}
+
+type camtStatement struct {
+	ID        string        `xml:"Id"`
+	CreatedAt string        `xml:"CreDtTm"`
+	FromTo    camtFromTo    `xml:"FrToDt"`
+	Account   camtAccount   `xml:"Acct"`
+	Balances  []camtBalance `xml:"Bal"`
+	Summary   camtSummary   `xml:"TxsSummry"`
+	Entries   []camtEntry   `xml:"Ntry"`
+}
+
+type camtFromTo struct {
+	From string `xml:"FrDtTm"`
+	To   string `xml:"ToDtTm"`
+}
+
+type camtAccount struct {
+	ID       camtAccountID `xml:"Id"`
+	Currency string        `xml:"Ccy"`
+	Owner    *camtParty    `xml:"Ownr,omitempty"`
+}
+
+type camtAccountID struct {
+	Other camtOther `xml:"Othr"`
+}
+
+type camtOther struct {
+	ID string `xml:"Id"`
+}
+
+type camtParty struct {
+	Name string `xml:"Nm"`
+}
+
+type camtBalance struct {
+	Code        string     `xml:"Tp>CdOrPrtry>Cd"`
+	Amount      camtAmount `xml:"Amt"`
+	CreditDebit string     `xml:"CdtDbtInd"`
+	Date        string     `xml:"Dt>Dt"`
+}
+
+type camtAmount struct {
+	Currency string `xml:"Ccy,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type camtSummary struct {
+	Total   camtTotal `xml:"TtlNtries"`
+	Credits camtSum   `xml:"TtlCdtNtries"`
+	Debits  camtSum   `xml:"TtlDbtNtries"`
+}
+
+type camtTotal struct {
+	Count       int    `xml:"NbOfNtries"`
+	Sum         string `xml:"Sum"`
+	Net         string `xml:"TtlNetNtryAmt"`
+	CreditDebit string `xml:"CdtDbtInd"`
+}
+
+type camtSum struct {
+	Count int    `xml:"NbOfNtries"`
+	Sum   string `xml:"Sum"`
+}
+
+type camtEntry struct {
+	Reference   string          `xml:"NtryRef"`
+	Amount      camtAmount      `xml:"Amt"`
+	CreditDebit string          `xml:"CdtDbtInd"`
+	Status      string          `xml:"Sts"`
+	BookingDate string          `xml:"BookgDt>DtTm"`
+	ValueDate   string          `xml:"ValDt>Dt"`
+	ServicerRef string          `xml:"AcctSvcrRef"`
+	BankCode    camtProprietary `xml:"BkTxCd>Prtry"`
+	Details     camtTxDetails   `xml:"NtryDtls>TxDtls"`
+}
+
+type camtProprietary struct {
+	Code   string `xml:"Cd"`
+	Issuer string `xml:"Issr"`
+}
+
+type camtTxDetails struct {
+	EndToEndID string            `xml:"Refs>EndToEndId"`
+	Parties    *camtRelatedParty `xml:"RltdPties,omitempty"`
+	Remittance *camtRemittance   `xml:"RmtInf,omitempty"`
+}
+
+type camtRelatedParty struct {
+	Debtor   *camtParty `xml:"Dbtr,omitempty"`
+	Creditor *camtParty `xml:"Cdtr,omitempty"`
+}
+
+type camtRemittance struct {
+	Unstructured string `xml:"Ustrd"`
+}
+
+const (
+	camtCredit = "CRDT"
+	camtDebit  = "DBIT"
+)
+
+func creditDebit(amount int64) string {
+	if amount < 0 {
+		return camtDebit
+	}
+	return camtCredit
+}
+
+// WriteCAMT053 writes the statement as an ISO 20022 camt.053.001.02 bank-to-customer statement.
+func WriteCAMT053(w io.Writer, s statement.Statement) error {
+	c, err := lookupCurrency(s.Currency)
+	if err != nil {
+		return err
+	}
+	amount := func(n int64) camtAmount {
+		return camtAmount{Currency: c.code, Value: formatAmount(n, c.exponent, ".", false)}
+	}
+	createdAt := s.GeneratedAt.UTC().Format(time.RFC3339)
+	id := fmt.Sprintf("%d-%s", s.AccountID, s.From.UTC().Format("20060102"))
+
+	doc := camtDocument{
+		Xmlns: camt053Namespace,
+		Report: camtBkToCstm{
+			GroupHeader: camtGroupHeader{MessageID: "STMT-" + id, CreatedAt: createdAt},
+			Statement: camtStatement{
+				ID:        id,
+				CreatedAt: createdAt,
+				FromTo: camtFromTo{
+					From: s.From.UTC().Format(time.RFC3339),
+					// camt.053의 ToDtTm은 기간에 포함되므로 마지막 1초를 쓴다.
+					To: s.To.Add(-time.Second).UTC().Format(time.RFC3339),
+				},
+				Account: camtAccount{
+					ID:       camtAccountID{Other: camtOther{ID: fmt.Sprint(s.AccountID)}},
+					Currency: c.code,
+				},
+				Balances: []camtBalance{
+					{Code: "OPBD", Amount: amount(s.OpeningBalance), CreditDebit: creditDebit(s.OpeningBalance), Date: s.From.UTC().Format("2006-01-02")},
+					{Code: "CLBD", Amount: amount(s.ClosingBalance), CreditDebit: creditDebit(s.ClosingBalance), Date: lastDay(s).Format("2006-01-02")},
+				},
+			},
+		},
+	}
+	if s.Owner != "" {
+		doc.Report.Statement.Account.Owner = &camtParty{Name: s.Owner}
+	}
+
+	var credits, debits camtSum
+	var creditSum, debitSum int64
+	for _, line := range s.Lines {
+		if line.Amount < 0 {
+			debits.Count++
+			debitSum -= line.Amount
+		} else {
+			credits.Count++
+			creditSum += line.Amount
+		}
+		doc.Report.Statement.Entries = append(doc.Report.Statement.Entries, camtEntryFor(line, amount(line.Amount)))
+	}
+	credits.Sum = formatAmount(creditSum, c.exponent, ".", false)
+	debits.Sum = formatAmount(debitSum, c.exponent, ".", false)
+	doc.Report.Statement.Summary = camtSummary{
+		Total: camtTotal{
+			Count:       len(s.Lines),
+			Sum:         formatAmount(creditSum+debitSum, c.exponent, ".", false),
+			Net:         formatAmount(creditSum-debitSum, c.exponent, ".", false),
+			CreditDebit: creditDebit(creditSum - debitSum),
+		},
+		Credits: credits,
+		Debits:  debits,
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "\n")
+	return err
+}
+
+func camtEntryFor(line statement.Line, amount camtAmount) camtEntry {
+	entry := camtEntry{
+		Reference:   fmt.Sprint(line.EntryID),
+		Amount:      amount,
+		CreditDebit: creditDebit(line.Amount),
+		Status:      "BOOK",
+		BookingDate: line.PostedAt.UTC().Format(time.RFC3339),
+		ValueDate:   line.PostedAt.UTC().Format("2006-01-02"),
+		ServicerRef: fmt.Sprint(line.EntryID),
+		BankCode:    camtProprietary{Code: line.Kind, Issuer: "SIMPLEBANK"},
+		Details:     camtTxDetails{EndToEndID: "NOTPROVIDED"},
+	}
+	if line.Reference != "" {
+		entry.Details.EndToEndID = line.Reference
+	}
+	if line.CounterpartyName != "" {
+		party := &camtParty{Name: line.CounterpartyName}
+		// 돈이 나간 entry의 상대방은 받는 쪽(creditor), 들어온 entry의 상대방은 보낸 쪽(debtor)이다.
+		if line.Amount < 0 {
+			entry.Details.Parties = &camtRelatedParty{Creditor: party}
+		} else {
+			entry.Details.Parties = &camtRelatedParty{Debtor: party}
+		}
+	}
+	if line.Description != "" {
+		entry.Details.Remittance = &camtRemittance{Unstructured: truncate(line.Description, 140)}
+	}
+	return entry
+}
+
+// lastDay is the last calendar day the statement covers.
+func lastDay(s statement.Statement) time.Time {
+	return s.To.Add(-time.Nanosecond).UTC()
+}
+
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
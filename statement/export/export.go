@@ -0,0 +1,85 @@
+// Package export writes statements in the file formats accounting software imports.
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/gyu-young-park/simplebank/statement"
+	"github.com/gyu-young-park/simplebank/util"
+)
+
+const (
+	FormatCAMT053 = "camt053"
+	FormatMT940   = "mt940"
+)
+
+var ErrUnsupportedFormat = errors.New("export format must be camt053 or mt940")
+
+// Supported reports whether format is one of the export formats.
+func Supported(format string) bool {
+	return format == FormatCAMT053 || format == FormatMT940
+}
+
+// ContentType returns the MIME type of an exported statement.
+func ContentType(format string) string {
+	if format == FormatCAMT053 {
+		return "application/xml"
+	}
+	return "text/plain"
+}
+
+// Extension returns the file extension accounting software expects.
+func Extension(format string) string {
+	if format == FormatCAMT053 {
+		return "xml"
+	}
+	return "sta"
+}
+
+// Render writes the statement in the given export format.
+func Render(w io.Writer, s statement.Statement, format string) error {
+	switch format {
+	case FormatCAMT053:
+		return WriteCAMT053(w, s)
+	case FormatMT940:
+		return WriteMT940(w, s)
+	}
+	return ErrUnsupportedFormat
+}
+
+// currency is the ISO 4217 code and minor unit exponent of a statement currency.
+type currency struct {
+	code     string
+	exponent int
+}
+
+func lookupCurrency(code string) (currency, error) {
+	isoCode, ok := util.ISOCurrencyCode(code)
+	if !ok {
+		return currency{}, fmt.Errorf("cannot export currency %s", code)
+	}
+	return currency{code: isoCode, exponent: util.CurrencyExponent(code)}, nil
+}
+
+// formatAmount writes the absolute value of a minor-unit amount with the given decimal separator.
+// exponent가 0이어도 separator는 남긴다. MT940에서는 "100,"처럼 쉼표가 필수이다.
+func formatAmount(amount int64, exponent int, separator string, keepSeparator bool) string {
+	if amount < 0 {
+		amount = -amount
+	}
+	digits := strconv.FormatInt(amount, 10)
+	if exponent == 0 {
+		if keepSeparator {
+			return digits + separator
+		}
+		return digits
+	}
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+	return digits[:len(digits)-exponent] + separator + digits[len(digits)-exponent:]
+}
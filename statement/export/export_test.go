@@ -0,0 +1,124 @@
+package export
+
+import (
+	"bytes"
+	"encoding/xml"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gyu-young-park/simplebank/statement"
+	"github.com/gyu-young-park/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+// go test ./statement/export -update 로 golden file을 다시 만든다.
+var update = flag.Bool("update", false, "update golden files")
+
+func testStatement(currency string) statement.Statement {
+	from := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	return statement.Statement{
+		AccountID:      42,
+		Owner:          "alice",
+		Currency:       currency,
+		Period:         "2022-03",
+		From:           from,
+		To:             from.AddDate(0, 1, 0),
+		OpeningBalance: 10000,
+		TotalCredits:   2550,
+		TotalDebits:    1205,
+		ClosingBalance: 11345,
+		GeneratedAt:    time.Date(2022, 4, 1, 1, 0, 0, 0, time.UTC),
+		Lines: []statement.Line{
+			{
+				EntryID:          101,
+				PostedAt:         time.Date(2022, 3, 3, 9, 30, 0, 0, time.UTC),
+				Kind:             "transfer",
+				Description:      "March rent",
+				Reference:        "INV-2022-03",
+				TransferID:       7,
+				CounterpartyName: "Bob Landlord",
+				Amount:           -1205,
+				Balance:          8795,
+			},
+			{
+				EntryID:     102,
+				PostedAt:    time.Date(2022, 3, 15, 12, 0, 0, 0, time.UTC),
+				Kind:        "deposit",
+				Description: "branch deposit",
+				Amount:      2500,
+				Balance:     11295,
+			},
+			{
+				EntryID:          103,
+				PostedAt:         time.Date(2022, 3, 31, 23, 59, 0, 0, time.UTC),
+				Kind:             "transfer",
+				TransferID:       9,
+				CounterpartyName: "김철수",
+				Amount:           50,
+				Balance:          11345,
+			},
+		},
+	}
+}
+
+func checkGolden(t *testing.T, name string, got []byte) {
+	path := filepath.Join("testdata", name)
+	if *update {
+		require.NoError(t, os.WriteFile(path, got, 0644))
+	}
+	want, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, string(want), string(got))
+}
+
+func TestCAMT053(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Render(&buf, testStatement(util.USD), FormatCAMT053))
+	checkGolden(t, "camt053_usd.golden", buf.Bytes())
+
+	// 다시 읽을 수 있는 XML이어야 한다.
+	var doc camtDocument
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+	require.Len(t, doc.Report.Statement.Entries, 3)
+	require.Equal(t, "12.05", doc.Report.Statement.Entries[0].Amount.Value)
+}
+
+func TestCAMT053WithoutDecimals(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Render(&buf, testStatement(util.WON), FormatCAMT053))
+	checkGolden(t, "camt053_krw.golden", buf.Bytes())
+}
+
+func TestMT940(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Render(&buf, testStatement(util.USD), FormatMT940))
+	checkGolden(t, "mt940_usd.golden", buf.Bytes())
+}
+
+func TestMT940WithoutDecimals(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Render(&buf, testStatement(util.WON), FormatMT940))
+	checkGolden(t, "mt940_krw.golden", buf.Bytes())
+}
+
+func TestUnsupported(t *testing.T) {
+	var buf bytes.Buffer
+	require.ErrorIs(t, Render(&buf, testStatement(util.USD), "csv"), ErrUnsupportedFormat)
+	require.Error(t, Render(&buf, testStatement("XYZ"), FormatMT940))
+}
+
+func TestFormatAmount(t *testing.T) {
+	require.Equal(t, "0.05", formatAmount(5, 2, ".", false))
+	require.Equal(t, "12.05", formatAmount(-1205, 2, ".", false))
+	require.Equal(t, "100,", formatAmount(100, 0, ",", true))
+	require.Equal(t, "100", formatAmount(100, 0, ".", false))
+}
+
+func TestMT940Information(t *testing.T) {
+	line := statement.Line{Description: ":" + string(bytes.Repeat([]byte("a"), 64)) + "-next"}
+	info := mt940Information(line)
+	require.Equal(t, "."+string(bytes.Repeat([]byte("a"), 64))+"\r\n.next", info)
+}
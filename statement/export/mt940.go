@@ -0,0 +1,125 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gyu-young-park/simplebank/statement"
+)
+
+// SWIFT 메시지는 CRLF로 줄을 나눈다.
+const mt940LineBreak = "\r\n"
+
+// WriteMT940 writes the statement as the text block of a SWIFT MT940 customer statement message.
+func WriteMT940(w io.Writer, s statement.Statement) error {
+	c, err := lookupCurrency(s.Currency)
+	if err != nil {
+		return err
+	}
+	balance := func(tag string, amount int64, day string) string {
+		return fmt.Sprintf(":%s:%s%s%s%s", tag, mt940Mark(amount), day, c.code, formatAmount(amount, c.exponent, ",", true))
+	}
+
+	from := s.From.UTC()
+	lines := []string{
+		":20:" + swiftText(fmt.Sprintf("%d-%s", s.AccountID, from.Format("060102")), 16),
+		":25:" + fmt.Sprint(s.AccountID),
+		// statement 번호는 기간이 시작하는 연월(YYMM)로 매긴다.
+		fmt.Sprintf(":28C:%s/1", from.Format("0601")),
+		balance("60F", s.OpeningBalance, from.Format("060102")),
+	}
+	for _, line := range s.Lines {
+		posted := line.PostedAt.UTC()
+		// "//" 뒤는 bank reference이므로 customer reference에는 들어가면 안 된다.
+		customerRef := strings.ReplaceAll(swiftText(line.Reference, 16), "//", "/.")
+		if customerRef == "" {
+			customerRef = "NONREF"
+		}
+		lines = append(lines, fmt.Sprintf(":61:%s%s%s%s%s%s//%d",
+			posted.Format("060102"),
+			posted.Format("0102"),
+			mt940Mark(line.Amount),
+			formatAmount(line.Amount, c.exponent, ",", true),
+			mt940TransactionType(line.Kind),
+			customerRef,
+			line.EntryID,
+		))
+		if info := mt940Information(line); info != "" {
+			lines = append(lines, ":86:"+info)
+		}
+	}
+	lines = append(lines, balance("62F", s.ClosingBalance, lastDay(s).Format("060102")), "-")
+
+	_, err = io.WriteString(w, strings.Join(lines, mt940LineBreak)+mt940LineBreak)
+	return err
+}
+
+func mt940Mark(amount int64) string {
+	if amount < 0 {
+		return "D"
+	}
+	return "C"
+}
+
+// mt940TransactionType maps a journal kind to a SWIFT transaction type identification code.
+func mt940TransactionType(kind string) string {
+	switch kind {
+	case "transfer":
+		return "NTRF"
+	case "deposit", "withdrawal":
+		return "NCHK"
+	case "interest":
+		return "NINT"
+	case "fee":
+		return "NCHG"
+	}
+	return "NMSC"
+}
+
+// mt940Information builds the :86: field. 최대 65자씩 6줄까지 쓸 수 있다.
+func mt940Information(line statement.Line) string {
+	var parts []string
+	if line.Description != "" {
+		parts = append(parts, line.Description)
+	}
+	if line.CounterpartyName != "" {
+		parts = append(parts, line.CounterpartyName)
+	}
+	text := swiftText(strings.Join(parts, " / "), 6*65)
+
+	var rows []string
+	for text != "" {
+		n := len(text)
+		if n > 65 {
+			n = 65
+		}
+		row := text[:n]
+		text = text[n:]
+		// ':'나 '-'로 시작하는 줄은 새 field나 메시지의 끝으로 읽힌다.
+		if row[0] == ':' || row[0] == '-' {
+			row = "." + row[1:]
+		}
+		rows = append(rows, row)
+	}
+	return strings.Join(rows, mt940LineBreak)
+}
+
+// swiftText keeps only the SWIFT X character set and cuts the text to n characters.
+func swiftText(s string, n int) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case strings.ContainsRune("/-?:().,'+ ", r):
+			b.WriteRune(r)
+		default:
+			b.WriteByte('.')
+		}
+		if b.Len() == n {
+			break
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
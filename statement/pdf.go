@@ -0,0 +1,137 @@
+package statement
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// PDF 레이아웃. A4 한 페이지에 고정폭 Courier 글꼴로 줄 단위 텍스트를 쓴다.
+const (
+	pdfPageWidth    = 595
+	pdfPageHeight   = 842
+	pdfMargin       = 40
+	pdfFontSize     = 8
+	pdfLeading      = 11
+	pdfLinesPerPage = (pdfPageHeight - 2*pdfMargin) / pdfLeading
+)
+
+// WritePDF renders the statement as a plain text PDF.
+// 외부 라이브러리 없이 쓰기 위해 내장 글꼴만 사용하므로 ASCII 밖의 문자는 '?'로 바뀐다.
+func WritePDF(w io.Writer, statement Statement) error {
+	lines := pdfLines(statement)
+	var pages [][]string
+	for len(lines) > 0 {
+		n := pdfLinesPerPage
+		if n > len(lines) {
+			n = len(lines)
+		}
+		pages = append(pages, lines[:n])
+		lines = lines[n:]
+	}
+
+	// object 번호: 1 catalog, 2 pages, 3 font, 그 다음 페이지마다 content stream과 page object.
+	var buf bytes.Buffer
+	offsets := make([]int, 3+2*len(pages))
+	writeObject := func(number int, body string) {
+		offsets[number-1] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", number, body)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+	writeObject(1, "<< /Type /Catalog /Pages 2 0 R >>")
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	writeObject(2, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	writeObject(3, "<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+
+	for i, page := range pages {
+		var content strings.Builder
+		fmt.Fprintf(&content, "BT\n/F1 %d Tf\n%d TL\n%d %d Td\n", pdfFontSize, pdfLeading, pdfMargin, pdfPageHeight-pdfMargin)
+		for _, line := range page {
+			fmt.Fprintf(&content, "(%s) Tj T*\n", pdfEscape(line))
+		}
+		content.WriteString("ET")
+		writeObject(4+2*i, fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.String()))
+		writeObject(5+2*i, fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, 4+2*i,
+		))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// pdfLines lays the statement out as fixed-width text lines.
+func pdfLines(statement Statement) []string {
+	const row = "%-10s %-10s %-30s %-22s %14s %14s"
+	lines := []string{
+		"ACCOUNT STATEMENT",
+		"",
+		fmt.Sprintf("Account:  #%d", statement.AccountID),
+		fmt.Sprintf("Owner:    %s", statement.Owner),
+		fmt.Sprintf("Currency: %s", statement.Currency),
+		fmt.Sprintf("Period:   %s (%s to %s)", statement.Period,
+			statement.From.Format("2006-01-02"), statement.To.AddDate(0, 0, -1).Format("2006-01-02")),
+		"",
+		fmt.Sprintf(row, "Date", "Kind", "Description", "Counterparty", "Amount", "Balance"),
+		strings.Repeat("-", 105),
+		fmt.Sprintf(row, statement.From.Format("2006-01-02"), "", "Opening balance", "", "", formatAmount(statement.OpeningBalance, statement.Currency)),
+	}
+	for _, line := range statement.Lines {
+		lines = append(lines, fmt.Sprintf(row,
+			line.PostedAt.UTC().Format("2006-01-02"),
+			truncate(line.Kind, 10),
+			truncate(line.Description, 30),
+			truncate(line.CounterpartyName, 22),
+			formatAmount(line.Amount, statement.Currency),
+			formatAmount(line.Balance, statement.Currency),
+		))
+	}
+	lines = append(lines,
+		strings.Repeat("-", 105),
+		fmt.Sprintf(row, "", "", "Total credits", "", formatAmount(statement.TotalCredits, statement.Currency), ""),
+		fmt.Sprintf(row, "", "", "Total debits", "", formatAmount(-statement.TotalDebits, statement.Currency), ""),
+		fmt.Sprintf(row, statement.To.AddDate(0, 0, -1).Format("2006-01-02"), "", "Closing balance", "", "", formatAmount(statement.ClosingBalance, statement.Currency)),
+		"",
+		fmt.Sprintf("Generated at %s", statement.GeneratedAt.UTC().Format(time.RFC3339)),
+	)
+	return lines
+}
+
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
+
+// pdfEscape escapes a PDF string literal and replaces what the built-in font cannot show.
+func pdfEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x20 || r > 0x7e:
+			b.WriteByte('?')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,90 @@
+package statement
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/gyu-young-park/simplebank/util"
+)
+
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+	FormatPDF  = "pdf"
+)
+
+var ErrUnsupportedFormat = errors.New("statement format must be json, csv or pdf")
+
+// ContentType returns the MIME type of a rendered statement.
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv"
+	case FormatPDF:
+		return "application/pdf"
+	}
+	return "application/json"
+}
+
+// Render writes the statement in the given format.
+func Render(w io.Writer, statement Statement, format string) error {
+	switch format {
+	case FormatJSON:
+		return json.NewEncoder(w).Encode(statement)
+	case FormatCSV:
+		return WriteCSV(w, statement)
+	case FormatPDF:
+		return WritePDF(w, statement)
+	}
+	return ErrUnsupportedFormat
+}
+
+// WriteCSV writes one row per entry, between an opening balance row and a closing balance row.
+// 금액은 통화의 소수 자리에 맞춰 "12.30"처럼 쓴다.
+func WriteCSV(w io.Writer, statement Statement) error {
+	writer := csv.NewWriter(w)
+	rows := [][]string{
+		{"entry_id", "posted_at", "kind", "description", "reference", "transfer_id", "counterparty_name", "amount", "balance"},
+		{"", statement.From.Format(time.RFC3339), "opening_balance", "Opening balance", "", "", "", "", formatAmount(statement.OpeningBalance, statement.Currency)},
+	}
+	for _, line := range statement.Lines {
+		rows = append(rows, []string{
+			formatInt(line.EntryID),
+			line.PostedAt.UTC().Format(time.RFC3339),
+			line.Kind,
+			line.Description,
+			line.Reference,
+			formatOptionalInt(line.TransferID),
+			line.CounterpartyName,
+			formatAmount(line.Amount, statement.Currency),
+			formatAmount(line.Balance, statement.Currency),
+		})
+	}
+	rows = append(rows, []string{"", statement.To.Format(time.RFC3339), "closing_balance", "Closing balance", "", "", "", "", formatAmount(statement.ClosingBalance, statement.Currency)})
+
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+// formatAmount writes an amount in minor units as a decimal in the currency's major unit.
+func formatAmount(amount int64, currency string) string {
+	return util.NewMoney(amount, currency).Decimal()
+}
+
+func formatInt(n int64) string {
+	return strconv.FormatInt(n, 10)
+}
+
+// formatOptionalInt leaves ids that are not set empty.
+func formatOptionalInt(n int64) string {
+	if n == 0 {
+		return ""
+	}
+	return formatInt(n)
+}
@@ -0,0 +1,94 @@
+package statement
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func randomStatement(lines int) Statement {
+	from := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	statement := Statement{
+		AccountID:      1,
+		Owner:          "alice",
+		Currency:       "USD",
+		Period:         "2022-03",
+		From:           from,
+		To:             from.AddDate(0, 1, 0),
+		OpeningBalance: 100,
+		ClosingBalance: 100 + int64(lines),
+		TotalCredits:   int64(lines),
+		GeneratedAt:    from.AddDate(0, 1, 1),
+	}
+	for i := 0; i < lines; i++ {
+		statement.Lines = append(statement.Lines, Line{
+			EntryID:     int64(i + 1),
+			PostedAt:    from.Add(time.Duration(i) * time.Hour),
+			Kind:        "deposit",
+			Description: fmt.Sprintf("deposit (%d)", i),
+			Amount:      1,
+			Balance:     101 + int64(i),
+		})
+	}
+	return statement
+}
+
+func TestRenderCSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Render(&buf, randomStatement(3), FormatCSV))
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	// header, opening, 3 entries, closing
+	require.Len(t, rows, 6)
+	require.Equal(t, "opening_balance", rows[1][2])
+	require.Equal(t, "deposit (0)", rows[2][3])
+	// 금액은 USD의 소수 두 자리로 쓴다.
+	require.Equal(t, "counterparty_name", rows[0][6])
+	require.Equal(t, "0.01", rows[2][7])
+	require.Equal(t, "1.03", rows[5][8])
+}
+
+func TestRenderJSON(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Render(&buf, randomStatement(2), FormatJSON))
+	require.NotContains(t, buf.String(), "counterparty_account_id")
+
+	var statement Statement
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &statement))
+	require.Len(t, statement.Lines, 2)
+}
+
+func TestRenderPDF(t *testing.T) {
+	var buf bytes.Buffer
+	// 한 페이지에 다 들어가지 않는 statement
+	require.NoError(t, Render(&buf, randomStatement(100), FormatPDF))
+
+	pdf := buf.String()
+	require.True(t, strings.HasPrefix(pdf, "%PDF-1.4\n"))
+	require.True(t, strings.HasSuffix(pdf, "%%EOF\n"))
+	require.Contains(t, pdf, "/Count 2")
+	require.Contains(t, pdf, `deposit \(0\)`)
+	require.Contains(t, pdf, "1.00")
+
+	// startxref는 xref table의 위치를 가리켜야 한다.
+	var xref int
+	_, err := fmt.Sscanf(pdf[strings.LastIndex(pdf, "startxref\n"):], "startxref\n%d", &xref)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(pdf[xref:], "xref\n"))
+}
+
+func TestRenderUnsupportedFormat(t *testing.T) {
+	var buf bytes.Buffer
+	require.ErrorIs(t, Render(&buf, randomStatement(1), "xml"), ErrUnsupportedFormat)
+}
+
+func TestPDFEscape(t *testing.T) {
+	require.Equal(t, `a\(b\)\\c??`, pdfEscape(`a(b)\c한글`))
+}
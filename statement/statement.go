@@ -0,0 +1,135 @@
+package statement
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+)
+
+// PeriodLayout is how a statement period is written, e.g. 2022-03.
+const PeriodLayout = "2006-01"
+
+// KindLegacy marks entries posted before the double-entry ledger, which have no journal.
+const KindLegacy = "legacy"
+
+var (
+	ErrInvalidPeriod = errors.New("period must be a month formatted as YYYY-MM")
+	ErrInvalidRange  = errors.New("statement range must end after it starts")
+)
+
+// Line is one entry on a statement.
+type Line struct {
+	EntryID     int64     `json:"entry_id"`
+	PostedAt    time.Time `json:"posted_at"`
+	Kind        string    `json:"kind"`
+	Description string    `json:"description"`
+	Reference   string    `json:"reference"`
+	TransferID  int64     `json:"transfer_id,omitempty"`
+	// 상대방의 계좌 번호는 statement에 싣지 않고 이름만 보여준다.
+	CounterpartyName string `json:"counterparty_name,omitempty"`
+	Amount           int64  `json:"amount"`
+	// 이 entry까지 반영된 잔액
+	Balance int64 `json:"balance"`
+}
+
+// Statement lists what happened on an account during one calendar month.
+type Statement struct {
+	AccountID      int64     `json:"account_id"`
+	Owner          string    `json:"owner"`
+	Currency       string    `json:"currency"`
+	Period         string    `json:"period"`
+	From           time.Time `json:"from"`
+	To             time.Time `json:"to"`
+	OpeningBalance int64     `json:"opening_balance"`
+	TotalCredits   int64     `json:"total_credits"`
+	TotalDebits    int64     `json:"total_debits"`
+	ClosingBalance int64     `json:"closing_balance"`
+	Lines          []Line    `json:"lines"`
+	GeneratedAt    time.Time `json:"generated_at"`
+}
+
+// ParsePeriod returns the first instant of the month and the first instant of the next month, in UTC.
+func ParsePeriod(period string) (from time.Time, to time.Time, err error) {
+	from, err = time.Parse(PeriodLayout, period)
+	if err != nil {
+		return from, to, ErrInvalidPeriod
+	}
+	return from, from.AddDate(0, 1, 0), nil
+}
+
+// PreviousPeriod returns the month before the one that contains t.
+func PreviousPeriod(t time.Time) string {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month()-1, 1, 0, 0, 0, 0, time.UTC).Format(PeriodLayout)
+}
+
+// Generate builds the statement of an account for a period.
+// 아직 끝나지 않은 달이면 지금까지의 entries만 들어간다.
+func Generate(ctx context.Context, store db.Store, account db.Account, period string, now time.Time) (Statement, error) {
+	from, to, err := ParsePeriod(period)
+	if err != nil {
+		return Statement{}, err
+	}
+	return generate(ctx, store, account, period, from, to, now)
+}
+
+// GenerateRange builds a statement of the entries created in [from, to).
+// period에는 "2022-03-01/2022-03-15"처럼 시작일과 마지막 날이 들어간다.
+func GenerateRange(ctx context.Context, store db.Store, account db.Account, from time.Time, to time.Time, now time.Time) (Statement, error) {
+	if !from.Before(to) {
+		return Statement{}, ErrInvalidRange
+	}
+	period := from.UTC().Format("2006-01-02") + "/" + to.Add(-time.Nanosecond).UTC().Format("2006-01-02")
+	return generate(ctx, store, account, period, from, to, now)
+}
+
+func generate(ctx context.Context, store db.Store, account db.Account, period string, from time.Time, to time.Time, now time.Time) (Statement, error) {
+	result, err := store.AccountStatementTx(ctx, db.AccountStatementTxParams{
+		AccountID: account.ID,
+		From:      from,
+		To:        to,
+	})
+	if err != nil {
+		return Statement{}, err
+	}
+
+	statement := Statement{
+		AccountID:      account.ID,
+		Owner:          account.Owner,
+		Currency:       account.Currency,
+		Period:         period,
+		From:           from,
+		To:             to,
+		OpeningBalance: result.OpeningBalance,
+		Lines:          make([]Line, 0, len(result.Entries)),
+		GeneratedAt:    now,
+	}
+	balance := result.OpeningBalance
+	for _, entry := range result.Entries {
+		balance += entry.Amount
+		if entry.Amount > 0 {
+			statement.TotalCredits += entry.Amount
+		} else {
+			statement.TotalDebits -= entry.Amount
+		}
+		kind := KindLegacy
+		if entry.JournalKind.Valid {
+			kind = entry.JournalKind.String
+		}
+		statement.Lines = append(statement.Lines, Line{
+			EntryID:          entry.ID,
+			PostedAt:         entry.CreatedAt,
+			Kind:             kind,
+			Description:      entry.Description,
+			Reference:        entry.Reference,
+			TransferID:       entry.TransferID.Int64,
+			CounterpartyName: entry.CounterpartyName.String,
+			Amount:           entry.Amount,
+			Balance:          balance,
+		})
+	}
+	statement.ClosingBalance = balance
+	return statement, nil
+}
@@ -0,0 +1,90 @@
+package statement
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/gyu-young-park/simplebank/db/mock"
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/gyu-young-park/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePeriod(t *testing.T) {
+	from, to, err := ParsePeriod("2022-12")
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC), from)
+	require.Equal(t, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), to)
+
+	_, _, err = ParsePeriod("2022-13")
+	require.ErrorIs(t, err, ErrInvalidPeriod)
+
+	require.Equal(t, "2022-12", PreviousPeriod(time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestGenerate(t *testing.T) {
+	account := db.Account{ID: 1, Owner: "alice", Currency: util.USD}
+	now := time.Now()
+	from := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	store := mockdb.NewMockStore(mockController)
+	store.EXPECT().
+		AccountStatementTx(gomock.Any(), gomock.Eq(db.AccountStatementTxParams{AccountID: 1, From: from, To: to})).
+		Times(1).
+		Return(db.AccountStatementTxResult{
+			OpeningBalance: 100,
+			Entries: []db.ListStatementEntriesRow{
+				{
+					ID:                    10,
+					Amount:                -30,
+					Description:           "rent",
+					JournalKind:           sql.NullString{String: db.JournalKindTransfer, Valid: true},
+					TransferID:            sql.NullInt64{Int64: 5, Valid: true},
+					CounterpartyAccountID: sql.NullInt64{Int64: 2, Valid: true},
+					CounterpartyName:      sql.NullString{String: "Bob", Valid: true},
+				},
+				{ID: 11, Amount: 50},
+			},
+		}, nil)
+
+	statement, err := Generate(context.Background(), store, account, "2022-03", now)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), statement.OpeningBalance)
+	require.Equal(t, int64(50), statement.TotalCredits)
+	require.Equal(t, int64(30), statement.TotalDebits)
+	require.Equal(t, int64(120), statement.ClosingBalance)
+	require.Len(t, statement.Lines, 2)
+	require.Equal(t, int64(70), statement.Lines[0].Balance)
+	require.Equal(t, "Bob", statement.Lines[0].CounterpartyName)
+	require.Equal(t, KindLegacy, statement.Lines[1].Kind)
+}
+
+func TestGenerateRange(t *testing.T) {
+	account := db.Account{ID: 1, Owner: "alice", Currency: util.USD}
+	from := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2022, 3, 16, 0, 0, 0, 0, time.UTC)
+
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	store := mockdb.NewMockStore(mockController)
+	store.EXPECT().
+		AccountStatementTx(gomock.Any(), gomock.Eq(db.AccountStatementTxParams{AccountID: 1, From: from, To: to})).
+		Times(1).
+		Return(db.AccountStatementTxResult{OpeningBalance: 5}, nil)
+
+	statement, err := GenerateRange(context.Background(), store, account, from, to, time.Now())
+	require.NoError(t, err)
+	require.Equal(t, "2022-03-01/2022-03-15", statement.Period)
+	require.Equal(t, int64(5), statement.ClosingBalance)
+
+	_, err = GenerateRange(context.Background(), store, account, to, from, time.Now())
+	require.ErrorIs(t, err, ErrInvalidRange)
+}
@@ -23,8 +23,8 @@ func NewJWTMaker(secretKey string) (TokenMaker, error) {
 
 // 순서는 이렇게된다. 먼저 NewWithClaims로 헤더와 페이로드 부분을 넣은 구조체를 만들고 SignedString호출하는데 내부적으로 SigningString를 호출하여 헤더와 페이로드를 마샬링한 후에
 // 인코딩한다. 그 다음 key로 서명한 뒤 서명이 완료된다.
-func (maker *JWTMaker) CreateToken(username string, duration time.Duration) (string, error) {
-	payload, err := NewPayload(username, duration)
+func (maker *JWTMaker) CreateToken(username string, role string, duration time.Duration) (string, error) {
+	payload, err := NewPayload(username, role, duration)
 	if err != nil {
 		return "", err
 	}
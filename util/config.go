@@ -7,11 +7,29 @@ import (
 )
 
 type Config struct {
-	DBDriver            string        `mapstructure:"DB_DRIVER"`
-	DBSource            string        `mapstructure:"DB_SOURCE"`
-	ServerAddress       string        `mapstructure:"SERVER_ADDRESS"`
-	TokenSymmetricKey   string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
-	AccessTokenDuration time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
+	DBDriver                   string        `mapstructure:"DB_DRIVER"`
+	DBSource                   string        `mapstructure:"DB_SOURCE"`
+	ServerAddress              string        `mapstructure:"SERVER_ADDRESS"`
+	TokenSymmetricKey          string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
+	AccessTokenDuration        time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
+	SchedulerInterval          time.Duration `mapstructure:"SCHEDULER_INTERVAL"`
+	StandingOrderMaxRetries    int32         `mapstructure:"STANDING_ORDER_MAX_RETRIES"`
+	StandingOrderRetryInterval time.Duration `mapstructure:"STANDING_ORDER_RETRY_INTERVAL"`
+	HoldDuration               time.Duration `mapstructure:"HOLD_DURATION"`
+	PayeeCoolingOffPeriod      time.Duration `mapstructure:"PAYEE_COOLING_OFF_PERIOD"`
+	PayeeCoolingOffLimits      []string      `mapstructure:"PAYEE_COOLING_OFF_LIMITS"`
+	PaymentRequestTTL          time.Duration `mapstructure:"PAYMENT_REQUEST_TTL"`
+	TransferLimits             []string      `mapstructure:"TRANSFER_LIMITS"`
+	ApprovalThresholds         []string      `mapstructure:"APPROVAL_THRESHOLDS"`
+	TxMaxRetries               int           `mapstructure:"TX_MAX_RETRIES"`
+	TxRetryBackoff             time.Duration `mapstructure:"TX_RETRY_BACKOFF"`
+	ReconciliationInterval     time.Duration `mapstructure:"RECONCILIATION_INTERVAL"`
+	ReconciliationFreeze       bool          `mapstructure:"RECONCILIATION_FREEZE"`
+	BalanceSnapshotInterval    time.Duration `mapstructure:"BALANCE_SNAPSHOT_INTERVAL"`
+	StatementInterval          time.Duration `mapstructure:"STATEMENT_INTERVAL"`
+	InterestAccrualInterval    time.Duration `mapstructure:"INTEREST_ACCRUAL_INTERVAL"`
+	InterestPostingInterval    time.Duration `mapstructure:"INTEREST_POSTING_INTERVAL"`
+	MaintenanceFeeInterval     time.Duration `mapstructure:"MAINTENANCE_FEE_INTERVAL"`
 }
 
 //LoadCOnfig read configuration from file or env,
@@ -8,9 +8,47 @@ const (
 )
 
 func IsSupportedCurrency(currency string) bool {
-	switch currency {
-	case USD, EUR, CAD, WON:
-		return true
+	_, ok := currencies[currency]
+	return ok
+}
+
+// currencyInfo is the ISO 4217 metadata of a supported currency.
+type currencyInfo struct {
+	isoCode string
+	// minor unit의 소수 자리 수이다.
+	exponent int
+}
+
+// WON은 ISO 4217 코드가 아니다.
+var currencies = map[string]currencyInfo{
+	USD: {isoCode: "USD", exponent: 2},
+	EUR: {isoCode: "EUR", exponent: 2},
+	CAD: {isoCode: "CAD", exponent: 2},
+	WON: {isoCode: "KRW", exponent: 0},
+}
+
+// ISOCurrencyCode returns the ISO 4217 code used in bank files for a supported currency.
+func ISOCurrencyCode(currency string) (string, bool) {
+	info, ok := currencies[currency]
+	return info.isoCode, ok
+}
+
+// CurrencyFromISOCode returns the supported currency for an ISO 4217 code.
+func CurrencyFromISOCode(code string) (string, bool) {
+	for currency, info := range currencies {
+		if info.isoCode == code {
+			return currency, true
+		}
+	}
+	return "", false
+}
+
+// CurrencyExponent returns how many decimal places the minor unit of a currency has.
+// 금액은 최소 단위의 정수로 저장되므로 USD 1.00은 100이고 WON 100은 100이다. 모르는 통화는 2로 본다.
+func CurrencyExponent(currency string) int {
+	info, ok := currencies[currency]
+	if !ok {
+		return 2
 	}
-	return false
+	return info.exponent
 }
@@ -0,0 +1,25 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestISOCurrencyCode(t *testing.T) {
+	code, ok := ISOCurrencyCode(WON)
+	require.True(t, ok)
+	require.Equal(t, "KRW", code)
+
+	currency, ok := CurrencyFromISOCode("KRW")
+	require.True(t, ok)
+	require.Equal(t, WON, currency)
+
+	_, ok = CurrencyFromISOCode("WON")
+	require.False(t, ok)
+	_, ok = ISOCurrencyCode("XYZ")
+	require.False(t, ok)
+
+	require.Equal(t, 0, CurrencyExponent(WON))
+	require.Equal(t, 2, CurrencyExponent(USD))
+}
@@ -0,0 +1,82 @@
+package util
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// Day-count convention은 하루치 이자가 1년의 몇 분의 일인지를 정한다.
+const (
+	DayCountActual365 = "ACT/365"
+	DayCountActual360 = "ACT/360"
+	DayCountActualAct = "ACT/ACT"
+	DayCount30360     = "30/360"
+)
+
+// InterestMicrosPerUnit is how many accrual units make one minor unit of a currency.
+// 하루치 이자는 1센트보다 작은 경우가 많아서 최소 단위의 1/1000000 단위로 쌓는다.
+const InterestMicrosPerUnit = 1000000
+
+func IsSupportedDayCount(dayCount string) bool {
+	switch dayCount {
+	case DayCountActual365, DayCountActual360, DayCountActualAct, DayCount30360:
+		return true
+	}
+	return false
+}
+
+// DayFraction returns the fraction of a year that the given UTC day counts for, as numerator and denominator.
+func DayFraction(dayCount string, day time.Time) (int64, int64, error) {
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	switch dayCount {
+	case DayCountActual365:
+		return 1, 365, nil
+	case DayCountActual360:
+		return 1, 360, nil
+	case DayCountActualAct:
+		return 1, int64(daysInYear(day.Year())), nil
+	case DayCount30360:
+		return days30360(day, day.AddDate(0, 0, 1)), 360, nil
+	}
+	return 0, 0, fmt.Errorf("unsupported day count %q", dayCount)
+}
+
+// DailyInterest returns the interest earned by balance on one day, in InterestMicrosPerUnit units rounded down.
+// rateBps는 연 이자율을 basis point(0.01%) 단위로 나타낸다. 잔액이 0 이하면 이자가 없다.
+func DailyInterest(balance int64, rateBps int32, dayCount string, day time.Time) (int64, error) {
+	numerator, denominator, err := DayFraction(dayCount, day)
+	if err != nil {
+		return 0, err
+	}
+	if balance <= 0 || rateBps <= 0 {
+		return 0, nil
+	}
+	// balance * rate/10000 * InterestMicrosPerUnit * numerator/denominator
+	amount := new(big.Int).SetInt64(balance)
+	amount.Mul(amount, big.NewInt(int64(rateBps)))
+	amount.Mul(amount, big.NewInt(InterestMicrosPerUnit/10000))
+	amount.Mul(amount, big.NewInt(numerator))
+	amount.Quo(amount, big.NewInt(denominator))
+	if !amount.IsInt64() {
+		return 0, fmt.Errorf("daily interest on %d overflows", balance)
+	}
+	return amount.Int64(), nil
+}
+
+func daysInYear(year int) int {
+	return time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+}
+
+// days30360 counts the days between two dates with the 30/360 US (bond basis) rule.
+// 31일은 30일로 취급하므로 한 달의 하루하루를 더하면 항상 30일이 된다.
+func days30360(from time.Time, to time.Time) int64 {
+	d1, d2 := from.Day(), to.Day()
+	if d1 == 31 {
+		d1 = 30
+	}
+	if d2 == 31 && d1 == 30 {
+		d2 = 30
+	}
+	return int64(360*(to.Year()-from.Year()) + 30*(int(to.Month())-int(from.Month())) + d2 - d1)
+}
@@ -0,0 +1,57 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestDailyInterest(t *testing.T) {
+	testCases := []struct {
+		name     string
+		balance  int64
+		rateBps  int32
+		dayCount string
+		day      time.Time
+		want     int64
+	}{
+		// 1,000.00 * 3.65% / 365 = 0.10
+		{"Actual365", 100000, 365, DayCountActual365, day(2022, 3, 1), 10 * InterestMicrosPerUnit},
+		{"Actual360", 100000, 360, DayCountActual360, day(2022, 3, 1), 10 * InterestMicrosPerUnit},
+		{"ActualActLeapYear", 100000, 366, DayCountActualAct, day(2024, 3, 1), 10 * InterestMicrosPerUnit},
+		{"ActualActRoundsDown", 100, 150, DayCountActualAct, day(2022, 3, 1), 4109},
+		{"ZeroBalance", 0, 150, DayCountActual365, day(2022, 3, 1), 0},
+		{"NegativeBalance", -100000, 150, DayCountActual365, day(2022, 3, 1), 0},
+		{"ZeroRate", 100000, 0, DayCountActual365, day(2022, 3, 1), 0},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := DailyInterest(tc.balance, tc.rateBps, tc.dayCount, tc.day)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+
+	_, err := DailyInterest(100, 150, "ACT/999", day(2022, 3, 1))
+	require.Error(t, err)
+}
+
+func TestDayFraction30360(t *testing.T) {
+	// 30/360에서는 달의 길이와 상관없이 한 달이 30일이다.
+	for _, month := range []time.Month{time.January, time.February, time.April} {
+		var days int64
+		start := day(2022, month, 1)
+		for d := start; d.Before(start.AddDate(0, 1, 0)); d = d.AddDate(0, 0, 1) {
+			numerator, denominator, err := DayFraction(DayCount30360, d)
+			require.NoError(t, err)
+			require.Equal(t, int64(360), denominator)
+			days += numerator
+		}
+		require.Equal(t, int64(30), days, month)
+	}
+}
@@ -0,0 +1,81 @@
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TransferLimit은 계좌에서 나가는 이체의 한도이다. 0이면 제한이 없다.
+type TransferLimit struct {
+	MaxPerTransfer int64 `json:"max_per_transfer"`
+	MaxDailyAmount int64 `json:"max_daily_amount"`
+	MaxDailyCount  int32 `json:"max_daily_count"`
+}
+
+// ParseTransferLimits parses per-currency defaults written as CURRENCY:PER_TRANSFER:DAILY_AMOUNT:DAILY_COUNT.
+func ParseTransferLimits(specs []string) (map[string]TransferLimit, error) {
+	limits := make(map[string]TransferLimit)
+	for _, spec := range specs {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+		fields := strings.Split(spec, ":")
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("invalid transfer limit %q: want CURRENCY:PER_TRANSFER:DAILY_AMOUNT:DAILY_COUNT", spec)
+		}
+		currency := fields[0]
+		if !IsSupportedCurrency(currency) {
+			return nil, fmt.Errorf("invalid transfer limit %q: unsupported currency", spec)
+		}
+
+		var values [3]int64
+		for i, field := range fields[1:] {
+			value, err := strconv.ParseInt(field, 10, 64)
+			if err != nil || value < 0 {
+				return nil, fmt.Errorf("invalid transfer limit %q: %q is not a non-negative number", spec, field)
+			}
+			values[i] = value
+		}
+		limits[currency] = TransferLimit{
+			MaxPerTransfer: values[0],
+			MaxDailyAmount: values[1],
+			MaxDailyCount:  int32(values[2]),
+		}
+	}
+	return limits, nil
+}
+
+// ParseApprovalThresholds parses per-currency approval thresholds written as CURRENCY:AMOUNT.
+func ParseApprovalThresholds(specs []string) (map[string]int64, error) {
+	return parseCurrencyAmounts("approval threshold", specs)
+}
+
+// ParsePayeeCoolingOffLimits parses the per-currency amounts a new recipient can receive, written as CURRENCY:AMOUNT.
+func ParsePayeeCoolingOffLimits(specs []string) (map[string]int64, error) {
+	return parseCurrencyAmounts("payee cooling-off limit", specs)
+}
+
+func parseCurrencyAmounts(kind string, specs []string) (map[string]int64, error) {
+	amounts := make(map[string]int64)
+	for _, spec := range specs {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+		fields := strings.Split(spec, ":")
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid %s %q: want CURRENCY:AMOUNT", kind, spec)
+		}
+		if !IsSupportedCurrency(fields[0]) {
+			return nil, fmt.Errorf("invalid %s %q: unsupported currency", kind, spec)
+		}
+		amount, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil || amount <= 0 {
+			return nil, fmt.Errorf("invalid %s %q: %q is not a positive number", kind, spec, fields[1])
+		}
+		amounts[fields[0]] = amount
+	}
+	return amounts, nil
+}
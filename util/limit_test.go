@@ -0,0 +1,51 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTransferLimits(t *testing.T) {
+	limits, err := ParseTransferLimits([]string{"USD:1000:5000:10", " EUR:0:0:0 ", ""})
+	require.NoError(t, err)
+	require.Len(t, limits, 2)
+	require.Equal(t, TransferLimit{MaxPerTransfer: 1000, MaxDailyAmount: 5000, MaxDailyCount: 10}, limits[USD])
+	require.Equal(t, TransferLimit{}, limits[EUR])
+
+	_, err = ParseTransferLimits([]string{"USD:1000:5000"})
+	require.Error(t, err)
+
+	_, err = ParseTransferLimits([]string{"XYZ:1:1:1"})
+	require.Error(t, err)
+
+	_, err = ParseTransferLimits([]string{"USD:1:-1:1"})
+	require.Error(t, err)
+}
+
+func TestParseApprovalThresholds(t *testing.T) {
+	thresholds, err := ParseApprovalThresholds([]string{"USD:1000", " WON:1000000 ", ""})
+	require.NoError(t, err)
+	require.Len(t, thresholds, 2)
+	require.Equal(t, int64(1000), thresholds[USD])
+	require.Equal(t, int64(1000000), thresholds[WON])
+
+	_, err = ParseApprovalThresholds([]string{"USD"})
+	require.Error(t, err)
+
+	_, err = ParseApprovalThresholds([]string{"XYZ:1"})
+	require.Error(t, err)
+
+	_, err = ParseApprovalThresholds([]string{"USD:0"})
+	require.Error(t, err)
+}
+
+func TestParsePayeeCoolingOffLimits(t *testing.T) {
+	limits, err := ParsePayeeCoolingOffLimits([]string{"USD:100000", "WON:100000000"})
+	require.NoError(t, err)
+	require.Equal(t, int64(100000), limits[USD])
+	require.Equal(t, int64(100000000), limits[WON])
+
+	_, err = ParsePayeeCoolingOffLimits([]string{"USD:-1"})
+	require.Error(t, err)
+}
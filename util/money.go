@@ -0,0 +1,135 @@
+package util
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidAmount       = errors.New("invalid amount")
+	ErrAmountTooPrecise    = errors.New("amount has more decimal places than the currency allows")
+	ErrUnsupportedCurrency = errors.New("unsupported currency")
+)
+
+// Money is an amount in the minor units of a currency.
+// USD 12.30은 Money{Amount: 1230, Currency: USD}이고 WON 1000은 Money{Amount: 1000, Currency: WON}이다.
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+func NewMoney(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// ParseMoney converts a decimal amount such as "12.30" or "-5" in the major unit of a currency into Money.
+// 통화의 소수 자리보다 정밀한 값은 ErrAmountTooPrecise로 거절한다. 지수 표기나 천 단위 구분자는 받지 않는다.
+func ParseMoney(value string, currency string) (Money, error) {
+	if !IsSupportedCurrency(currency) {
+		return Money{}, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, currency)
+	}
+	exponent := CurrencyExponent(currency)
+
+	digits, negative := strings.TrimPrefix(value, "-"), strings.HasPrefix(value, "-")
+	whole, fraction := digits, ""
+	if i := strings.IndexByte(digits, '.'); i >= 0 {
+		whole, fraction = digits[:i], digits[i+1:]
+	}
+	if whole == "" || strings.Trim(whole+fraction, "0123456789") != "" {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, value)
+	}
+	// "12.300"처럼 뒤에 붙은 0은 정밀도를 넘지 않는다.
+	fraction = strings.TrimRight(fraction, "0")
+	if len(fraction) > exponent {
+		return Money{}, fmt.Errorf("%w: %q in %s", ErrAmountTooPrecise, value, currency)
+	}
+	fraction += strings.Repeat("0", exponent-len(fraction))
+
+	amount, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, value)
+	}
+	if negative {
+		amount = -amount
+	}
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// Exponent is the number of decimal places of the currency's minor unit.
+func (m Money) Exponent() int {
+	return CurrencyExponent(m.Currency)
+}
+
+// Decimal formats the amount in the major unit, such as "12.30" for 1230 USD cents.
+func (m Money) Decimal() string {
+	exponent := m.Exponent()
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	digits := strconv.FormatInt(amount, 10)
+	if exponent == 0 {
+		return sign + digits
+	}
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-exponent] + "." + digits[len(digits)-exponent:]
+}
+
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+// moneyJSON은 최소 단위 금액과 소수 문자열을 함께 보여주므로 클라이언트가 소수 자리를 추측하지 않아도 된다.
+type moneyJSON struct {
+	Amount   *int64 `json:"amount"`
+	Decimal  string `json:"decimal"`
+	Currency string `json:"currency"`
+	Exponent int    `json:"exponent"`
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moneyJSON{
+		Amount:   &m.Amount,
+		Decimal:  m.Decimal(),
+		Currency: m.Currency,
+		Exponent: m.Exponent(),
+	})
+}
+
+// UnmarshalJSON accepts the amount in minor units, as a decimal string, or both if they agree.
+// exponent는 무시하고 통화에서 다시 구한다.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var value moneyJSON
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	if !IsSupportedCurrency(value.Currency) {
+		return fmt.Errorf("%w: %s", ErrUnsupportedCurrency, value.Currency)
+	}
+
+	money := Money{Currency: value.Currency}
+	switch {
+	case value.Decimal != "":
+		parsed, err := ParseMoney(value.Decimal, value.Currency)
+		if err != nil {
+			return err
+		}
+		if value.Amount != nil && *value.Amount != parsed.Amount {
+			return fmt.Errorf("%w: amount %d does not match decimal %q", ErrInvalidAmount, *value.Amount, value.Decimal)
+		}
+		money = parsed
+	case value.Amount != nil:
+		money.Amount = *value.Amount
+	default:
+		return fmt.Errorf("%w: amount or decimal is required", ErrInvalidAmount)
+	}
+	*m = money
+	return nil
+}
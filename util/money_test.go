@@ -0,0 +1,77 @@
+package util
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMoney(t *testing.T) {
+	testCases := []struct {
+		value    string
+		currency string
+		amount   int64
+		err      error
+	}{
+		{"12.30", USD, 1230, nil},
+		{"12.3", USD, 1230, nil},
+		{"12", USD, 1200, nil},
+		{"0.05", EUR, 5, nil},
+		{"12.300", USD, 1230, nil},
+		{"-4.50", CAD, -450, nil},
+		{"1000", WON, 1000, nil},
+		{"1000.0", WON, 1000, nil},
+		{"12.345", USD, 0, ErrAmountTooPrecise},
+		{"10.5", WON, 0, ErrAmountTooPrecise},
+		{".50", USD, 0, ErrInvalidAmount},
+		{"1e3", USD, 0, ErrInvalidAmount},
+		{"1,000", USD, 0, ErrInvalidAmount},
+		{"", USD, 0, ErrInvalidAmount},
+		{"99999999999999999999", USD, 0, ErrInvalidAmount},
+		{"10", "XYZ", 0, ErrUnsupportedCurrency},
+	}
+	for _, tc := range testCases {
+		money, err := ParseMoney(tc.value, tc.currency)
+		if tc.err != nil {
+			require.ErrorIs(t, err, tc.err, tc.value)
+			continue
+		}
+		require.NoError(t, err, tc.value)
+		require.Equal(t, NewMoney(tc.amount, tc.currency), money, tc.value)
+	}
+}
+
+func TestMoneyDecimal(t *testing.T) {
+	require.Equal(t, "12.30", NewMoney(1230, USD).Decimal())
+	require.Equal(t, "0.05", NewMoney(5, USD).Decimal())
+	require.Equal(t, "-0.50", NewMoney(-50, EUR).Decimal())
+	require.Equal(t, "0.00", NewMoney(0, CAD).Decimal())
+	require.Equal(t, "1000", NewMoney(1000, WON).Decimal())
+	require.Equal(t, "12.30 USD", NewMoney(1230, USD).String())
+}
+
+func TestMoneyJSON(t *testing.T) {
+	data, err := json.Marshal(NewMoney(1230, USD))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"amount": 1230, "decimal": "12.30", "currency": "USD", "exponent": 2}`, string(data))
+
+	data, err = json.Marshal(NewMoney(1000, WON))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"amount": 1000, "decimal": "1000", "currency": "WON", "exponent": 0}`, string(data))
+
+	var money Money
+	require.NoError(t, json.Unmarshal([]byte(`{"decimal": "12.3", "currency": "USD"}`), &money))
+	require.Equal(t, NewMoney(1230, USD), money)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"amount": 0, "currency": "EUR"}`), &money))
+	require.Equal(t, NewMoney(0, EUR), money)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"amount": 1230, "decimal": "12.30", "currency": "USD", "exponent": 2}`), &money))
+	require.Equal(t, NewMoney(1230, USD), money)
+
+	require.ErrorIs(t, json.Unmarshal([]byte(`{"amount": 1, "decimal": "12.30", "currency": "USD"}`), &money), ErrInvalidAmount)
+	require.ErrorIs(t, json.Unmarshal([]byte(`{"decimal": "0.001", "currency": "USD"}`), &money), ErrAmountTooPrecise)
+	require.ErrorIs(t, json.Unmarshal([]byte(`{"decimal": "1", "currency": "XYZ"}`), &money), ErrUnsupportedCurrency)
+	require.ErrorIs(t, json.Unmarshal([]byte(`{"currency": "USD"}`), &money), ErrInvalidAmount)
+}
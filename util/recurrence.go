@@ -0,0 +1,87 @@
+package util
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	Daily   = "daily"
+	Weekly  = "weekly"
+	Monthly = "monthly"
+)
+
+// Recurrence는 standing order가 언제 실행될지를 나타낸다.
+// Day는 weekly일 때 요일(0 = 일요일), monthly일 때 날짜(1~31)이다. 달의 마지막 날보다 크면 마지막 날에 실행된다.
+type Recurrence struct {
+	Frequency string
+	Day       int
+}
+
+func IsSupportedFrequency(frequency string) bool {
+	switch frequency {
+	case Daily, Weekly, Monthly:
+		return true
+	}
+	return false
+}
+
+func (r Recurrence) Validate() error {
+	switch r.Frequency {
+	case Daily:
+		return nil
+	case Weekly:
+		if r.Day < 0 || r.Day > 6 {
+			return fmt.Errorf("weekly recurrence day must be between 0 and 6, got %d", r.Day)
+		}
+		return nil
+	case Monthly:
+		if r.Day < 1 || r.Day > 31 {
+			return fmt.Errorf("monthly recurrence day must be between 1 and 31, got %d", r.Day)
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported frequency %q", r.Frequency)
+}
+
+// First returns the first occurrence at or after start. 실행 시각(시/분/초)은 start를 따른다.
+func (r Recurrence) First(start time.Time) time.Time {
+	switch r.Frequency {
+	case Weekly:
+		diff := (r.Day - int(start.Weekday()) + 7) % 7
+		return start.AddDate(0, 0, diff)
+	case Monthly:
+		day := clampDay(start.Year(), start.Month(), r.Day)
+		if start.Day() <= day {
+			return start.AddDate(0, 0, day-start.Day())
+		}
+		return r.monthlyOccurrence(start, 1)
+	}
+	return start
+}
+
+// Next returns the occurrence that follows prev.
+func (r Recurrence) Next(prev time.Time) time.Time {
+	switch r.Frequency {
+	case Weekly:
+		return prev.AddDate(0, 0, 7)
+	case Monthly:
+		return r.monthlyOccurrence(prev, 1)
+	}
+	return prev.AddDate(0, 0, 1)
+}
+
+// AddDate로 달을 더하면 1월 31일 + 1달이 3월 3일이 되므로 직접 날짜를 맞춘다.
+func (r Recurrence) monthlyOccurrence(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	day := clampDay(first.Year(), first.Month(), r.Day)
+	return first.AddDate(0, 0, day-1)
+}
+
+func clampDay(year int, month time.Month, day int) int {
+	last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	if day > last {
+		return last
+	}
+	return day
+}
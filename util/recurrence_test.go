@@ -0,0 +1,44 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 9, 30, 0, 0, time.UTC)
+}
+
+func TestRecurrenceValidate(t *testing.T) {
+	require.NoError(t, Recurrence{Frequency: Daily}.Validate())
+	require.NoError(t, Recurrence{Frequency: Weekly, Day: 6}.Validate())
+	require.NoError(t, Recurrence{Frequency: Monthly, Day: 31}.Validate())
+
+	require.Error(t, Recurrence{Frequency: Weekly, Day: 7}.Validate())
+	require.Error(t, Recurrence{Frequency: Monthly, Day: 0}.Validate())
+	require.Error(t, Recurrence{Frequency: "yearly"}.Validate())
+}
+
+func TestRecurrenceFirst(t *testing.T) {
+	// 2022-03-02는 수요일이다.
+	start := date(2022, time.March, 2)
+
+	require.Equal(t, start, Recurrence{Frequency: Daily}.First(start))
+	require.Equal(t, start, Recurrence{Frequency: Weekly, Day: int(time.Wednesday)}.First(start))
+	require.Equal(t, date(2022, time.March, 4), Recurrence{Frequency: Weekly, Day: int(time.Friday)}.First(start))
+	require.Equal(t, date(2022, time.March, 7), Recurrence{Frequency: Weekly, Day: int(time.Monday)}.First(start))
+	require.Equal(t, date(2022, time.March, 15), Recurrence{Frequency: Monthly, Day: 15}.First(start))
+	require.Equal(t, date(2022, time.April, 1), Recurrence{Frequency: Monthly, Day: 1}.First(start))
+}
+
+func TestRecurrenceNext(t *testing.T) {
+	require.Equal(t, date(2022, time.March, 3), Recurrence{Frequency: Daily}.Next(date(2022, time.March, 2)))
+	require.Equal(t, date(2022, time.March, 9), Recurrence{Frequency: Weekly, Day: 3}.Next(date(2022, time.March, 2)))
+
+	monthly := Recurrence{Frequency: Monthly, Day: 31}
+	require.Equal(t, date(2022, time.February, 28), monthly.Next(date(2022, time.January, 31)))
+	require.Equal(t, date(2022, time.March, 31), monthly.Next(date(2022, time.February, 28)))
+	require.Equal(t, date(2023, time.January, 31), monthly.Next(date(2022, time.December, 31)))
+}
@@ -0,0 +1,68 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+)
+
+// snapshotDelay keeps the snapshotter away from the day boundary.
+// entries의 created_at은 트랜잭션이 시작된 시각이라서, 자정 직전에 시작된 트랜잭션이 자정 이후에 commit될 수 있다.
+const snapshotDelay = time.Hour
+
+// BalanceSnapshotter stores every account's balance at the last UTC midnight.
+type BalanceSnapshotter struct {
+	store db.Store
+	now   func() time.Time
+}
+
+func NewBalanceSnapshotter(store db.Store) *BalanceSnapshotter {
+	return &BalanceSnapshotter{
+		store: store,
+		now:   time.Now,
+	}
+}
+
+// 하루에 여러 번 실행되어도 이미 찍은 snapshot은 건너뛴다.
+func (snapshotter *BalanceSnapshotter) Run(ctx context.Context) error {
+	takenAt := snapshotter.now().UTC().Add(-snapshotDelay).Truncate(24 * time.Hour)
+
+	accountIDs, err := snapshotter.store.ListAccountIDs(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot list accounts: %w", err)
+	}
+
+	// 한 계좌가 실패해도 나머지 계좌의 snapshot은 찍는다.
+	created := 0
+	failed := 0
+	var firstErr error
+	for _, accountID := range accountIDs {
+		_, err := snapshotter.store.SnapshotBalance(ctx, db.SnapshotBalanceParams{
+			AccountID: accountID,
+			TakenAt:   takenAt,
+		})
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			log.Printf("cannot snapshot account %d: %v", accountID, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			failed++
+			continue
+		}
+		created++
+	}
+	if created > 0 {
+		log.Printf("took %d balance snapshots at %s", created, takenAt.Format(time.RFC3339))
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d balance snapshots failed, first: %w", failed, len(accountIDs), firstErr)
+	}
+	return nil
+}
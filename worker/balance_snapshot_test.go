@@ -0,0 +1,65 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/gyu-young-park/simplebank/db/mock"
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBalanceSnapshotter(t *testing.T) {
+	// 자정 직후에는 아직 전날 자정의 snapshot을 찍는다.
+	now := time.Date(2022, 3, 10, 0, 30, 0, 0, time.UTC)
+	takenAt := time.Date(2022, 3, 9, 0, 0, 0, 0, time.UTC)
+
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	store := mockdb.NewMockStore(mockController)
+	store.EXPECT().ListAccountIDs(gomock.Any()).Times(1).Return([]int64{1, 2}, nil)
+	store.EXPECT().
+		SnapshotBalance(gomock.Any(), gomock.Eq(db.SnapshotBalanceParams{AccountID: 1, TakenAt: takenAt})).
+		Times(1).
+		Return(db.BalanceSnapshot{AccountID: 1, TakenAt: takenAt}, nil)
+	// 이미 snapshot이 있는 계좌는 건너뛴다.
+	store.EXPECT().
+		SnapshotBalance(gomock.Any(), gomock.Eq(db.SnapshotBalanceParams{AccountID: 2, TakenAt: takenAt})).
+		Times(1).
+		Return(db.BalanceSnapshot{}, sql.ErrNoRows)
+
+	snapshotter := NewBalanceSnapshotter(store)
+	snapshotter.now = func() time.Time { return now }
+	require.NoError(t, snapshotter.Run(context.Background()))
+}
+
+func TestBalanceSnapshotterContinuesAfterError(t *testing.T) {
+	now := time.Date(2022, 3, 10, 12, 0, 0, 0, time.UTC)
+	takenAt := time.Date(2022, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	store := mockdb.NewMockStore(mockController)
+	store.EXPECT().ListAccountIDs(gomock.Any()).Times(1).Return([]int64{1, 2}, nil)
+	store.EXPECT().
+		SnapshotBalance(gomock.Any(), gomock.Eq(db.SnapshotBalanceParams{AccountID: 1, TakenAt: takenAt})).
+		Times(1).
+		Return(db.BalanceSnapshot{}, sql.ErrConnDone)
+	// 앞의 계좌가 실패해도 다음 계좌의 snapshot은 찍는다.
+	store.EXPECT().
+		SnapshotBalance(gomock.Any(), gomock.Eq(db.SnapshotBalanceParams{AccountID: 2, TakenAt: takenAt})).
+		Times(1).
+		Return(db.BalanceSnapshot{AccountID: 2, TakenAt: takenAt}, nil)
+
+	snapshotter := NewBalanceSnapshotter(store)
+	snapshotter.now = func() time.Time { return now }
+
+	err := snapshotter.Run(context.Background())
+	require.ErrorIs(t, err, sql.ErrConnDone)
+	require.Contains(t, err.Error(), "1 of 2 balance snapshots failed")
+}
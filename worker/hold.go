@@ -0,0 +1,35 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+)
+
+// HoldExpirer releases holds that were neither captured nor voided before they expired.
+type HoldExpirer struct {
+	store db.Store
+	now   func() time.Time
+}
+
+func NewHoldExpirer(store db.Store) *HoldExpirer {
+	return &HoldExpirer{
+		store: store,
+		now:   time.Now,
+	}
+}
+
+// 만료된 hold는 잔액 계산에서 이미 빠지므로, 여기서는 상태만 정리한다.
+func (expirer *HoldExpirer) Run(ctx context.Context) error {
+	holds, err := expirer.store.ExpireAccountHolds(ctx, expirer.now())
+	if err != nil {
+		return fmt.Errorf("cannot expire holds: %w", err)
+	}
+	if len(holds) > 0 {
+		log.Printf("expired %d holds", len(holds))
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/gyu-young-park/simplebank/db/mock"
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHoldExpirer(t *testing.T) {
+	now := time.Now()
+
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	store := mockdb.NewMockStore(mockController)
+	store.EXPECT().
+		ExpireAccountHolds(gomock.Any(), gomock.Eq(now)).
+		Times(1).
+		Return([]db.AccountHold{{ID: 1, Status: db.HoldStatusExpired}}, nil)
+
+	expirer := NewHoldExpirer(store)
+	expirer.now = func() time.Time { return now }
+	require.NoError(t, expirer.Run(context.Background()))
+}
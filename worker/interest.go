@@ -0,0 +1,163 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/gyu-young-park/simplebank/statement"
+)
+
+// maxAccrualCatchUp limits how many missed days one run accrues for an account.
+// 밀린 날짜는 현재 이자율로 계산되므로 너무 오래된 날짜까지 거슬러 올라가지 않는다.
+const maxAccrualCatchUp = 31
+
+// interestPostingDelay gives the accruer time to record the last day of the month before it is paid.
+const interestPostingDelay = 24*time.Hour + snapshotDelay
+
+// InterestAccruer records each day's interest on every account whose product pays interest.
+type InterestAccruer struct {
+	store db.Store
+	now   func() time.Time
+}
+
+func NewInterestAccruer(store db.Store) *InterestAccruer {
+	return &InterestAccruer{
+		store: store,
+		now:   time.Now,
+	}
+}
+
+// 어제까지의 이자를 쌓는다. 이미 쌓인 날짜는 건너뛰므로 여러 번 실행되어도 된다.
+func (accruer *InterestAccruer) Run(ctx context.Context) error {
+	// snapshot과 같은 이유로 자정 직후에는 전날의 commit이 남아 있을 수 있다.
+	today := accruer.now().UTC().Add(-snapshotDelay).Truncate(24 * time.Hour)
+	earliest := today.AddDate(0, 0, -maxAccrualCatchUp)
+
+	accounts, err := accruer.store.ListInterestBearingAccounts(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot list interest bearing accounts: %w", err)
+	}
+
+	// 한 계좌가 실패해도 나머지 계좌의 이자는 쌓고, 실패한 것은 마지막에 모아서 돌려준다.
+	accrued := 0
+	failed := 0
+	var firstErr error
+	for _, account := range accounts {
+		days, err := accruer.accrueAccount(ctx, account, earliest, today)
+		accrued += days
+		if err != nil {
+			log.Printf("cannot accrue interest of account %d: %v", account.ID, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			failed++
+		}
+	}
+	if accrued > 0 {
+		log.Printf("accrued %d days of interest", accrued)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d interest accruals failed, first: %w", failed, len(accounts), firstErr)
+	}
+	return nil
+}
+
+// accrueAccount accrues the account's missing days before today and returns how many it recorded.
+// 날짜 순서대로 쌓아야 하므로 하루가 실패하면 그 계좌의 나머지 날짜는 다음 실행으로 미룬다.
+func (accruer *InterestAccruer) accrueAccount(ctx context.Context, account db.Account, earliest time.Time, today time.Time) (int, error) {
+	start := account.CreatedAt.UTC().Truncate(24 * time.Hour)
+	last, err := accruer.store.GetLastInterestAccrualDate(ctx, account.ID)
+	switch {
+	case err == nil:
+		start = last.UTC().AddDate(0, 0, 1)
+	case err != sql.ErrNoRows:
+		return 0, fmt.Errorf("cannot get last accrual of account %d: %w", account.ID, err)
+	}
+	if start.Before(earliest) {
+		start = earliest
+	}
+
+	accrued := 0
+	for day := start; day.Before(today); day = day.AddDate(0, 0, 1) {
+		_, err := accruer.store.AccrueInterestTx(ctx, db.AccrueInterestTxParams{
+			AccountID: account.ID,
+			Date:      day,
+		})
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			return accrued, fmt.Errorf("cannot accrue interest of account %d on %s: %w", account.ID, day.Format("2006-01-02"), err)
+		}
+		accrued++
+	}
+	return accrued, nil
+}
+
+// InterestPoster pays last month's accrued interest into the accounts.
+type InterestPoster struct {
+	store db.Store
+	now   func() time.Time
+}
+
+func NewInterestPoster(store db.Store) *InterestPoster {
+	return &InterestPoster{
+		store: store,
+		now:   time.Now,
+	}
+}
+
+// 계좌마다 한 달에 한 번만 지급하므로 여러 번 실행되어도 된다.
+// 지급이 끝난 뒤에 늦게 쌓인 이자는 다음 달 지급에 포함된다.
+func (poster *InterestPoster) Run(ctx context.Context) error {
+	period := statement.PreviousPeriod(poster.now().Add(-interestPostingDelay))
+	_, to, err := statement.ParsePeriod(period)
+	if err != nil {
+		return err
+	}
+
+	accountIDs, err := poster.store.ListAccountsWithUnpostedInterest(ctx, to)
+	if err != nil {
+		return fmt.Errorf("cannot list accounts with unpaid interest: %w", err)
+	}
+
+	posted := 0
+	failed := 0
+	var firstErr error
+	for _, accountID := range accountIDs {
+		_, err := poster.store.PostInterestTx(ctx, db.PostInterestTxParams{
+			AccountID: accountID,
+			Period:    period,
+			Before:    to,
+		})
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if errors.Is(err, db.ErrAccountNotActive) {
+			// frozen 계좌는 풀린 뒤에 지급한다.
+			log.Printf("cannot post interest of account %d: %v", accountID, err)
+			continue
+		}
+		if err != nil {
+			log.Printf("cannot post interest of account %d: %v", accountID, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			failed++
+			continue
+		}
+		posted++
+	}
+	if posted > 0 {
+		log.Printf("posted %s interest to %d accounts", period, posted)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d interest postings failed, first: %w", failed, len(accountIDs), firstErr)
+	}
+	return nil
+}
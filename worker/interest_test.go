@@ -0,0 +1,164 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/gyu-young-park/simplebank/db/mock"
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterestAccruer(t *testing.T) {
+	now := time.Date(2022, 3, 10, 2, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time { return time.Date(2022, 3, d, 0, 0, 0, 0, time.UTC) }
+
+	// 1: 3월 7일까지 쌓였다. 2: 3월 9일에 만들어진 새 계좌이다. 3: 한 번도 쌓이지 않은 오래된 계좌이다.
+	accounts := []db.Account{
+		{ID: 1, CreatedAt: day(1)},
+		{ID: 2, CreatedAt: day(9).Add(15 * time.Hour)},
+		{ID: 3, CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	store := mockdb.NewMockStore(mockController)
+	store.EXPECT().ListInterestBearingAccounts(gomock.Any()).Times(1).Return(accounts, nil)
+	store.EXPECT().GetLastInterestAccrualDate(gomock.Any(), gomock.Eq(int64(1))).Times(1).Return(day(7), nil)
+	store.EXPECT().GetLastInterestAccrualDate(gomock.Any(), gomock.Eq(int64(2))).Times(1).Return(time.Time{}, sql.ErrNoRows)
+	store.EXPECT().GetLastInterestAccrualDate(gomock.Any(), gomock.Eq(int64(3))).Times(1).Return(time.Time{}, sql.ErrNoRows)
+
+	accrued := map[int64][]time.Time{}
+	store.EXPECT().
+		AccrueInterestTx(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(_ context.Context, arg db.AccrueInterestTxParams) (db.InterestAccrual, error) {
+			accrued[arg.AccountID] = append(accrued[arg.AccountID], arg.Date)
+			// 3월 8일은 다른 실행이 이미 쌓았다.
+			if arg.AccountID == 1 && arg.Date.Equal(day(8)) {
+				return db.InterestAccrual{}, sql.ErrNoRows
+			}
+			return db.InterestAccrual{AccountID: arg.AccountID}, nil
+		})
+
+	accruer := NewInterestAccruer(store)
+	accruer.now = func() time.Time { return now }
+	require.NoError(t, accruer.Run(context.Background()))
+
+	require.Equal(t, []time.Time{day(8), day(9)}, accrued[1])
+	require.Equal(t, []time.Time{day(9)}, accrued[2])
+	// 밀린 날짜는 maxAccrualCatchUp일까지만 쌓는다.
+	require.Len(t, accrued[3], maxAccrualCatchUp)
+	require.Equal(t, day(10).AddDate(0, 0, -maxAccrualCatchUp), accrued[3][0])
+	require.Equal(t, day(9), accrued[3][maxAccrualCatchUp-1])
+}
+
+func TestInterestAccruerContinuesAfterError(t *testing.T) {
+	now := time.Date(2022, 3, 10, 2, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time { return time.Date(2022, 3, d, 0, 0, 0, 0, time.UTC) }
+	accounts := []db.Account{{ID: 1, CreatedAt: day(1)}, {ID: 2, CreatedAt: day(1)}}
+
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	store := mockdb.NewMockStore(mockController)
+	store.EXPECT().ListInterestBearingAccounts(gomock.Any()).Times(1).Return(accounts, nil)
+	store.EXPECT().GetLastInterestAccrualDate(gomock.Any(), gomock.Any()).Times(2).Return(day(7), nil)
+	// 계좌 1은 3월 8일에 실패해서 3월 9일은 다음 실행으로 미룬다.
+	store.EXPECT().
+		AccrueInterestTx(gomock.Any(), gomock.Eq(db.AccrueInterestTxParams{AccountID: 1, Date: day(8)})).
+		Times(1).
+		Return(db.InterestAccrual{}, sql.ErrConnDone)
+	store.EXPECT().
+		AccrueInterestTx(gomock.Any(), gomock.Eq(db.AccrueInterestTxParams{AccountID: 1, Date: day(9)})).
+		Times(0)
+	// 앞의 계좌가 실패해도 다음 계좌의 이자는 쌓인다.
+	store.EXPECT().
+		AccrueInterestTx(gomock.Any(), gomock.Eq(db.AccrueInterestTxParams{AccountID: 2, Date: day(8)})).
+		Times(1).
+		Return(db.InterestAccrual{AccountID: 2}, nil)
+	store.EXPECT().
+		AccrueInterestTx(gomock.Any(), gomock.Eq(db.AccrueInterestTxParams{AccountID: 2, Date: day(9)})).
+		Times(1).
+		Return(db.InterestAccrual{AccountID: 2}, nil)
+
+	accruer := NewInterestAccruer(store)
+	accruer.now = func() time.Time { return now }
+
+	err := accruer.Run(context.Background())
+	require.ErrorIs(t, err, sql.ErrConnDone)
+	require.Contains(t, err.Error(), "1 of 2 interest accruals failed")
+}
+
+func TestInterestPoster(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	before := time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC)
+	store := mockdb.NewMockStore(mockController)
+	store.EXPECT().ListAccountsWithUnpostedInterest(gomock.Any(), gomock.Eq(before)).Times(1).Return([]int64{1, 2, 3}, nil)
+	store.EXPECT().
+		PostInterestTx(gomock.Any(), gomock.Eq(db.PostInterestTxParams{AccountID: 1, Period: "2022-03", Before: before})).
+		Times(1).
+		Return(db.PostInterestTxResult{}, nil)
+	// 이미 지급했다.
+	store.EXPECT().
+		PostInterestTx(gomock.Any(), gomock.Eq(db.PostInterestTxParams{AccountID: 2, Period: "2022-03", Before: before})).
+		Times(1).
+		Return(db.PostInterestTxResult{}, sql.ErrNoRows)
+	store.EXPECT().
+		PostInterestTx(gomock.Any(), gomock.Eq(db.PostInterestTxParams{AccountID: 3, Period: "2022-03", Before: before})).
+		Times(1).
+		Return(db.PostInterestTxResult{}, fmt.Errorf("%w: account 3 is frozen", db.ErrAccountNotActive))
+
+	poster := NewInterestPoster(store)
+	poster.now = func() time.Time { return time.Date(2022, 4, 2, 2, 0, 0, 0, time.UTC) }
+	require.NoError(t, poster.Run(context.Background()))
+}
+
+func TestInterestPosterContinuesAfterError(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	before := time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC)
+	store := mockdb.NewMockStore(mockController)
+	store.EXPECT().ListAccountsWithUnpostedInterest(gomock.Any(), gomock.Eq(before)).Times(1).Return([]int64{1, 2}, nil)
+	store.EXPECT().
+		PostInterestTx(gomock.Any(), gomock.Eq(db.PostInterestTxParams{AccountID: 1, Period: "2022-03", Before: before})).
+		Times(1).
+		Return(db.PostInterestTxResult{}, sql.ErrConnDone)
+	// 앞의 계좌가 실패해도 다음 계좌에는 지급한다.
+	store.EXPECT().
+		PostInterestTx(gomock.Any(), gomock.Eq(db.PostInterestTxParams{AccountID: 2, Period: "2022-03", Before: before})).
+		Times(1).
+		Return(db.PostInterestTxResult{}, nil)
+
+	poster := NewInterestPoster(store)
+	poster.now = func() time.Time { return time.Date(2022, 4, 2, 2, 0, 0, 0, time.UTC) }
+
+	err := poster.Run(context.Background())
+	require.ErrorIs(t, err, sql.ErrConnDone)
+	require.Contains(t, err.Error(), "1 of 2 interest postings failed")
+}
+
+func TestInterestPosterWaitsForLastAccrual(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	// 4월 1일에는 아직 3월 31일의 이자가 쌓이지 않았을 수 있으므로 2월분을 찾는다.
+	store := mockdb.NewMockStore(mockController)
+	store.EXPECT().
+		ListAccountsWithUnpostedInterest(gomock.Any(), gomock.Eq(time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC))).
+		Times(1).
+		Return([]int64{}, nil)
+	store.EXPECT().PostInterestTx(gomock.Any(), gomock.Any()).Times(0)
+
+	poster := NewInterestPoster(store)
+	poster.now = func() time.Time { return time.Date(2022, 4, 1, 12, 0, 0, 0, time.UTC) }
+	require.NoError(t, poster.Run(context.Background()))
+}
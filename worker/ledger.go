@@ -0,0 +1,44 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+)
+
+// LedgerPoster applies pending ledger account entries to the ledger account balances.
+type LedgerPoster struct {
+	store db.Store
+}
+
+func NewLedgerPoster(store db.Store) *LedgerPoster {
+	return &LedgerPoster{store: store}
+}
+
+// ledger account마다 pending entry가 남지 않을 때까지 batch 단위로 반영한다.
+func (poster *LedgerPoster) Run(ctx context.Context) error {
+	ledgerAccountIDs, err := poster.store.ListPendingLedgerAccountIDs(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot list ledger accounts with pending entries: %w", err)
+	}
+
+	posted := 0
+	for _, ledgerAccountID := range ledgerAccountIDs {
+		for {
+			result, err := poster.store.PostLedgerEntriesTx(ctx, ledgerAccountID)
+			if err != nil {
+				return fmt.Errorf("cannot post entries of ledger account %d: %w", ledgerAccountID, err)
+			}
+			posted += len(result.Entries)
+			if len(result.Entries) == 0 {
+				break
+			}
+		}
+	}
+	if posted > 0 {
+		log.Printf("posted %d ledger entries", posted)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/gyu-young-park/simplebank/db/mock"
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLedgerPoster(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	store := mockdb.NewMockStore(mockController)
+	store.EXPECT().
+		ListPendingLedgerAccountIDs(gomock.Any()).
+		Times(1).
+		Return([]int64{1, 2}, nil)
+	// 1번은 batch 두 번에 걸쳐 반영되고, 2번은 한 번에 끝난다.
+	gomock.InOrder(
+		store.EXPECT().
+			PostLedgerEntriesTx(gomock.Any(), gomock.Eq(int64(1))).
+			Return(db.PostLedgerEntriesTxResult{Entries: []db.Entry{{ID: 1}, {ID: 2}}}, nil),
+		store.EXPECT().
+			PostLedgerEntriesTx(gomock.Any(), gomock.Eq(int64(1))).
+			Return(db.PostLedgerEntriesTxResult{}, nil),
+		store.EXPECT().
+			PostLedgerEntriesTx(gomock.Any(), gomock.Eq(int64(2))).
+			Return(db.PostLedgerEntriesTxResult{Entries: []db.Entry{{ID: 3}}}, nil),
+		store.EXPECT().
+			PostLedgerEntriesTx(gomock.Any(), gomock.Eq(int64(2))).
+			Return(db.PostLedgerEntriesTxResult{}, nil),
+	)
+
+	require.NoError(t, NewLedgerPoster(store).Run(context.Background()))
+}
+
+func TestLedgerPosterError(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	store := mockdb.NewMockStore(mockController)
+	store.EXPECT().
+		ListPendingLedgerAccountIDs(gomock.Any()).
+		Times(1).
+		Return([]int64{1}, nil)
+	store.EXPECT().
+		PostLedgerEntriesTx(gomock.Any(), gomock.Eq(int64(1))).
+		Times(1).
+		Return(db.PostLedgerEntriesTxResult{}, db.ErrTxConflict)
+
+	err := NewLedgerPoster(store).Run(context.Background())
+	require.True(t, errors.Is(err, db.ErrTxConflict))
+}
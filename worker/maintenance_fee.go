@@ -0,0 +1,73 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/gyu-young-park/simplebank/statement"
+)
+
+// MaintenanceFeeCharger charges last month's maintenance fee to every account whose product has one.
+type MaintenanceFeeCharger struct {
+	store db.Store
+	now   func() time.Time
+}
+
+func NewMaintenanceFeeCharger(store db.Store) *MaintenanceFeeCharger {
+	return &MaintenanceFeeCharger{
+		store: store,
+		now:   time.Now,
+	}
+}
+
+// 계좌마다 한 달에 한 번만 청구하므로 여러 번 실행되어도 된다.
+func (charger *MaintenanceFeeCharger) Run(ctx context.Context) error {
+	period := statement.PreviousPeriod(charger.now().Add(-snapshotDelay))
+	_, to, err := statement.ParsePeriod(period)
+	if err != nil {
+		return err
+	}
+
+	// 그 달이 끝난 뒤에 만들어진 계좌에는 청구하지 않는다.
+	accountIDs, err := charger.store.ListMaintenanceFeeAccounts(ctx, to)
+	if err != nil {
+		return fmt.Errorf("cannot list accounts with a maintenance fee: %w", err)
+	}
+
+	charged, waived := 0, 0
+	failed := 0
+	var firstErr error
+	for _, accountID := range accountIDs {
+		result, err := charger.store.ChargeMaintenanceFeeTx(ctx, db.ChargeMaintenanceFeeTxParams{
+			AccountID: accountID,
+			Period:    period,
+		})
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			log.Printf("cannot charge maintenance fee of account %d: %v", accountID, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			failed++
+			continue
+		}
+		if result.Charge.JournalID.Valid {
+			charged++
+		} else {
+			waived++
+		}
+	}
+	if charged+waived > 0 {
+		log.Printf("charged %s maintenance fees to %d accounts, waived %d", period, charged, waived)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d maintenance fees failed, first: %w", failed, len(accountIDs), firstErr)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/gyu-young-park/simplebank/db/mock"
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenanceFeeCharger(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	to := time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC)
+	store := mockdb.NewMockStore(mockController)
+	store.EXPECT().ListMaintenanceFeeAccounts(gomock.Any(), gomock.Eq(to)).Times(1).Return([]int64{1, 2, 3}, nil)
+	store.EXPECT().
+		ChargeMaintenanceFeeTx(gomock.Any(), gomock.Eq(db.ChargeMaintenanceFeeTxParams{AccountID: 1, Period: "2022-03"})).
+		Times(1).
+		Return(db.ChargeMaintenanceFeeTxResult{Charge: db.MaintenanceFeeCharge{JournalID: sql.NullInt64{Int64: 5, Valid: true}}}, nil)
+	// 이미 청구했다.
+	store.EXPECT().
+		ChargeMaintenanceFeeTx(gomock.Any(), gomock.Eq(db.ChargeMaintenanceFeeTxParams{AccountID: 2, Period: "2022-03"})).
+		Times(1).
+		Return(db.ChargeMaintenanceFeeTxResult{}, sql.ErrNoRows)
+	// 잔액이 모자라서 면제되었다.
+	store.EXPECT().
+		ChargeMaintenanceFeeTx(gomock.Any(), gomock.Eq(db.ChargeMaintenanceFeeTxParams{AccountID: 3, Period: "2022-03"})).
+		Times(1).
+		Return(db.ChargeMaintenanceFeeTxResult{Charge: db.MaintenanceFeeCharge{Amount: 500}}, nil)
+
+	charger := NewMaintenanceFeeCharger(store)
+	charger.now = func() time.Time { return time.Date(2022, 4, 1, 2, 0, 0, 0, time.UTC) }
+	require.NoError(t, charger.Run(context.Background()))
+}
+
+func TestMaintenanceFeeChargerContinuesAfterError(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	to := time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC)
+	store := mockdb.NewMockStore(mockController)
+	store.EXPECT().ListMaintenanceFeeAccounts(gomock.Any(), gomock.Eq(to)).Times(1).Return([]int64{1, 2}, nil)
+	store.EXPECT().
+		ChargeMaintenanceFeeTx(gomock.Any(), gomock.Eq(db.ChargeMaintenanceFeeTxParams{AccountID: 1, Period: "2022-03"})).
+		Times(1).
+		Return(db.ChargeMaintenanceFeeTxResult{}, sql.ErrConnDone)
+	// 앞의 계좌가 실패해도 다음 계좌에는 청구한다.
+	store.EXPECT().
+		ChargeMaintenanceFeeTx(gomock.Any(), gomock.Eq(db.ChargeMaintenanceFeeTxParams{AccountID: 2, Period: "2022-03"})).
+		Times(1).
+		Return(db.ChargeMaintenanceFeeTxResult{Charge: db.MaintenanceFeeCharge{JournalID: sql.NullInt64{Int64: 6, Valid: true}}}, nil)
+
+	charger := NewMaintenanceFeeCharger(store)
+	charger.now = func() time.Time { return time.Date(2022, 4, 1, 2, 0, 0, 0, time.UTC) }
+
+	err := charger.Run(context.Background())
+	require.ErrorIs(t, err, sql.ErrConnDone)
+	require.Contains(t, err.Error(), "1 of 2 maintenance fees failed")
+}
@@ -0,0 +1,19 @@
+package worker
+
+import (
+	"context"
+	"log"
+)
+
+// Notifier sends a message to a user. 메일, 푸시 등 실제 채널은 구현체가 결정한다.
+type Notifier interface {
+	Notify(ctx context.Context, username string, message string) error
+}
+
+// LogNotifier writes notifications to the application log.
+type LogNotifier struct{}
+
+func (LogNotifier) Notify(ctx context.Context, username string, message string) error {
+	log.Printf("notify %s: %s", username, message)
+	return nil
+}
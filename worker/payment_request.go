@@ -0,0 +1,42 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+)
+
+// PaymentRequestExpirer expires pending payment requests and tells the requester.
+type PaymentRequestExpirer struct {
+	store    db.Store
+	notifier Notifier
+	now      func() time.Time
+}
+
+func NewPaymentRequestExpirer(store db.Store, notifier Notifier) *PaymentRequestExpirer {
+	return &PaymentRequestExpirer{
+		store:    store,
+		notifier: notifier,
+		now:      time.Now,
+	}
+}
+
+func (expirer *PaymentRequestExpirer) Run(ctx context.Context) error {
+	requests, err := expirer.store.ExpirePaymentRequests(ctx, expirer.now())
+	if err != nil {
+		return fmt.Errorf("cannot expire payment requests: %w", err)
+	}
+
+	for _, request := range requests {
+		message := fmt.Sprintf(
+			"payment request %d for %d %s to %s expired without being paid",
+			request.ID, request.Amount, request.Currency, request.Payer,
+		)
+		if err := expirer.notifier.Notify(ctx, request.Requester, message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
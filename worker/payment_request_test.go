@@ -0,0 +1,42 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/gyu-young-park/simplebank/db/mock"
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/gyu-young-park/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaymentRequestExpirer(t *testing.T) {
+	now := time.Now()
+	request := db.PaymentRequest{
+		ID:        util.RandomInt(1, 1000),
+		Requester: util.RandomOwner(),
+		Payer:     util.RandomOwner(),
+		Amount:    util.RandomMoney(),
+		Currency:  util.RandomCurrency(),
+		Status:    db.PaymentRequestStatusExpired,
+	}
+
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	store := mockdb.NewMockStore(mockController)
+	store.EXPECT().
+		ExpirePaymentRequests(gomock.Any(), gomock.Eq(now)).
+		Times(1).
+		Return([]db.PaymentRequest{request}, nil)
+
+	notifier := &fakeNotifier{messages: make(map[string][]string)}
+	expirer := NewPaymentRequestExpirer(store, notifier)
+	expirer.now = func() time.Time { return now }
+
+	require.NoError(t, expirer.Run(context.Background()))
+	require.Len(t, notifier.messages[request.Requester], 1)
+	require.Empty(t, notifier.messages[request.Payer])
+}
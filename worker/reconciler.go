@@ -0,0 +1,157 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+)
+
+// reconcilerName은 reconciliation이 계좌를 freeze했을 때 account status history에 남는 이름이다.
+const reconcilerName = "reconciliation"
+
+const (
+	DiscrepancyAccountBalance       = "account_balance"
+	DiscrepancyLedgerAccountBalance = "ledger_account_balance"
+	DiscrepancyTransferEntries      = "transfer_entries"
+)
+
+// Discrepancy is one thing the ledger disagrees with itself about.
+// expected는 entries에서 계산한 값이고 actual은 저장된 값이다.
+type Discrepancy struct {
+	Kind            string `json:"kind"`
+	AccountID       int64  `json:"account_id,omitempty"`
+	LedgerAccountID int64  `json:"ledger_account_id,omitempty"`
+	TransferID      int64  `json:"transfer_id,omitempty"`
+	Expected        int64  `json:"expected"`
+	Actual          int64  `json:"actual"`
+	Detail          string `json:"detail"`
+}
+
+// ReconciliationReport is the machine-readable result of a reconciliation run.
+type ReconciliationReport struct {
+	RunID            int64         `json:"run_id"`
+	StartedAt        time.Time     `json:"started_at"`
+	AccountsChecked  int64         `json:"accounts_checked"`
+	TransfersChecked int64         `json:"transfers_checked"`
+	LegacyTransfers  int64         `json:"legacy_transfers"`
+	Discrepancies    []Discrepancy `json:"discrepancies"`
+	FrozenAccounts   []int64       `json:"frozen_accounts"`
+}
+
+// Reconciler checks that balances match their entries and that every transfer has its two entries.
+type Reconciler struct {
+	store db.Store
+	// true면 잔액이 entries와 맞지 않는 계좌를 frozen으로 바꾼다.
+	freeze bool
+	now    func() time.Time
+}
+
+func NewReconciler(store db.Store, freeze bool) *Reconciler {
+	return &Reconciler{
+		store:  store,
+		freeze: freeze,
+		now:    time.Now,
+	}
+}
+
+func (reconciler *Reconciler) Run(ctx context.Context) error {
+	report, err := reconciler.Reconcile(ctx)
+	if err != nil {
+		return err
+	}
+	if len(report.Discrepancies) > 0 {
+		log.Printf("reconciliation run %d found %d discrepancies, froze %d accounts",
+			report.RunID, len(report.Discrepancies), len(report.FrozenAccounts))
+	}
+	return nil
+}
+
+// Reconcile runs one reconciliation and records its report in reconciliation_runs.
+func (reconciler *Reconciler) Reconcile(ctx context.Context) (ReconciliationReport, error) {
+	report := ReconciliationReport{
+		StartedAt:      reconciler.now(),
+		Discrepancies:  []Discrepancy{},
+		FrozenAccounts: []int64{},
+	}
+
+	result, err := reconciler.store.ReconcileTx(ctx)
+	if err != nil {
+		return report, fmt.Errorf("cannot reconcile: %w", err)
+	}
+	report.AccountsChecked = result.AccountsChecked
+	report.TransfersChecked = result.TransfersChecked
+	report.LegacyTransfers = result.LegacyTransfers
+
+	for _, mismatch := range result.AccountMismatches {
+		report.Discrepancies = append(report.Discrepancies, Discrepancy{
+			Kind:      DiscrepancyAccountBalance,
+			AccountID: mismatch.ID,
+			Expected:  mismatch.EntriesTotal,
+			Actual:    mismatch.Balance,
+			Detail:    "account balance does not match the sum of its entries",
+		})
+	}
+	for _, mismatch := range result.LedgerAccountMismatches {
+		report.Discrepancies = append(report.Discrepancies, Discrepancy{
+			Kind:            DiscrepancyLedgerAccountBalance,
+			LedgerAccountID: mismatch.ID,
+			Expected:        mismatch.EntriesTotal,
+			Actual:          mismatch.Balance,
+			Detail:          fmt.Sprintf("%s %s balance does not match the sum of its entries", mismatch.Code, mismatch.Currency),
+		})
+	}
+	for _, mismatch := range result.TransferMismatches {
+		report.Discrepancies = append(report.Discrepancies, Discrepancy{
+			Kind:       DiscrepancyTransferEntries,
+			TransferID: mismatch.ID,
+			Expected:   mismatch.Amount,
+			Actual:     mismatch.ToAmount,
+			Detail: fmt.Sprintf(
+				"want 2 entries of -%d and %d, got %d entries of %d and %d",
+				mismatch.Amount, mismatch.Amount, mismatch.EntryCount, mismatch.FromAmount, mismatch.ToAmount,
+			),
+		})
+	}
+
+	if reconciler.freeze {
+		for _, mismatch := range result.AccountMismatches {
+			_, err := reconciler.store.ChangeAccountStatusTx(ctx, db.ChangeAccountStatusTxParams{
+				AccountID: mismatch.ID,
+				ToStatus:  db.AccountStatusFrozen,
+				Reason:    "balance does not match the sum of its entries",
+				ChangedBy: reconcilerName,
+			})
+			if errors.Is(err, db.ErrInvalidAccountTransition) {
+				// 이미 frozen이거나 closed인 계좌이다.
+				continue
+			}
+			if err != nil {
+				return report, fmt.Errorf("cannot freeze account %d: %w", mismatch.ID, err)
+			}
+			report.FrozenAccounts = append(report.FrozenAccounts, mismatch.ID)
+		}
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		return report, err
+	}
+	run, err := reconciler.store.CreateReconciliationRun(ctx, db.CreateReconciliationRunParams{
+		AccountsChecked:  report.AccountsChecked,
+		TransfersChecked: report.TransfersChecked,
+		DiscrepancyCount: int32(len(report.Discrepancies)),
+		FrozenCount:      int32(len(report.FrozenAccounts)),
+		Report:           data,
+		StartedAt:        report.StartedAt,
+	})
+	if err != nil {
+		return report, fmt.Errorf("cannot record reconciliation run: %w", err)
+	}
+	report.RunID = run.ID
+	return report, nil
+}
@@ -0,0 +1,90 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/gyu-young-park/simplebank/db/mock"
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconciler(t *testing.T) {
+	now := time.Now()
+	result := db.Reconciliation{
+		AccountsChecked:  10,
+		TransfersChecked: 20,
+		AccountMismatches: []db.ListAccountBalanceMismatchesRow{
+			{ID: 1, Balance: 100, EntriesTotal: 90},
+			{ID: 2, Balance: 50, EntriesTotal: 0},
+		},
+		TransferMismatches: []db.ListTransferEntryMismatchesRow{
+			{ID: 7, Amount: 10, EntryCount: 1, FromAmount: -10},
+		},
+	}
+
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	store := mockdb.NewMockStore(mockController)
+	store.EXPECT().ReconcileTx(gomock.Any()).Times(1).Return(result, nil)
+	store.EXPECT().
+		ChangeAccountStatusTx(gomock.Any(), gomock.Eq(db.ChangeAccountStatusTxParams{
+			AccountID: 1,
+			ToStatus:  db.AccountStatusFrozen,
+			Reason:    "balance does not match the sum of its entries",
+			ChangedBy: "reconciliation",
+		})).
+		Times(1).
+		Return(db.ChangeAccountStatusTxResult{Account: db.Account{ID: 1, Status: db.AccountStatusFrozen}}, nil)
+	// 이미 frozen인 계좌는 건너뛴다.
+	store.EXPECT().
+		ChangeAccountStatusTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(db.ChangeAccountStatusTxResult{}, fmt.Errorf("%w: frozen to frozen", db.ErrInvalidAccountTransition))
+	store.EXPECT().
+		CreateReconciliationRun(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.CreateReconciliationRunParams) (db.ReconciliationRun, error) {
+			require.Equal(t, int64(10), arg.AccountsChecked)
+			require.Equal(t, int32(3), arg.DiscrepancyCount)
+			require.Equal(t, int32(1), arg.FrozenCount)
+			require.Equal(t, now, arg.StartedAt)
+
+			var report ReconciliationReport
+			require.NoError(t, json.Unmarshal(arg.Report, &report))
+			require.Len(t, report.Discrepancies, 3)
+			return db.ReconciliationRun{ID: 5}, nil
+		})
+
+	reconciler := NewReconciler(store, true)
+	reconciler.now = func() time.Time { return now }
+
+	report, err := reconciler.Reconcile(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(5), report.RunID)
+	require.Equal(t, []int64{1}, report.FrozenAccounts)
+	require.Equal(t, DiscrepancyAccountBalance, report.Discrepancies[0].Kind)
+	require.Equal(t, int64(90), report.Discrepancies[0].Expected)
+	require.Equal(t, int64(100), report.Discrepancies[0].Actual)
+	require.Equal(t, DiscrepancyTransferEntries, report.Discrepancies[2].Kind)
+	require.Equal(t, int64(7), report.Discrepancies[2].TransferID)
+}
+
+func TestReconcilerWithoutFreeze(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	store := mockdb.NewMockStore(mockController)
+	store.EXPECT().ReconcileTx(gomock.Any()).Times(1).Return(db.Reconciliation{
+		AccountMismatches: []db.ListAccountBalanceMismatchesRow{{ID: 1, Balance: 100}},
+	}, nil)
+	store.EXPECT().ChangeAccountStatusTx(gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().CreateReconciliationRun(gomock.Any(), gomock.Any()).Times(1).Return(db.ReconciliationRun{ID: 1}, nil)
+
+	require.NoError(t, NewReconciler(store, false).Run(context.Background()))
+}
@@ -0,0 +1,51 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Job은 scheduler가 주기적으로 실행하는 작업이다.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Scheduler runs background jobs such as standing orders on a fixed interval.
+type Scheduler struct {
+	jobs []Job
+}
+
+func NewScheduler() *Scheduler {
+	return &Scheduler{}
+}
+
+func (scheduler *Scheduler) Add(job Job) {
+	scheduler.jobs = append(scheduler.jobs, job)
+}
+
+// Start runs every job in its own goroutine until ctx is cancelled.
+// job이 실패해도 다음 주기에 다시 실행되므로 에러는 로그만 남긴다.
+func (scheduler *Scheduler) Start(ctx context.Context) {
+	for _, job := range scheduler.jobs {
+		go runJob(ctx, job)
+	}
+}
+
+func runJob(ctx context.Context, job Job) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := job.Run(ctx); err != nil {
+			log.Printf("job %s failed: %v", job.Name, err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/gyu-young-park/simplebank/util"
+)
+
+const standingOrderBatchSize = 100
+
+// StandingOrderProcessor materializes due standing orders into transfers.
+type StandingOrderProcessor struct {
+	store         db.Store
+	notifier      Notifier
+	maxRetries    int32
+	retryInterval time.Duration
+	now           func() time.Time
+}
+
+func NewStandingOrderProcessor(config util.Config, store db.Store, notifier Notifier) *StandingOrderProcessor {
+	return &StandingOrderProcessor{
+		store:         store,
+		notifier:      notifier,
+		maxRetries:    config.StandingOrderMaxRetries,
+		retryInterval: config.StandingOrderRetryInterval,
+		now:           time.Now,
+	}
+}
+
+func (processor *StandingOrderProcessor) Run(ctx context.Context) error {
+	now := processor.now()
+	orders, err := processor.store.ListDueStandingOrders(ctx, db.ListDueStandingOrdersParams{
+		NextRunAt:     now,
+		LastAttemptAt: sql.NullTime{Time: now.Add(-processor.retryInterval), Valid: true},
+		Limit:         standingOrderBatchSize,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot list due standing orders: %w", err)
+	}
+
+	// 한 standing order가 실패해도 나머지는 계속 처리하고, 실패한 것은 마지막에 모아서 돌려준다.
+	failed := 0
+	var firstErr error
+	for _, order := range orders {
+		if err := processor.execute(ctx, order, now); err != nil {
+			log.Printf("cannot process standing order %d: %v", order.ID, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d standing orders failed, first: %w", failed, len(orders), firstErr)
+	}
+	return nil
+}
+
+// 실패한 회차는 retryInterval마다 다시 시도하고, maxRetries를 넘기면 건너뛴 뒤 owner에게 알린다.
+func (processor *StandingOrderProcessor) execute(ctx context.Context, order db.StandingOrder, now time.Time) error {
+	_, runErr := processor.store.ExecuteStandingOrderTx(ctx, db.ExecuteStandingOrderTxParams{
+		StandingOrderID: order.ID,
+		Now:             now,
+	})
+	if runErr == nil || errors.Is(runErr, db.ErrStandingOrderNotDue) {
+		return nil
+	}
+
+	failed, err := processor.store.RecordStandingOrderFailure(ctx, db.RecordStandingOrderFailureParams{
+		ID:            order.ID,
+		LastAttemptAt: sql.NullTime{Time: now, Valid: true},
+		LastError:     runErr.Error(),
+	})
+	if err != nil {
+		return fmt.Errorf("cannot record failure of standing order %d: %w", order.ID, err)
+	}
+	if failed.FailedAttempts <= processor.maxRetries {
+		return nil
+	}
+
+	_, err = processor.store.UpdateStandingOrderSchedule(ctx, db.NextStandingOrderSchedule(failed))
+	if err != nil {
+		return fmt.Errorf("cannot skip standing order %d: %w", order.ID, err)
+	}
+
+	message := fmt.Sprintf(
+		"standing order %d scheduled at %s failed after %d attempts: %s",
+		order.ID, failed.NextRunAt.Format(time.RFC3339), failed.FailedAttempts, runErr,
+	)
+	return processor.notifier.Notify(ctx, order.Owner, message)
+}
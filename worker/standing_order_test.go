@@ -0,0 +1,190 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/gyu-young-park/simplebank/db/mock"
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/gyu-young-park/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeNotifier struct {
+	messages map[string][]string
+}
+
+func (notifier *fakeNotifier) Notify(ctx context.Context, username string, message string) error {
+	notifier.messages[username] = append(notifier.messages[username], message)
+	return nil
+}
+
+func randomStandingOrder(nextRunAt time.Time) db.StandingOrder {
+	return db.StandingOrder{
+		ID:            util.RandomInt(1, 1000),
+		Owner:         util.RandomOwner(),
+		FromAccountID: util.RandomInt(1, 1000),
+		ToAccountID:   util.RandomInt(1, 1000),
+		Amount:        util.RandomMoney(),
+		Frequency:     util.Daily,
+		StartDate:     nextRunAt,
+		NextRunAt:     nextRunAt,
+		Status:        db.StandingOrderStatusActive,
+	}
+}
+
+func TestStandingOrderProcessor(t *testing.T) {
+	now := time.Now()
+	config := util.Config{
+		StandingOrderMaxRetries:    2,
+		StandingOrderRetryInterval: time.Hour,
+	}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore, order db.StandingOrder)
+		checkNotified func(t *testing.T, notifier *fakeNotifier, order db.StandingOrder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore, order db.StandingOrder) {
+				store.EXPECT().
+					ExecuteStandingOrderTx(gomock.Any(), gomock.Eq(db.ExecuteStandingOrderTxParams{StandingOrderID: order.ID, Now: now})).
+					Times(1).
+					Return(db.ExecuteStandingOrderTxResult{}, nil)
+				store.EXPECT().RecordStandingOrderFailure(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkNotified: func(t *testing.T, notifier *fakeNotifier, order db.StandingOrder) {
+				require.Empty(t, notifier.messages)
+			},
+		},
+		{
+			name: "RetryLater",
+			buildStubs: func(store *mockdb.MockStore, order db.StandingOrder) {
+				store.EXPECT().
+					ExecuteStandingOrderTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ExecuteStandingOrderTxResult{}, db.ErrInsufficientFunds)
+
+				failed := order
+				failed.FailedAttempts = 2
+				store.EXPECT().
+					RecordStandingOrderFailure(gomock.Any(), gomock.Eq(db.RecordStandingOrderFailureParams{
+						ID:            order.ID,
+						LastAttemptAt: sql.NullTime{Time: now, Valid: true},
+						LastError:     db.ErrInsufficientFunds.Error(),
+					})).
+					Times(1).
+					Return(failed, nil)
+				store.EXPECT().UpdateStandingOrderSchedule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkNotified: func(t *testing.T, notifier *fakeNotifier, order db.StandingOrder) {
+				require.Empty(t, notifier.messages)
+			},
+		},
+		{
+			name: "RetriesExhausted",
+			buildStubs: func(store *mockdb.MockStore, order db.StandingOrder) {
+				store.EXPECT().
+					ExecuteStandingOrderTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ExecuteStandingOrderTxResult{}, db.ErrInsufficientFunds)
+
+				failed := order
+				failed.FailedAttempts = 3
+				store.EXPECT().
+					RecordStandingOrderFailure(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(failed, nil)
+				store.EXPECT().
+					UpdateStandingOrderSchedule(gomock.Any(), gomock.Eq(db.NextStandingOrderSchedule(failed))).
+					Times(1).
+					Return(failed, nil)
+			},
+			checkNotified: func(t *testing.T, notifier *fakeNotifier, order db.StandingOrder) {
+				require.Len(t, notifier.messages[order.Owner], 1)
+			},
+		},
+		{
+			name: "AlreadyExecuted",
+			buildStubs: func(store *mockdb.MockStore, order db.StandingOrder) {
+				store.EXPECT().
+					ExecuteStandingOrderTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ExecuteStandingOrderTxResult{}, db.ErrStandingOrderNotDue)
+				store.EXPECT().RecordStandingOrderFailure(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkNotified: func(t *testing.T, notifier *fakeNotifier, order db.StandingOrder) {
+				require.Empty(t, notifier.messages)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			mockController := gomock.NewController(t)
+			defer mockController.Finish()
+
+			order := randomStandingOrder(now.Add(-time.Minute))
+			store := mockdb.NewMockStore(mockController)
+			store.EXPECT().
+				ListDueStandingOrders(gomock.Any(), gomock.Eq(db.ListDueStandingOrdersParams{
+					NextRunAt:     now,
+					LastAttemptAt: sql.NullTime{Time: now.Add(-config.StandingOrderRetryInterval), Valid: true},
+					Limit:         standingOrderBatchSize,
+				})).
+				Times(1).
+				Return([]db.StandingOrder{order}, nil)
+			tc.buildStubs(store, order)
+
+			notifier := &fakeNotifier{messages: make(map[string][]string)}
+			processor := NewStandingOrderProcessor(config, store, notifier)
+			processor.now = func() time.Time { return now }
+
+			err := processor.Run(context.Background())
+			require.NoError(t, err)
+			tc.checkNotified(t, notifier, order)
+		})
+	}
+}
+
+func TestStandingOrderProcessorContinuesAfterError(t *testing.T) {
+	now := time.Now()
+	config := util.Config{
+		StandingOrderMaxRetries:    2,
+		StandingOrderRetryInterval: time.Hour,
+	}
+	broken := randomStandingOrder(now.Add(-time.Minute))
+	order := randomStandingOrder(now.Add(-time.Minute))
+	order.ID = broken.ID + 1
+
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	store := mockdb.NewMockStore(mockController)
+	store.EXPECT().ListDueStandingOrders(gomock.Any(), gomock.Any()).Times(1).Return([]db.StandingOrder{broken, order}, nil)
+	store.EXPECT().
+		ExecuteStandingOrderTx(gomock.Any(), gomock.Eq(db.ExecuteStandingOrderTxParams{StandingOrderID: broken.ID, Now: now})).
+		Times(1).
+		Return(db.ExecuteStandingOrderTxResult{}, db.ErrInsufficientFunds)
+	store.EXPECT().
+		RecordStandingOrderFailure(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(db.StandingOrder{}, sql.ErrConnDone)
+	// 앞의 standing order가 실패해도 다음 것은 실행된다.
+	store.EXPECT().
+		ExecuteStandingOrderTx(gomock.Any(), gomock.Eq(db.ExecuteStandingOrderTxParams{StandingOrderID: order.ID, Now: now})).
+		Times(1).
+		Return(db.ExecuteStandingOrderTxResult{}, nil)
+
+	processor := NewStandingOrderProcessor(config, store, &fakeNotifier{messages: make(map[string][]string)})
+	processor.now = func() time.Time { return now }
+
+	err := processor.Run(context.Background())
+	require.ErrorIs(t, err, sql.ErrConnDone)
+	require.Contains(t, err.Error(), "1 of 2 standing orders failed")
+}
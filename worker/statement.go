@@ -0,0 +1,93 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/gyu-young-park/simplebank/statement"
+)
+
+// StatementGenerator stores last month's statement of every account.
+type StatementGenerator struct {
+	store db.Store
+	now   func() time.Time
+}
+
+func NewStatementGenerator(store db.Store) *StatementGenerator {
+	return &StatementGenerator{
+		store: store,
+		now:   time.Now,
+	}
+}
+
+// 이미 만들어진 statement는 건너뛰므로 여러 번 실행되어도 된다.
+func (generator *StatementGenerator) Run(ctx context.Context) error {
+	// snapshot과 같은 이유로 달이 바뀐 직후에는 아직 지난 달의 commit이 남아 있을 수 있다.
+	now := generator.now()
+	period := statement.PreviousPeriod(now.Add(-snapshotDelay))
+	_, to, err := statement.ParsePeriod(period)
+	if err != nil {
+		return err
+	}
+
+	accountIDs, err := generator.store.ListAccountIDs(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot list accounts: %w", err)
+	}
+
+	created := 0
+	for _, accountID := range accountIDs {
+		_, err := generator.store.GetStatement(ctx, db.GetStatementParams{
+			AccountID: accountID,
+			Period:    period,
+		})
+		if err == nil {
+			continue
+		}
+		if err != sql.ErrNoRows {
+			return fmt.Errorf("cannot get statement of account %d: %w", accountID, err)
+		}
+
+		account, err := generator.store.GetAccount(ctx, accountID)
+		if err != nil {
+			return fmt.Errorf("cannot get account %d: %w", accountID, err)
+		}
+		// 그 달이 끝난 뒤에 만들어진 계좌는 statement가 없다.
+		if !account.CreatedAt.Before(to) {
+			continue
+		}
+
+		result, err := statement.Generate(ctx, generator.store, account, period, now)
+		if err != nil {
+			return fmt.Errorf("cannot generate statement of account %d: %w", accountID, err)
+		}
+		data, err := json.Marshal(result)
+		if err != nil {
+			return err
+		}
+		_, err = generator.store.CreateStatement(ctx, db.CreateStatementParams{
+			AccountID:      account.ID,
+			Period:         period,
+			OpeningBalance: result.OpeningBalance,
+			ClosingBalance: result.ClosingBalance,
+			Data:           data,
+		})
+		if err == sql.ErrNoRows {
+			// 다른 인스턴스가 먼저 만들었다.
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("cannot store statement of account %d: %w", accountID, err)
+		}
+		created++
+	}
+	if created > 0 {
+		log.Printf("generated %d statements for %s", created, period)
+	}
+	return nil
+}
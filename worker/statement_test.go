@@ -0,0 +1,52 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/gyu-young-park/simplebank/db/mock"
+	db "github.com/gyu-young-park/simplebank/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatementGenerator(t *testing.T) {
+	now := time.Date(2022, 4, 2, 0, 0, 0, 0, time.UTC)
+	from := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	account := db.Account{ID: 1, Owner: "alice", CreatedAt: from.AddDate(0, -1, 0)}
+	newAccount := db.Account{ID: 3, Owner: "bob", CreatedAt: to.Add(time.Hour)}
+
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	store := mockdb.NewMockStore(mockController)
+	store.EXPECT().ListAccountIDs(gomock.Any()).Times(1).Return([]int64{1, 2, 3}, nil)
+	store.EXPECT().GetStatement(gomock.Any(), gomock.Eq(db.GetStatementParams{AccountID: 1, Period: "2022-03"})).Times(1).Return(db.Statement{}, sql.ErrNoRows)
+	// 이미 만들어진 statement는 건너뛴다.
+	store.EXPECT().GetStatement(gomock.Any(), gomock.Eq(db.GetStatementParams{AccountID: 2, Period: "2022-03"})).Times(1).Return(db.Statement{ID: 9}, nil)
+	store.EXPECT().GetStatement(gomock.Any(), gomock.Eq(db.GetStatementParams{AccountID: 3, Period: "2022-03"})).Times(1).Return(db.Statement{}, sql.ErrNoRows)
+
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(int64(1))).Times(1).Return(account, nil)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(int64(3))).Times(1).Return(newAccount, nil)
+	store.EXPECT().
+		AccountStatementTx(gomock.Any(), gomock.Eq(db.AccountStatementTxParams{AccountID: 1, From: from, To: to})).
+		Times(1).
+		Return(db.AccountStatementTxResult{OpeningBalance: 10, Entries: []db.ListStatementEntriesRow{{ID: 1, Amount: 5}}}, nil)
+	store.EXPECT().
+		CreateStatement(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.CreateStatementParams) (db.Statement, error) {
+			require.Equal(t, int64(1), arg.AccountID)
+			require.Equal(t, "2022-03", arg.Period)
+			require.Equal(t, int64(10), arg.OpeningBalance)
+			require.Equal(t, int64(15), arg.ClosingBalance)
+			return db.Statement{ID: 10}, nil
+		})
+
+	generator := NewStatementGenerator(store)
+	generator.now = func() time.Time { return now }
+	require.NoError(t, generator.Run(context.Background()))
+}